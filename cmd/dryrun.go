@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/fs"
+)
+
+var dryRun bool
+
+// treeNode represent a directory or a file inside the printed tree of dry run
+type treeNode struct {
+	name     string
+	status   string
+	children []*treeNode
+}
+
+// newFsService will return filesystem which keep every write in memory when --dry-run is given
+func newFsService() domain.FsService {
+	if dryRun {
+		return fs.NewDryRunFsService()
+	}
+	return fs.NewFsService()
+}
+
+// printDryRun will print tree of every file which would be written, followed by diff against the existing files
+func printDryRun(w io.Writer, newFs domain.FsService) {
+	changes := newFs.Changes()
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "Dry run, no file would be changed")
+		return
+	}
+
+	fmt.Fprintln(w, "Dry run, no file was written. These files would be changed:")
+	fmt.Fprint(w, fileTree(changes))
+
+	for _, c := range changes {
+		if c.Created {
+			continue
+		}
+		diff := fs.Diff(filepath.ToSlash(c.Name), c.Before, c.After)
+		if diff != "" {
+			fmt.Fprintln(w)
+			fmt.Fprint(w, diff)
+		}
+	}
+}
+
+// fileTree will render the changed files as a tree, rooted in the deepest directory which contains all of them
+func fileTree(changes []domain.FileChange) string {
+	var names []string
+	for _, c := range changes {
+		names = append(names, filepath.Clean(c.Name))
+	}
+
+	root := filepath.Dir(names[0])
+	for _, n := range names[1:] {
+		for root != "." && root != string(filepath.Separator) && !strings.HasPrefix(n, root+string(filepath.Separator)) {
+			root = filepath.Dir(root)
+		}
+	}
+
+	tree := &treeNode{name: root}
+	for i, c := range changes {
+		rel, err := filepath.Rel(root, names[i])
+		if err != nil {
+			rel = names[i]
+		}
+
+		node := tree
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		for _, p := range parts {
+			var child *treeNode
+			for _, ch := range node.children {
+				if ch.name == p {
+					child = ch
+					break
+				}
+			}
+			if child == nil {
+				child = &treeNode{name: p}
+				node.children = append(node.children, child)
+			}
+			node = child
+		}
+		node.status = changeStatus(c)
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, strings.TrimSuffix(tree.name, string(os.PathSeparator))+string(os.PathSeparator))
+	writeTree(buf, tree, "")
+	return buf.String()
+}
+
+func writeTree(buf *bytes.Buffer, node *treeNode, indent string) {
+	for i, ch := range node.children {
+		branch, next := "├── ", "│   "
+		if i == len(node.children)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		if len(ch.children) > 0 {
+			fmt.Fprintf(buf, "%s%s%s/\n", indent, branch, ch.name)
+			writeTree(buf, ch, indent+next)
+			continue
+		}
+		fmt.Fprintf(buf, "%s%s%s (%s)\n", indent, branch, ch.name, ch.status)
+	}
+}
+
+func changeStatus(c domain.FileChange) string {
+	switch {
+	case c.Removed:
+		return "removed"
+	case c.Created:
+		return "new"
+	case bytes.Equal(c.Before, c.After):
+		return "unchanged"
+	default:
+		return "modified"
+	}
+}
@@ -0,0 +1,981 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/fs"
+	"github.com/wicaker/cacli/generator"
+	"github.com/wicaker/cacli/manifest"
+	"github.com/wicaker/cacli/parser"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectPath string
+	generateCmd = &cobra.Command{
+		Use:     "generate",
+		Aliases: []string{"g"},
+		Short:   "Generate code inside an existing clean architecture project",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+	generateDomainCmd = &cobra.Command{
+		Use:     "domain [name]",
+		Aliases: []string{"d"},
+		Short:   "Generate a new domain with its usecase, repository and transport layer",
+		Args:    cobra.ExactArgs(1),
+		Run:     runGenerateDomain,
+	}
+	generateUsecaseCmd = &cobra.Command{
+		Use:     "usecase",
+		Aliases: []string{"u"},
+		Short:   "Generate usecase layer based on a domain file",
+		Args:    cobra.NoArgs,
+		Run:     runGenerateUsecase,
+	}
+	generateRepositoryCmd = &cobra.Command{
+		Use:     "repository",
+		Aliases: []string{"r"},
+		Short:   "Generate repository layer based on a domain file",
+		Args:    cobra.NoArgs,
+		Run:     runGenerateRepository,
+	}
+	generateTransportCmd = &cobra.Command{
+		Use:     "transport",
+		Aliases: []string{"t"},
+		Short:   "Generate transport layer based on a domain file",
+		Args:    cobra.NoArgs,
+		Run:     runGenerateTransport,
+	}
+	generateMigrationCmd = &cobra.Command{
+		Use:     "migration [domain]",
+		Aliases: []string{"m"},
+		Short:   "Generate up and down migration of a domain entity",
+		Args:    cobra.ExactArgs(1),
+		Run:     runGenerateMigration,
+	}
+	layerDomainFile  string
+	layerDbHelper    string
+	layerDialect     string
+	layerRestServer  string
+	layerGraphqlOpt  bool
+	layerGrpcOpt     bool
+	layerProblemJSON bool
+	forceWrite       bool
+	migrationFormat  string
+	domainNameRegexp = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+)
+
+func runGenerateDomain(cmd *cobra.Command, args []string) {
+	var (
+		domainName  = args[0]
+		newFs       = newFsService()
+		newGen      = generator.NewGeneratorService(newFs)
+		newManifest = manifest.NewManifestService(newFs)
+	)
+
+	if !domainNameRegexp.MatchString(domainName) {
+		failOnGenerateError(errors.New("name must start with lowercase letter and only contain letters or digits"), `validate domain name `+domainName)
+	}
+
+	prj, err := readProject(newFs, newManifest, projectPath)
+	failOnGenerateError(err, `read existing project `+projectPath)
+	if prj.DbHelper == "" {
+		failOnGenerateError(errors.New("database helper is unknown"), `read existing project `+projectPath)
+	}
+	newGen.Configure(domain.Generator{ProblemJSON: prj.ProblemJSON})
+
+	res, err := newFs.FindFile(projectPath + "/domain/" + domainName + ".go")
+	failOnGenerateError(err, `find existing domain `+domainName)
+	if res != nil {
+		failOnGenerateError(errors.New("domain `"+domainName+"` already exist"), `generate domain `+domainName)
+	}
+
+	// server files are generated from every domain of project, so the hand written changes are detected before the domain is added
+	previewServer := func() ([]domain.FileChange, error) {
+		return previewServers(projectPath, prj, domain.Generator{ProblemJSON: prj.ProblemJSON}, prj.RestServer, prj.GraphqlOpt, prj.GrpcOpt)
+	}
+	modified, err := modifiedServers(previewServer)
+	failOnGenerateError(err, `find server which was changed by hand`)
+
+	before, err := newFs.ListFiles(projectPath)
+	failOnGenerateError(err, `list existing files`)
+
+	// generate entity, usecase and repository contract inside domain
+	err = newGen.GenDomain(projectPath+"/domain", domainName)
+	failOnInitError(newFs, err, `generate domain file `+domainName)
+
+	par, err := generateLayers(newFs, newGen, projectPath, domainName, prj.GoModName, prj.DbHelper, prj.Dialect, prj.RestServer, prj.GraphqlOpt, prj.GrpcOpt)
+	failOnInitError(newFs, err, `generate layers of domain `+domainName)
+
+	// re-wire server so the new handler is registered, graphql schema serves every domain through a single handler
+	serverGen := generator.NewGeneratorService(newServerFsService(newFs, modified, previewServer))
+	serverGen.Configure(domain.Generator{ProblemJSON: prj.ProblemJSON})
+	_, err = generateServers(serverGen, projectPath, prj.GoModName, prj.DbHelper, prj.RestServer, prj.GraphqlOpt, prj.GrpcOpt, par)
+	failOnInitError(newFs, err, `re-wire server `)
+
+	addDomain(prj, domainName)
+	err = saveManifest(newFs, newManifest, projectPath, prj, before)
+	failOnInitError(newFs, err, `write manifest`)
+
+	if dryRun {
+		printDryRun(os.Stdout, newFs)
+		return
+	}
+	log.Info("Domain `" + domainName + "` was successfully generated !")
+}
+
+func runGenerateUsecase(cmd *cobra.Command, args []string) {
+	var (
+		baseFs      = newFsService()
+		newFs       = newLayerFsService(baseFs)
+		newGen      = generator.NewGeneratorService(newFs)
+		newManifest = manifest.NewManifestService(baseFs)
+	)
+
+	layer, err := readLayerInput(newFs, newManifest, projectPath, layerDomainFile)
+	failOnGenerateError(err, `read domain file `+layerDomainFile)
+
+	before, err := newFs.ListFiles(projectPath)
+	failOnGenerateError(err, `list existing files`)
+
+	// generated tests of every layer are built on the mocks, so they follow the domain file too
+	err = generateMock(newFs, newGen, projectPath, layer.manifest.GoModName, layer.parser)
+	failOnInitError(newFs, err, `generate mock of `+layer.domainName)
+
+	err = ensureDir(newFs, projectPath+"/usecase")
+	failOnInitError(newFs, err, `create usecase directory`)
+
+	err = generateUsecase(newGen, projectPath, layer.domainFile, layer.manifest.GoModName, layer.parser)
+	failOnInitError(newFs, err, `generate usecase of `+layer.domainName)
+
+	addDomain(layer.manifest, layer.domainName)
+	err = saveManifest(newFs, newManifest, projectPath, layer.manifest, before)
+	failOnInitError(newFs, err, `write manifest`)
+
+	if dryRun {
+		printDryRun(os.Stdout, newFs)
+		return
+	}
+	log.Info("Usecase of `" + layer.domainName + "` was successfully generated !")
+}
+
+func runGenerateRepository(cmd *cobra.Command, args []string) {
+	var (
+		baseFs      = newFsService()
+		newFs       = newLayerFsService(baseFs)
+		newGen      = generator.NewGeneratorService(newFs)
+		newManifest = manifest.NewManifestService(baseFs)
+	)
+
+	layer, err := readLayerInput(newFs, newManifest, projectPath, layerDomainFile)
+	failOnGenerateError(err, `read domain file `+layerDomainFile)
+
+	// use database helper of project if not specified
+	if layerDbHelper == "" {
+		layerDbHelper = layer.manifest.DbHelper
+	}
+	if layerDbHelper != domain.GoPg && layerDbHelper != domain.Gorm && layerDbHelper != domain.Sqlx && layerDbHelper != domain.SQL && layerDbHelper != domain.Mongod {
+		failOnGenerateError(errors.New("--db must be one of: gopg, gorm, sqlx, sql, mongod"), `validate database helper `+layerDbHelper)
+	}
+
+	// use sql dialect of project if not specified
+	if layerDialect == "" {
+		layerDialect = layer.manifest.Dialect
+	}
+	if layerDialect != "" && layerDialect != domain.MySQL && layerDialect != domain.Postgres {
+		failOnGenerateError(errors.New("--dialect must be one of: mysql, postgres"), `validate sql dialect `+layerDialect)
+	}
+
+	before, err := newFs.ListFiles(projectPath)
+	failOnGenerateError(err, `list existing files`)
+
+	// generated tests of every layer are built on the mocks, so they follow the domain file too
+	err = generateMock(newFs, newGen, projectPath, layer.manifest.GoModName, layer.parser)
+	failOnInitError(newFs, err, `generate mock of `+layer.domainName)
+
+	err = ensureDir(newFs, projectPath+"/repository")
+	failOnInitError(newFs, err, `create repository directory`)
+
+	err = generateRepository(newGen, projectPath, layer.domainFile, layer.manifest.GoModName, layerDbHelper, layerDialect, layer.parser)
+	failOnInitError(newFs, err, `generate repository of `+layer.domainName)
+
+	addDomain(layer.manifest, layer.domainName)
+	err = saveManifest(newFs, newManifest, projectPath, layer.manifest, before)
+	failOnInitError(newFs, err, `write manifest`)
+
+	if dryRun {
+		printDryRun(os.Stdout, newFs)
+		return
+	}
+	log.Info("Repository of `" + layer.domainName + "` was successfully generated !")
+}
+
+func runGenerateTransport(cmd *cobra.Command, args []string) {
+	var (
+		baseFs      = newFsService()
+		newFs       = newLayerFsService(baseFs)
+		newGen      = generator.NewGeneratorService(newFs)
+		newManifest = manifest.NewManifestService(baseFs)
+	)
+
+	layer, err := readLayerInput(newFs, newManifest, projectPath, layerDomainFile)
+	failOnGenerateError(err, `read domain file `+layerDomainFile)
+
+	// use transport of project if not specified
+	if layerRestServer == "" && !layerGraphqlOpt && !layerGrpcOpt {
+		layerRestServer = layer.manifest.RestServer
+		layerGraphqlOpt = layer.manifest.GraphqlOpt
+		layerGrpcOpt = layer.manifest.GrpcOpt
+	}
+	if layerRestServer == "" {
+		layerRestServer = "no"
+	}
+	if layerRestServer != "no" && layerRestServer != domain.Echo && layerRestServer != domain.Gin && layerRestServer != domain.GorillaMux && layerRestServer != domain.NetHTTP {
+		failOnGenerateError(errors.New("--rest must be one of: echo, gin, gorilla mux, net/http"), `validate rest server `+layerRestServer)
+	}
+	if layerRestServer == "no" && !layerGraphqlOpt && !layerGrpcOpt {
+		failOnGenerateError(errors.New("choose at least one of --rest, --graphql or --grpc"), `validate transport`)
+	}
+	if layerProblemJSON && layerRestServer == "no" {
+		failOnGenerateError(errors.New("--problem-json only applies to rest transport, choose --rest"), `validate transport`)
+	}
+
+	// problem details are chosen once for the whole project, so it is kept in manifest
+	if layerProblemJSON {
+		layer.manifest.ProblemJSON = true
+	}
+	newGen.Configure(domain.Generator{ProblemJSON: layer.manifest.ProblemJSON && layerRestServer != "no"})
+
+	// graphql server is generated from every domain of project, so the hand written changes are detected before the domain is added
+	previewServer := func() ([]domain.FileChange, error) {
+		return previewServers(projectPath, layer.manifest, domain.Generator{}, "no", true, false)
+	}
+	modified := map[string]bool{}
+	if layerGraphqlOpt && layer.manifest.GraphqlOpt {
+		modified, err = modifiedServers(previewServer)
+		failOnGenerateError(err, `find server which was changed by hand`)
+	}
+
+	before, err := newFs.ListFiles(projectPath)
+	failOnGenerateError(err, `list existing files`)
+
+	// generated tests of every layer are built on the mocks, so they follow the domain file too
+	err = generateMock(newFs, newGen, projectPath, layer.manifest.GoModName, layer.parser)
+	failOnInitError(newFs, err, `generate mock of `+layer.domainName)
+
+	if layerRestServer != "no" {
+		err = ensureDir(newFs, projectPath+"/transport/rest")
+		failOnInitError(newFs, err, `create transport/rest directory`)
+	}
+	if layerGraphqlOpt {
+		err = ensureDir(newFs, projectPath+"/transport/graphql")
+		failOnInitError(newFs, err, `create transport/graphql directory`)
+	}
+	if layerGrpcOpt {
+		err = ensureDir(newFs, projectPath+"/transport/grpc")
+		failOnInitError(newFs, err, `create transport/grpc directory`)
+		err = ensureDir(newFs, projectPath+"/proto")
+		failOnInitError(newFs, err, `create proto directory`)
+	}
+
+	err = generateValidation(newFs, newGen, projectPath)
+	failOnInitError(newFs, err, `generate validation of request`)
+
+	err = generateTransport(newGen, projectPath, layer.domainFile, layer.manifest.GoModName, layerRestServer, layerGraphqlOpt, layerGrpcOpt, layer.parser)
+	failOnInitError(newFs, err, `generate transport of `+layer.domainName)
+
+	if layer.manifest.ProblemJSON && layerRestServer != "no" {
+		err = generateProblem(newFs, newGen, projectPath, layer.manifest.GoModName, layerRestServer)
+		failOnInitError(newFs, err, `generate problem details`)
+	}
+
+	// graphql handler takes usecase of every served domain, so its server is re-wired along with the schema
+	if layerGraphqlOpt && layer.manifest.GraphqlOpt {
+		serverGen := generator.NewGeneratorService(newServerFsService(baseFs, modified, previewServer))
+		_, err = generateServers(serverGen, projectPath, layer.manifest.GoModName, layer.manifest.DbHelper, "no", true, false, layer.parser)
+		failOnInitError(newFs, err, `re-wire graphql server`)
+	}
+
+	addDomain(layer.manifest, layer.domainName)
+	err = saveManifest(newFs, newManifest, projectPath, layer.manifest, before)
+	failOnInitError(newFs, err, `write manifest`)
+
+	if dryRun {
+		printDryRun(os.Stdout, newFs)
+		return
+	}
+	log.Info("Transport of `" + layer.domainName + "` was successfully generated !")
+}
+
+func runGenerateMigration(cmd *cobra.Command, args []string) {
+	var (
+		domainName  = args[0]
+		newFs       = newFsService()
+		newGen      = generator.NewGeneratorService(newFs)
+		newManifest = manifest.NewManifestService(newFs)
+		domainPath  = projectPath + "/domain/" + domainName + ".go"
+	)
+
+	prj, err := readProject(newFs, newManifest, projectPath)
+	failOnGenerateError(err, `read existing project `+projectPath)
+
+	res, err := newFs.FindFile(domainPath)
+	failOnGenerateError(err, `find domain `+domainName)
+	if res == nil {
+		failOnGenerateError(errors.New(domainPath+" not found"), `find domain `+domainName)
+	}
+
+	// use sql dialect of project if not specified
+	if layerDialect == "" {
+		layerDialect = migrationDialect(prj.DbHelper, prj.Dialect)
+	}
+	if layerDialect != domain.MySQL && layerDialect != domain.Postgres {
+		failOnGenerateError(errors.New("--dialect must be one of: mysql, postgres"), `validate sql dialect `+layerDialect)
+	}
+
+	par, err := parseDomain(newFs, domainPath, domainName)
+	failOnGenerateError(err, `parse domain `+domainName)
+
+	before, err := newFs.ListFiles(projectPath)
+	failOnGenerateError(err, `list existing files`)
+
+	err = ensureDir(newFs, projectPath+"/database")
+	failOnGenerateError(err, `create database directory`)
+	err = ensureDir(newFs, projectPath+"/database/migrations")
+	failOnGenerateError(err, `create migrations directory`)
+
+	err = generateMigration(newGen, projectPath, domainName, prj, migrationFormat, layerDialect, time.Now(), par)
+	if err == domain.ErrNoMigration {
+		log.Info("Entity of `" + domainName + "` has no change since the last migration")
+		return
+	}
+	failOnGenerateError(err, `generate migration of `+domainName)
+
+	err = saveManifest(newFs, newManifest, projectPath, prj, before)
+	failOnGenerateError(err, `write manifest`)
+
+	if dryRun {
+		printDryRun(os.Stdout, newFs)
+		return
+	}
+	log.Info("Migration of `" + domainName + "` was successfully generated !")
+}
+
+// generateMigration will generate migration of the domain entity based on its snapshot in manifest,
+// then replace the snapshot so the next migration only contain the next changes
+func generateMigration(
+	newGen domain.GeneratorService,
+	path string,
+	domainName string,
+	prj *domain.Manifest,
+	format string,
+	dialect string,
+	now time.Time,
+	par *domain.Parser,
+) error {
+	table, err := generator.MigrationTable(domainName, prj.DbHelper, par.Entity)
+	if err != nil {
+		return err
+	}
+
+	var previous *domain.Table
+	for i := range prj.Tables {
+		if prj.Tables[i].Domain == domainName {
+			previous = &prj.Tables[i]
+		}
+	}
+
+	err = newGen.GenMigration(path+"/database/migrations", now.UTC().Format("20060102150405"), format, dialect, previous, table)
+	if err != nil {
+		return err
+	}
+
+	if previous != nil {
+		*previous = *table
+	} else {
+		prj.Tables = append(prj.Tables, *table)
+	}
+	return nil
+}
+
+// migrationDialect will return sql dialect of migration, go-pg and the generated gorm config only connect to postgres
+func migrationDialect(dbHelper string, dialect string) string {
+	if dialect != "" {
+		return dialect
+	}
+	if dbHelper == domain.GoPg || dbHelper == domain.Gorm {
+		return domain.Postgres
+	}
+	return domain.MySQL
+}
+
+// generateLayers will generate usecase, repository and transport layer of the given domain
+func generateLayers(
+	newFs domain.FsService,
+	newGen domain.GeneratorService,
+	path string,
+	domainName string,
+	goModName string,
+	dbHelper string,
+	dialect string,
+	restServer string,
+	graphqlOpt bool,
+	grpcOpt bool,
+) (*domain.Parser, error) {
+	domainFile := domainName + ".go"
+
+	// parse file in domain dir
+	par, err := parseDomain(newFs, path+"/domain/"+domainFile, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = generateMock(newFs, newGen, path, goModName, par)
+	if err != nil {
+		return nil, err
+	}
+
+	err = generateUsecase(newGen, path, domainFile, goModName, par)
+	if err != nil {
+		return nil, err
+	}
+
+	err = generateRepository(newGen, path, domainFile, goModName, dbHelper, dialect, par)
+	if err != nil {
+		return nil, err
+	}
+
+	err = generateValidation(newFs, newGen, path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = generateTransport(newGen, path, domainFile, goModName, restServer, graphqlOpt, grpcOpt, par)
+	if err != nil {
+		return nil, err
+	}
+
+	return par, nil
+}
+
+// newLayerFsService will return filesystem which never silently overwrite a file of project, since it may contain hand written code,
+// the conflicting file is only overwritten after it is confirmed or --force is given. Mocks are not edited by hand, so they are always overwritten.
+// Every change is undone when the confirmation fails, e.g. there is no terminal to ask
+func newLayerFsService(newFs domain.FsService) domain.FsService {
+	if forceWrite {
+		return newFs
+	}
+
+	resolve := promptConflict()
+	return fs.NewMergeFsService(newFs, func(fileName string) (bool, error) {
+		if strings.Contains(filepath.ToSlash(fileName), "/domain/mocks/") {
+			return true, nil
+		}
+		// dry run show the generated content, the real run will ask before overwriting
+		if dryRun {
+			log.Warnf("%s already exist, it will be asked before overwritten", fileName)
+			return true, nil
+		}
+		overwrite, err := resolve(fileName)
+		if err != nil {
+			return false, fmt.Errorf("%s already exist, run again with --force to overwrite it: %s", fileName, err)
+		}
+		return overwrite, nil
+	})
+}
+
+// parseDomain will parse usecase and repository interface of the given domain file
+func parseDomain(newFs domain.FsService, domainPath string, domainName string) (*domain.Parser, error) {
+	p := parser.NewParserDomain(newFs, domainName)
+	par, err := p.DomainParser(domainPath)
+	if err != nil {
+		return nil, fmt.Errorf("parse file in domain dir: %s", err)
+	}
+	return par, nil
+}
+
+// generateMock will create testify mock of usecase and repository interface inside domain/mocks,
+// the mocks are always overwritten so they follow the latest contract of the domain
+func generateMock(newFs domain.FsService, newGen domain.GeneratorService, path string, goModName string, par *domain.Parser) error {
+	err := ensureDir(newFs, path+"/domain/mocks")
+	if err != nil {
+		return fmt.Errorf("create mocks directory: %s", err)
+	}
+	err = newGen.GenMock(path+"/domain/mocks", goModName, par)
+	if err != nil {
+		return fmt.Errorf("create mock of interface in domain layer: %s", err)
+	}
+	return nil
+}
+
+// generateUsecase will create usecase based on interface in domain layer
+func generateUsecase(newGen domain.GeneratorService, path string, domainFile string, goModName string, par *domain.Parser) error {
+	err := newGen.GenUsecase(path+"/usecase", domainFile, goModName, par)
+	if err != nil {
+		return fmt.Errorf("create usecase based on interface in domain layer: %s", err)
+	}
+	return nil
+}
+
+// generateRepository will create repository layer with the chosen database helper,
+// dialect is only used by sql and sqlx to write the queries
+func generateRepository(newGen domain.GeneratorService, path string, domainFile string, goModName string, dbHelper string, dialect string, par *domain.Parser) (err error) {
+	if dbHelper == domain.GoPg {
+		err = newGen.GenGopgRepository(path+"/repository", domainFile, goModName, par)
+	} else if dbHelper == domain.Gorm {
+		err = newGen.GenGormRepository(path+"/repository", domainFile, goModName, par)
+	} else if dbHelper == domain.Sqlx {
+		err = newGen.GenSqlxRepository(path+"/repository", domainFile, goModName, dialect, par)
+	} else if dbHelper == domain.SQL {
+		err = newGen.GenSQLRepository(path+"/repository", domainFile, goModName, dialect, par)
+	} else if dbHelper == domain.Mongod {
+		err = newGen.GenMongodRepository(path+"/repository", domainFile, goModName, par)
+	}
+	if err != nil {
+		return fmt.Errorf("create %s repository layer: %s", dbHelper, err)
+	}
+	return nil
+}
+
+// generateValidation will create validation of request inside middleware,
+// it is shared by every transport, so it is rewritten along with the transport of project
+func generateValidation(newFs domain.FsService, newGen domain.GeneratorService, path string) error {
+	err := ensureDir(newFs, path+"/middleware")
+	if err != nil {
+		return fmt.Errorf("create middleware directory: %s", err)
+	}
+	err = newGen.GenValidationMiddleware(path + "/middleware")
+	if err != nil {
+		return fmt.Errorf("generate validation middleware: %s", err)
+	}
+	return nil
+}
+
+// generateTransport will create rest, graphql and grpc transport layer based on the chosen options
+func generateTransport(
+	newGen domain.GeneratorService,
+	path string,
+	domainFile string,
+	goModName string,
+	restServer string,
+	graphqlOpt bool,
+	grpcOpt bool,
+	par *domain.Parser,
+) (err error) {
+	// generate transport rest api
+	if restServer == domain.Echo {
+		err = newGen.GenEchoTransport(path+"/transport/rest", domainFile, goModName, par)
+	} else if restServer == domain.Gin {
+		err = newGen.GenGinTransport(path+"/transport/rest", domainFile, goModName, par)
+	} else if restServer == domain.GorillaMux {
+		err = newGen.GenGorillaMuxTransport(path+"/transport/rest", domainFile, goModName, par)
+	} else if restServer == domain.NetHTTP {
+		err = newGen.GenNetHTTPTransport(path+"/transport/rest", domainFile, goModName, par)
+	}
+	if err != nil {
+		return fmt.Errorf("generate transport rest api %s: %s", restServer, err)
+	}
+
+	// generate tranport graphql
+	if graphqlOpt {
+		err = newGen.GenGraphqlTransport(path+"/transport/graphql", domainFile, goModName, par)
+		if err != nil {
+			return fmt.Errorf("generate transport graphql: %s", err)
+		}
+	}
+
+	// generate tranport grpc
+	if grpcOpt {
+		err = newGen.GenProtobuf(path+"/proto", domainFile, goModName, par)
+		if err != nil {
+			return fmt.Errorf("generate protobuf: %s", err)
+		}
+
+		err = newGen.GenGrpcTransport(path+"/transport/grpc", domainFile, goModName, par)
+		if err != nil {
+			return fmt.Errorf("generate transport grpc: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// generateProblem will add problem details into domain and Recover into middleware of rest server,
+// the files which already exist are kept, since they may have been changed by hand
+func generateProblem(newFs domain.FsService, newGen domain.GeneratorService, path string, goModName string, restServer string) error {
+	res, err := newFs.FindFile(path + "/domain/problem.go")
+	if err != nil {
+		return err
+	}
+	if res == nil {
+		err = newGen.GenDomainProblem(path + "/domain")
+		if err != nil {
+			return fmt.Errorf("generate problem file inside domain: %s", err)
+		}
+	}
+
+	res, err = newFs.FindFile(path + "/middleware/recover_middleware.go")
+	if err != nil {
+		return err
+	}
+	if res == nil {
+		err = ensureDir(newFs, path+"/middleware")
+		if err != nil {
+			return err
+		}
+		err = newGen.GenRecoverMiddleware(path+"/middleware", goModName, restServer)
+		if err != nil {
+			return fmt.Errorf("generate recover middleware: %s", err)
+		}
+		log.Info("register `middl.Recover` in server, so panic of handler is answered as problem details too")
+	}
+	return nil
+}
+
+// generateServers will generate server of every chosen transport, every domain found in the project will be registered
+func generateServers(
+	newGen domain.GeneratorService,
+	path string,
+	goModName string,
+	dbHelper string,
+	restServer string,
+	graphqlOpt bool,
+	grpcOpt bool,
+	par *domain.Parser,
+) (transport []string, err error) {
+	if restServer == domain.Echo {
+		err = newGen.GenEchoServer(path+"/server", path, dbHelper, goModName, par)
+	} else if restServer == domain.Gin {
+		err = newGen.GenGinServer(path+"/server", path, dbHelper, goModName, par)
+	} else if restServer == domain.GorillaMux {
+		err = newGen.GenGorillaMuxServer(path+"/server", path, dbHelper, goModName, par)
+	} else if restServer == domain.NetHTTP {
+		err = newGen.GenNetHTTPMuxServer(path+"/server", path, dbHelper, goModName, par)
+	}
+	if err != nil {
+		return transport, fmt.Errorf("generate server %s: %s", restServer, err)
+	}
+	if restServer != "" && restServer != "no" {
+		transport = append(transport, restServer)
+	}
+
+	if graphqlOpt {
+		err = newGen.GenGraphqlServer(path+"/server", path, dbHelper, goModName, par)
+		if err != nil {
+			return transport, fmt.Errorf("generate server graphql: %s", err)
+		}
+		transport = append(transport, domain.Graphql)
+	}
+
+	if grpcOpt {
+		err = newGen.GenGrpcServer(path+"/server", path, dbHelper, goModName, par)
+		if err != nil {
+			return transport, fmt.Errorf("generate server grpc: %s", err)
+		}
+		transport = append(transport, domain.Grpc)
+	}
+
+	return transport, nil
+}
+
+// previewServers will generate server of the chosen transports in memory, so the existing server files are not changed
+func previewServers(path string, prj *domain.Manifest, config domain.Generator, restServer string, graphqlOpt bool, grpcOpt bool) ([]domain.FileChange, error) {
+	var (
+		previewFs  = fs.NewDryRunFsService()
+		previewGen = generator.NewGeneratorService(previewFs)
+	)
+
+	previewGen.Configure(config)
+	_, err := generateServers(previewGen, path, prj.GoModName, prj.DbHelper, restServer, graphqlOpt, grpcOpt, nil)
+	if err != nil {
+		return nil, err
+	}
+	return previewFs.Changes(), nil
+}
+
+// modifiedServers will return the server files which were changed by hand,
+// they are different with the server which is generated from the current domains of project
+func modifiedServers(preview func() ([]domain.FileChange, error)) (map[string]bool, error) {
+	changes, err := preview()
+	if err != nil {
+		return nil, err
+	}
+
+	modified := map[string]bool{}
+	for _, c := range changes {
+		if !c.Created && !bytes.Equal(c.Before, c.After) {
+			modified[filepath.Clean(c.Name)] = true
+		}
+	}
+	return modified, nil
+}
+
+// newServerFsService will return filesystem which re-wire the server files, the untouched server file is always overwritten,
+// while the diff of server file which was changed by hand is printed, then it is only overwritten after confirmation or --force is given
+func newServerFsService(newFs domain.FsService, modified map[string]bool, preview func() ([]domain.FileChange, error)) domain.FsService {
+	if forceWrite || len(modified) == 0 {
+		return newFs
+	}
+
+	resolve := promptConflict()
+	return fs.NewMergeFsService(newFs, func(fileName string) (bool, error) {
+		if !modified[filepath.Clean(fileName)] {
+			return true, nil
+		}
+		// dry run show the generated server, the real run will ask before overwriting
+		if dryRun {
+			log.Warnf("%s was changed by hand, it will be asked before overwritten", fileName)
+			return true, nil
+		}
+
+		// the new layers are already written, so the preview contains the new domain
+		changes, err := preview()
+		if err != nil {
+			return false, err
+		}
+		for _, c := range changes {
+			if filepath.Clean(c.Name) == filepath.Clean(fileName) {
+				fmt.Print(fs.Diff(filepath.ToSlash(c.Name), c.Before, c.After))
+			}
+		}
+
+		overwrite, err := resolve(fileName)
+		if err != nil {
+			return false, fmt.Errorf("%s was changed by hand, run again with --force to overwrite it: %s", fileName, err)
+		}
+		if !overwrite {
+			log.Warnf("%s: register the repository, usecase and handler of the new domain by hand", fileName)
+		}
+		return overwrite, nil
+	})
+}
+
+// layerInput represent the parsed domain file which used to generate a single layer
+type layerInput struct {
+	manifest   *domain.Manifest
+	domainName string
+	domainFile string
+	parser     *domain.Parser
+}
+
+// readLayerInput will parse the given domain file, relative path is resolved from root of project
+func readLayerInput(newFs domain.FsService, newManifest domain.ManifestService, path string, domainPath string) (*layerInput, error) {
+	if domainPath == "" {
+		return nil, errors.New("--domain is required, e.g. --domain domain/task.go")
+	}
+	if !filepath.IsAbs(domainPath) {
+		domainPath = filepath.Join(path, domainPath)
+	}
+
+	res, err := newFs.FindFile(domainPath)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, errors.New(domainPath + " not found")
+	}
+
+	prj, err := readProject(newFs, newManifest, path)
+	if err != nil {
+		return nil, err
+	}
+
+	domainFile := filepath.Base(domainPath)
+	domainName := strings.TrimSuffix(domainFile, ".go")
+	par, err := parseDomain(newFs, domainPath, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &layerInput{
+		manifest:   prj,
+		domainName: domainName,
+		domainFile: domainFile,
+		parser:     par,
+	}, nil
+}
+
+// ensureDir will create the directory if it does not exist yet
+func ensureDir(newFs domain.FsService, dirName string) error {
+	res, err := newFs.FindDir(dirName)
+	if err != nil {
+		return err
+	}
+	if res != nil {
+		return nil
+	}
+	return newFs.CreateDir(dirName)
+}
+
+// readGoModName will read module name from go.mod in root of project
+func readGoModName(newFs domain.FsService, path string) (string, error) {
+	res, err := newFs.FindFile(path + "/go.mod")
+	if err != nil {
+		return "", err
+	}
+	if res == nil {
+		return "", errors.New("go.mod not found, make sure the path is root of project")
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(res.([]byte)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(line[len("module "):]), `"`), nil
+		}
+	}
+	return "", errors.New("module name not found in go.mod")
+}
+
+// readProject will read manifest of project,
+// project which was initiated before the manifest exist is detected from go.mod and the layout which produced by `init` command
+func readProject(newFs domain.FsService, newManifest domain.ManifestService, path string) (*domain.Manifest, error) {
+	var (
+		dbConfig   = map[string]string{domain.GoPg: "gopg", domain.Gorm: "gorm", domain.Sqlx: "sqlx", domain.SQL: "sql", domain.Mongod: "mongod"}
+		restConfig = map[string]string{domain.Echo: "echo", domain.Gin: "gin", domain.GorillaMux: "gorilla_mux", domain.NetHTTP: "net_http_mux"}
+	)
+
+	prj, err := newManifest.Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %s", domain.ManifestFile, err)
+	}
+	if prj != nil {
+		return prj, nil
+	}
+
+	goModName, err := readGoModName(newFs, path)
+	if err != nil {
+		return nil, err
+	}
+	prj = &domain.Manifest{GoModName: goModName}
+
+	for lib, name := range dbConfig {
+		res, err := newFs.FindFile(path + "/database/config/" + name + "_config.go")
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			prj.DbHelper = lib
+		}
+	}
+
+	prj.RestServer = "no"
+	for lib, name := range restConfig {
+		res, err := newFs.FindFile(path + "/server/" + name + "_server.go")
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			prj.RestServer = lib
+		}
+	}
+
+	res, err := newFs.FindFile(path + "/server/graphql_server.go")
+	if err != nil {
+		return nil, err
+	}
+	prj.GraphqlOpt = res != nil
+
+	res, err = newFs.FindFile(path + "/server/grpc_server.go")
+	if err != nil {
+		return nil, err
+	}
+	prj.GrpcOpt = res != nil
+
+	res, err = newFs.FindFile(path + "/domain/problem.go")
+	if err != nil {
+		return nil, err
+	}
+	prj.ProblemJSON = res != nil
+
+	usecases, err := newFs.ReadDir(path + "/usecase")
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range usecases {
+		if strings.HasSuffix(u.Name(), "_usecase.go") {
+			addDomain(prj, strings.TrimSuffix(u.Name(), "_usecase.go"))
+		}
+	}
+
+	return prj, nil
+}
+
+// addDomain will record the domain into manifest if not recorded yet
+func addDomain(prj *domain.Manifest, domainName string) {
+	for _, d := range prj.Domains {
+		if d == domainName {
+			return
+		}
+	}
+	prj.Domains = append(prj.Domains, domainName)
+}
+
+// saveManifest will record every file which was created after before was listed, then write the manifest into root of project
+func saveManifest(newFs domain.FsService, newManifest domain.ManifestService, path string, prj *domain.Manifest, before []string) error {
+	after, err := newFs.ListFiles(path)
+	if err != nil {
+		return err
+	}
+
+	exist := map[string]bool{domain.ManifestFile: true}
+	for _, f := range before {
+		exist[f] = true
+	}
+	for _, f := range prj.Files {
+		exist[f] = true
+	}
+	for _, f := range after {
+		if !exist[f] {
+			prj.Files = append(prj.Files, f)
+		}
+	}
+
+	prj.Version = Version
+	return newManifest.Write(path, prj)
+}
+
+func failOnGenerateError(err error, msg string) {
+	if err != nil {
+		log.Errorf("%s: %s", msg, err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	generateCmd.PersistentFlags().StringVar(&projectPath, "path", ".", "Root directory of project which initiated by cacli")
+	generateCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the files which would be changed without writing them")
+	generateCmd.PersistentFlags().BoolVar(&forceWrite, "force", false, "Overwrite the existing files without asking, hand written code inside them is lost")
+
+	for _, c := range []*cobra.Command{generateUsecaseCmd, generateRepositoryCmd, generateTransportCmd} {
+		c.Flags().StringVar(&layerDomainFile, "domain", "", "Path of domain file, e.g. domain/task.go")
+	}
+	generateRepositoryCmd.Flags().StringVar(&layerDbHelper, "db", "", "Database helper library. Choose one of: gopg, gorm, sqlx, sql, mongod")
+	generateRepositoryCmd.Flags().StringVar(&layerDialect, "dialect", "", "SQL dialect of sql and sqlx queries. Choose one of: mysql, postgres")
+	generateTransportCmd.Flags().StringVar(&layerRestServer, "rest", "", "Rest API server library. Choose one of: echo, gin, gorilla mux, net/http")
+	generateTransportCmd.Flags().BoolVar(&layerGraphqlOpt, "graphql", false, "True if generate graphql transport")
+	generateTransportCmd.Flags().BoolVar(&layerGrpcOpt, "grpc", false, "True if generate grpc transport")
+	generateTransportCmd.Flags().BoolVar(&layerProblemJSON, "problem-json", false, "True if rest transport answers errors as application/problem+json of RFC 7807")
+	generateMigrationCmd.Flags().StringVar(&migrationFormat, "format", domain.SQLMigration, "Format of migration. Choose one of: sql, fizz")
+	generateMigrationCmd.Flags().StringVar(&layerDialect, "dialect", "", "SQL dialect of sql migration. Choose one of: mysql, postgres")
+
+	generateCmd.AddCommand(generateDomainCmd)
+	generateCmd.AddCommand(generateUsecaseCmd)
+	generateCmd.AddCommand(generateRepositoryCmd)
+	generateCmd.AddCommand(generateTransportCmd)
+	generateCmd.AddCommand(generateMigrationCmd)
+	RootCmd.AddCommand(generateCmd)
+}
@@ -0,0 +1,139 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/wicaker/cacli/cmd"
+	"github.com/wicaker/cacli/fs"
+)
+
+const generateSpec = `service: test_generate
+module: github.com/wicaker/testgenerate
+database: sqlx
+dialect: postgres
+transports:
+  rest: echo
+entities:
+  - name: task
+    fields:
+      - name: ID
+        type: uint64
+      - name: Title
+        type: string
+`
+
+func TestGenerateLayerCommand(t *testing.T) {
+	var (
+		newFs       = fs.NewFsService()
+		serviceName = "test_generate"
+		usecaseFile = serviceName + "/usecase/task_usecase.go"
+	)
+
+	specFile, err := ioutil.TempFile("", "cacli-spec-*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(specFile.Name())
+	_, err = specFile.WriteString(generateSpec)
+	assert.NoError(t, err)
+	specFile.Close()
+
+	cmd.RootCmd.SetArgs([]string{`init`, `--config=` + specFile.Name()})
+	cmd.RootCmd.Execute()
+	resetConfigFlag(t)
+
+	t.Run("success, should not ask for file which has no change", func(t *testing.T) {
+		before, err := ioutil.ReadFile(usecaseFile)
+		assert.NoError(t, err)
+
+		cmd.RootCmd.SetArgs([]string{`generate`, `usecase`, `--path=` + serviceName, `--domain=domain/task.go`, `--force=false`, `--dry-run=false`})
+		cmd.RootCmd.Execute()
+
+		after, err := ioutil.ReadFile(usecaseFile)
+		assert.NoError(t, err)
+		assert.Equal(t, string(before), string(after))
+	})
+
+	t.Run("success, should overwrite hand written code on force", func(t *testing.T) {
+		data, err := ioutil.ReadFile(usecaseFile)
+		assert.NoError(t, err)
+		data = []byte(strings.Replace(string(data), "defer cancel()", "defer cancel()\n\t// HAND WRITTEN", 1))
+		err = ioutil.WriteFile(usecaseFile, data, 0644)
+		assert.NoError(t, err)
+
+		cmd.RootCmd.SetArgs([]string{`generate`, `usecase`, `--path=` + serviceName, `--domain=domain/task.go`, `--force=true`, `--dry-run=false`})
+		cmd.RootCmd.Execute()
+
+		data, err = ioutil.ReadFile(usecaseFile)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "HAND WRITTEN")
+	})
+
+	// remove directory of service
+	err = newFs.RemoveDir(serviceName)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+}
+
+func TestGenerateDomainCommand(t *testing.T) {
+	var (
+		newFs       = fs.NewFsService()
+		serviceName = "test_generate"
+		serverFile  = serviceName + "/server/echo_server.go"
+	)
+
+	specFile, err := ioutil.TempFile("", "cacli-spec-*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(specFile.Name())
+	_, err = specFile.WriteString(generateSpec)
+	assert.NoError(t, err)
+	specFile.Close()
+
+	cmd.RootCmd.SetArgs([]string{`init`, `--config=` + specFile.Name()})
+	cmd.RootCmd.Execute()
+	resetConfigFlag(t)
+
+	t.Run("success, should re-wire server which was not changed by hand", func(t *testing.T) {
+		cmd.RootCmd.SetArgs([]string{`generate`, `domain`, `note`, `--path=` + serviceName, `--force=false`, `--dry-run=false`})
+		cmd.RootCmd.Execute()
+
+		data, err := ioutil.ReadFile(serverFile)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "rest.NewNoteHandler(r, noteusecase)")
+	})
+
+	t.Run("success, should overwrite server which was changed by hand on force", func(t *testing.T) {
+		data, err := ioutil.ReadFile(serverFile)
+		assert.NoError(t, err)
+		data = []byte(strings.Replace(string(data), "r.Use(middl.CORS)", "r.Use(middl.CORS)\n\t// HAND WRITTEN", 1))
+		err = ioutil.WriteFile(serverFile, data, 0644)
+		assert.NoError(t, err)
+
+		cmd.RootCmd.SetArgs([]string{`generate`, `domain`, `label`, `--path=` + serviceName, `--force=true`, `--dry-run=false`})
+		cmd.RootCmd.Execute()
+
+		data, err = ioutil.ReadFile(serverFile)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "rest.NewLabelHandler(r, labelusecase)")
+		assert.NotContains(t, string(data), "HAND WRITTEN")
+	})
+
+	// remove directory of service
+	err = newFs.RemoveDir(serviceName)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+}
+
+// resetConfigFlag will clear the flag of specification, so the next command of init is not run from the removed file
+func resetConfigFlag(t *testing.T) {
+	initCmd, _, err := cmd.RootCmd.Find([]string{`init`})
+	assert.NoError(t, err)
+	assert.NoError(t, initCmd.PersistentFlags().Set(`config`, ``))
+}
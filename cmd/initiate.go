@@ -1,16 +1,18 @@
 package cmd
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/wicaker/cacli/domain"
 	"github.com/wicaker/cacli/fs"
 	"github.com/wicaker/cacli/generator"
-	"github.com/wicaker/cacli/parser"
+	"github.com/wicaker/cacli/manifest"
+	"github.com/wicaker/cacli/spec"
 
 	"github.com/manifoldco/promptui"
 	log "github.com/sirupsen/logrus"
@@ -19,7 +21,9 @@ import (
 
 var (
 	serviceName, goModName, dbHelper, restServer string
-	overWrite, grpcOpt, graphqlOpt               bool
+	sqlDialect, configFile                       string
+	overWrite, mergeDir, grpcOpt, graphqlOpt     bool
+	problemJSONOpt                               bool
 	initCmd                                      = &cobra.Command{
 		Use:     "init",
 		Aliases: []string{"i"},
@@ -37,6 +41,10 @@ func runInit(cmd *cobra.Command, args []string) {
 			domain.Option{Title: domain.SQL, Description: "Will using package from https://golang.org/pkg/database/sql/"},
 			domain.Option{Title: domain.Mongod, Description: "Will using package from go.mongodb.org/mongo-driver/mongo/"},
 		}
+		selectDialectOpt = []domain.Option{
+			domain.Option{Title: domain.MySQL, Description: "Queries will using ? placeholder and driver from https://github.com/go-sql-driver/mysql"},
+			domain.Option{Title: domain.Postgres, Description: "Queries will using $n placeholder and driver from https://github.com/lib/pq"},
+		}
 		selectRestServerOpt = []domain.Option{
 			domain.Option{Title: domain.Echo, Description: "Will using package from https://github.com/labstack/echo"},
 			domain.Option{Title: domain.Gin, Description: "Will using package from https://github.com/gin-gonic/gin"},
@@ -52,64 +60,78 @@ func runInit(cmd *cobra.Command, args []string) {
 			domain.Option{Title: "yes", Description: "This transport wil using package from google.golang.org/grpc"},
 			domain.Option{Title: "no", Description: "Grpc transport will not added"},
 		}
-		overwriteDirOpt = []domain.Option{
-			domain.Option{Title: "yes", Description: "The existing directory will be overwriten"},
+		existingDirOpt = []domain.Option{
+			domain.Option{Title: "merge", Description: "Only the missing files will be written, you will be asked for every conflicting file"},
+			domain.Option{Title: "overwrite", Description: "The existing directory will be archived, then removed"},
 			domain.Option{Title: "no", Description: "Close the app"},
 		}
-		newFs = fs.NewFsService()
+		newFs = newFsService()
 	)
 
+	// every choice is taken from specification file, no prompt is shown
+	if configFile != "" {
+		runInitSpec(newFs)
+		return
+	}
+
 	// input service name
 	if serviceName == "" {
 		resName, err := promptInit("Service name")
 		serviceName = resName
-		failOnInitError(err, `input service name `, serviceName)
+		failOnInitError(newFs, err, `input service name `)
 	}
 
 	// find existing directory which equal to service name
 	res, err := newFs.FindDir(serviceName)
-	failOnInitError(err, `find existing directory which equal to service name `, serviceName)
+	failOnInitError(newFs, err, `find existing directory which equal to service name `)
 
-	// ask to overwrite already existing directory
-	// will be removed if yes
+	// ask how to handle already existing directory,
+	// it is archived before anything is changed inside it
 	if res != nil {
-		overwriteOpt, err := selectInit(overwriteDirOpt, "Directory name `"+serviceName+"` already exist, do you want to overwrite ? (be careful to remove existing directory)")
-		if err != nil {
-			log.Error(err, `ask to overwrite already directory`)
-			os.Exit(1)
+		mode := existingDirMode()
+		if mode == "" {
+			mode, err = selectInit(existingDirOpt, "Directory name `"+serviceName+"` already exist, what do you want to do ?")
+			if err != nil {
+				log.Error(err, `ask to handle already existing directory`)
+				os.Exit(1)
+			}
 		}
 
-		if overwriteOpt == "no" {
-			log.Error("Directory name `" + serviceName + "` already exist")
+		newFs, err = prepareExistingDir(newFs, serviceName, mode, promptConflict())
+		if err != nil {
+			log.Error(err)
 			os.Exit(1)
-		} else {
-			err := newFs.RemoveDir(serviceName)
-			failOnInitError(err, `overwrite already dir, remove existing directory `, serviceName)
 		}
 	}
 
 	// input gomod name
 	if goModName == "" {
 		goModName, err = promptInit("Go module name")
-		failOnInitError(err, `input gomod name `, serviceName)
+		failOnInitError(newFs, err, `input gomod name `)
 	}
 
 	// input dbHelper or ORM
 	if dbHelper != domain.GoPg && dbHelper != domain.Gorm && dbHelper != domain.Sqlx && dbHelper != domain.SQL && dbHelper != domain.Mongod {
 		dbHelper, err = selectInit(selectDBOpt, "DB Helper")
-		failOnInitError(err, `input dbHelper or ORM `, serviceName)
+		failOnInitError(newFs, err, `input dbHelper or ORM `)
+	}
+
+	// input sql dialect, only sql and sqlx write their own queries
+	if (dbHelper == domain.Sqlx || dbHelper == domain.SQL) && sqlDialect != domain.MySQL && sqlDialect != domain.Postgres {
+		sqlDialect, err = selectInit(selectDialectOpt, "SQL dialect")
+		failOnInitError(newFs, err, `input sql dialect `)
 	}
 
 	// input http rest api server transport
 	if restServer != domain.Echo && restServer != domain.Gin && restServer != domain.GorillaMux && restServer != domain.NetHTTP && restServer != "no" {
 		restServer, err = selectInit(selectRestServerOpt, "Using REST API? , choose one if yes!")
-		failOnInitError(err, `input http rest api server transport `, serviceName)
+		failOnInitError(newFs, err, `input http rest api server transport `)
 	}
 
 	// input graphql transport
 	if graphqlOpt == false {
 		graphqlOp, err := selectInit(selectGraphqlOpt, "Using Graphql ?")
-		failOnInitError(err, `input graphql transport `, serviceName)
+		failOnInitError(newFs, err, `input graphql transport `)
 		if graphqlOp == "yes" || graphqlOp == "y" {
 			graphqlOpt = true
 		}
@@ -118,7 +140,7 @@ func runInit(cmd *cobra.Command, args []string) {
 	// input htt2 gRPC transport
 	if grpcOpt == false {
 		grpcOp, err := selectInit(selectGrpcOpt, "Using gRPC ?")
-		failOnInitError(err, `input htt2 gRPC transport `, serviceName)
+		failOnInitError(newFs, err, `input htt2 gRPC transport `)
 		if grpcOp == "yes" || grpcOp == "y" {
 			grpcOpt = true
 		}
@@ -129,9 +151,57 @@ func runInit(cmd *cobra.Command, args []string) {
 		serviceName,
 		goModName,
 		dbHelper,
+		sqlDialect,
 		restServer,
 		graphqlOpt,
 		grpcOpt,
+		problemJSONOpt,
+		nil,
+	)
+}
+
+// runInitSpec will initiate the service based on specification file which given by --config
+func runInitSpec(newFs domain.FsService) {
+	svcSpec, err := spec.NewSpecService().Read(configFile)
+	if err != nil {
+		log.Errorf("read specification %s: %s", configFile, err)
+		os.Exit(1)
+	}
+
+	res, err := newFs.FindDir(svcSpec.Service)
+	if err != nil {
+		log.Errorf("find existing directory which equal to service name %s: %s", svcSpec.Service, err)
+		os.Exit(1)
+	}
+	if res != nil {
+		mode := existingDirMode()
+		if mode == "" {
+			log.Error("Directory name `" + svcSpec.Service + "` already exist, use --merge to keep it or --overwrite to remove it")
+			os.Exit(1)
+		}
+
+		// no prompt is shown, so every conflicting file is skipped
+		newFs, err = prepareExistingDir(newFs, svcSpec.Service, mode, func(fileName string) (bool, error) {
+			log.Warnf("%s already exist, skipped", fileName)
+			return false, nil
+		})
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	}
+
+	generateInit(
+		newFs,
+		svcSpec.Service,
+		svcSpec.Module,
+		svcSpec.Database,
+		svcSpec.Dialect,
+		svcSpec.Transports.Rest,
+		svcSpec.Transports.Graphql,
+		svcSpec.Transports.Grpc,
+		svcSpec.Transports.ProblemJSON,
+		svcSpec.Entities,
 	)
 }
 
@@ -140,246 +210,339 @@ func generateInit(
 	serviceName string,
 	goModName string,
 	dbHelper string,
+	dialect string,
 	restServer string,
 	graphqlOpt bool,
 	grpcOpt bool,
+	problemJSON bool,
+	entities []domain.SpecEntity,
 ) {
-	var (
-		stdout, stderr bytes.Buffer
-		transport      []string
-	)
+	var transport []string
+
+	// dialect only matters for the helpers which write their own queries
+	if dbHelper != domain.Sqlx && dbHelper != domain.SQL {
+		dialect = ""
+	} else if dialect == "" {
+		dialect = domain.MySQL
+	}
+
+	// problem details only answer errors of rest transport
+	if restServer == "no" {
+		problemJSON = false
+	}
 
 	// generator service
-	newGen := generator.NewGeneratorService()
+	newGen := generator.NewGeneratorService(newFs)
+	newGen.Configure(domain.Generator{ProblemJSON: problemJSON})
 
 	// create project if no directory
 	if serviceName != "" {
+		// files which already exist are not recorded as generated files when merging,
+		// directory which does not exist yet has no file
+		before, _ := newFs.ListFiles(serviceName)
+
+		// manifest of the merged project keeps the snapshot of tables, so only changes since then are migrated
+		existing, err := manifest.NewManifestService(newFs).Read(serviceName)
+		failOnInitError(newFs, err, `read existing manifest `)
+
 		// create directory service
-		err := newFs.CreateDir(serviceName)
-		failOnInitError(err, `create directory service `, serviceName)
+		err = newFs.CreateDir(serviceName)
+		failOnInitError(newFs, err, `create directory service `)
 
 		// create go module
-		cmd := exec.Command("go", "mod", "init", goModName)
-		cmd.Dir = "./" + serviceName
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
-		err = cmd.Run()
-		failOnInitError(err, `create go module `, serviceName)
+		err = newGen.GenGoMod(serviceName, goModName)
+		failOnInitError(newFs, err, `create go module `)
 
 		// create domain directory
 		err = newFs.CreateDir("./" + serviceName + "/domain")
-		failOnInitError(err, `create domain directory `, serviceName)
+		failOnInitError(newFs, err, `create domain directory `)
 
 		// generate errors file inside domain
 		err = newGen.GenDomainErrors(serviceName + "/domain")
-		failOnInitError(err, `generate errors file inside domain `, serviceName)
+		failOnInitError(newFs, err, `generate errors file inside domain `)
 
 		// generate status_code file inside domain
 		err = newGen.GenDomainStatusCode(serviceName + "/domain")
-		failOnInitError(err, `generate status_code file inside domain `, serviceName)
+		failOnInitError(newFs, err, `generate status_code file inside domain `)
 
 		// generate success file inside domain
 		err = newGen.GenDomainSuccess(serviceName + "/domain")
-		failOnInitError(err, `generate success file inside domain `, serviceName)
+		failOnInitError(newFs, err, `generate success file inside domain `)
 
-		// generate example file inside domain
-		err = newGen.GenDomainExample(serviceName + "/domain")
-		failOnInitError(err, `generate example file inside domain `, serviceName)
+		// generate problem file inside domain
+		if problemJSON {
+			err = newGen.GenDomainProblem(serviceName + "/domain")
+			failOnInitError(newFs, err, `generate problem file inside domain `)
+		}
+
+		// generate example file inside domain if no entity is declared
+		domainNames := []string{"example"}
+		if len(entities) == 0 {
+			err = newGen.GenDomainExample(serviceName + "/domain")
+			failOnInitError(newFs, err, `generate example file inside domain `)
+		} else {
+			domainNames = []string{}
+			for _, e := range entities {
+				err = newGen.GenDomainSpec(serviceName+"/domain", e)
+				failOnInitError(newFs, err, `generate `+e.Name+` file inside domain `)
+				domainNames = append(domainNames, e.Name)
+			}
+		}
 
 		// create usecase directory
 		err = newFs.CreateDir("./" + serviceName + "/usecase")
-		failOnInitError(err, `create usecase directory `, serviceName)
-
-		// parse file in domain dir
-		p := parser.NewParserDomain("example")
-		par, err := p.DomainParser("./" + serviceName + "/domain/example.go")
-		failOnInitError(err, `parse file in domain dir `, serviceName)
-
-		// create example usecase based on interface in domain layer
-		err = newGen.GenUsecase(serviceName+"/usecase", "example.go", goModName, par)
-		failOnInitError(err, `create example usecase based on interface in domain layer `, serviceName)
+		failOnInitError(newFs, err, `create usecase directory `)
 
 		// create repository directory
 		err = newFs.CreateDir("./" + serviceName + "/repository")
-		failOnInitError(err, `create repository directory`, serviceName)
-
-		// create repository layer
-		if dbHelper == domain.GoPg {
-			err = newGen.GenGopgRepository(serviceName+"/repository", "example.go", goModName, par)
-			failOnInitError(err, `create gopg repository layer `, serviceName)
-		} else if dbHelper == domain.Gorm {
-			err = newGen.GenGormRepository(serviceName+"/repository", "example.go", goModName, par)
-			failOnInitError(err, `create gorm repository layer `, serviceName)
-		} else if dbHelper == domain.Sqlx {
-			err = newGen.GenSqlxRepository(serviceName+"/repository", "example.go", goModName, par)
-			failOnInitError(err, `create sqlx repository layer `, serviceName)
-		} else if dbHelper == domain.SQL {
-			err = newGen.GenSQLRepository(serviceName+"/repository", "example.go", goModName, par)
-			failOnInitError(err, `create sql repository layer `, serviceName)
-		}
+		failOnInitError(newFs, err, `create repository directory`)
 
 		// create database directory
 		err = newFs.CreateDir("./" + serviceName + "/database")
-		failOnInitError(err, `create database directory `, serviceName)
+		failOnInitError(newFs, err, `create database directory `)
 
 		// create config directory
 		err = newFs.CreateDir("./" + serviceName + "/database/config")
-		failOnInitError(err, `create config directory `, serviceName)
+		failOnInitError(newFs, err, `create config directory `)
 
 		// create db config
 		if dbHelper == domain.GoPg {
 			err = newGen.GenGopgConfig(serviceName + "/database/config")
-			failOnInitError(err, `create db config gopg `, serviceName)
+			failOnInitError(newFs, err, `create db config gopg `)
 		} else if dbHelper == domain.Gorm {
 			err = newGen.GenGormConfig(serviceName + "/database/config")
-			failOnInitError(err, `create db config gorm `, serviceName)
+			failOnInitError(newFs, err, `create db config gorm `)
 		} else if dbHelper == domain.Sqlx {
-			err = newGen.GenSqlxConfig(serviceName + "/database/config")
-			failOnInitError(err, `create db config sqlx `, serviceName)
+			err = newGen.GenSqlxConfig(serviceName+"/database/config", dialect)
+			failOnInitError(newFs, err, `create db config sqlx `)
 		} else if dbHelper == domain.SQL {
-			err = newGen.GenSQLConfig(serviceName + "/database/config")
-			failOnInitError(err, `create db config sql `, serviceName)
+			err = newGen.GenSQLConfig(serviceName+"/database/config", dialect)
+			failOnInitError(newFs, err, `create db config sql `)
+		} else if dbHelper == domain.Mongod {
+			err = newGen.GenMongodConfig(serviceName + "/database/config")
+			failOnInitError(newFs, err, `create db config mongod `)
 		}
 
 		// create transport directory
 		err = newFs.CreateDir("./" + serviceName + "/transport")
-		failOnInitError(err, `create transport directory `, serviceName)
+		failOnInitError(newFs, err, `create transport directory `)
 
 		// create middleware directory
 		err = newFs.CreateDir("./" + serviceName + "/middleware")
-		failOnInitError(err, `create middleware directory `, serviceName)
+		failOnInitError(newFs, err, `create middleware directory `)
 
 		// create server directory
 		err = newFs.CreateDir("./" + serviceName + "/server")
-		failOnInitError(err, ` create server directory `, serviceName)
+		failOnInitError(newFs, err, ` create server directory `)
 
 		// create rest directory
 		if restServer != "no" {
 			err = newFs.CreateDir("./" + serviceName + "/transport/rest")
-			failOnInitError(err, `create transport rest directory `, serviceName)
+			failOnInitError(newFs, err, `create transport rest directory `)
+		}
 
+		// create graphql directory
+		if graphqlOpt {
+			err = newFs.CreateDir("./" + serviceName + "/transport/graphql")
+			failOnInitError(newFs, err, `create transport graphql directory `)
 		}
 
-		// generate transport rest api
-		if restServer == domain.Echo {
-			err = newGen.GenEchoTransport(serviceName+"/transport/rest", "example.go", goModName, par)
-			failOnInitError(err, `generate transport rest api echo `, serviceName)
+		// create grpc and proto directory
+		if grpcOpt {
+			err = newFs.CreateDir("./" + serviceName + "/transport/grpc")
+			failOnInitError(newFs, err, `create transport grpc directory `)
 
-			err = newGen.GenEchoMiddleware(serviceName + "/middleware")
-			failOnInitError(err, `generate middleware echo `, serviceName)
+			err = newFs.CreateDir("./" + serviceName + "/proto")
+			failOnInitError(newFs, err, `create proto directory `)
+		}
 
-			err = newGen.GenEchoServer(serviceName+"/server", serviceName, dbHelper, goModName, par)
-			failOnInitError(err, `generate server echo `, serviceName)
+		// generate usecase, repository and transport layer of every domain
+		var (
+			par     *domain.Parser
+			parsers []*domain.Parser
+		)
+		for i, d := range domainNames {
+			p, err := generateLayers(newFs, newGen, serviceName, d, goModName, dbHelper, dialect, restServer, graphqlOpt, grpcOpt)
+			failOnInitError(newFs, err, `generate layers of `+d+` domain `)
+			if i == 0 {
+				par = p
+			}
+			parsers = append(parsers, p)
+		}
 
-			transport = append(transport, domain.Echo)
+		// generate middleware
+		if restServer == domain.Echo {
+			err = newGen.GenEchoMiddleware(serviceName + "/middleware")
+			failOnInitError(newFs, err, `generate middleware echo `)
 		} else if restServer == domain.Gin {
-			err = newGen.GenGinTransport(serviceName+"/transport/rest", "example.go", goModName, par)
-			failOnInitError(err, `generate transport rest api gin `, serviceName)
-
 			err = newGen.GenGinMiddleware(serviceName + "/middleware")
-			failOnInitError(err, `generate middleware gin `, serviceName)
-
-			err = newGen.GenGinServer(serviceName+"/server", serviceName, dbHelper, goModName, par)
-			failOnInitError(err, `generate server gin `, serviceName)
-
-			transport = append(transport, domain.Gin)
+			failOnInitError(newFs, err, `generate middleware gin `)
 		} else if restServer == domain.GorillaMux {
-			err = newGen.GenGorillaMuxTransport(serviceName+"/transport/rest", "example.go", goModName, par)
-			failOnInitError(err, `generate transport rest api gorilla mux `, serviceName)
-
 			err = newGen.GenGorillaMuxMiddleware(serviceName + "/middleware")
-			failOnInitError(err, `generate middleware gorilla mux `, serviceName)
-
-			err = newGen.GenGorillaMuxServer(serviceName+"/server", serviceName, dbHelper, goModName, par)
-			failOnInitError(err, `generate server gorilla mux `, serviceName)
-
-			transport = append(transport, domain.GorillaMux)
+			failOnInitError(newFs, err, `generate middleware gorilla mux `)
 		} else if restServer == domain.NetHTTP {
-			err = newGen.GenNetHTTPTransport(serviceName+"/transport/rest", "example.go", goModName, par)
-			failOnInitError(err, `generate transport rest api net/http `, serviceName)
-
 			err = newGen.GenNetHTTPMiddleware(serviceName + "/middleware")
-			failOnInitError(err, `generate middleware net/http `, serviceName)
-
-			err = newGen.GenNetHTTPMuxServer(serviceName+"/server", serviceName, dbHelper, goModName, par)
-			failOnInitError(err, `generate server net/http `, serviceName)
-
-			transport = append(transport, domain.NetHTTP)
+			failOnInitError(newFs, err, `generate middleware net/http `)
 		}
-
-		// generate tranport graphql
 		if graphqlOpt {
-			err = newFs.CreateDir("./" + serviceName + "/transport/graphql")
-			failOnInitError(err, `create transport graphql directory `, serviceName)
+			err = newGen.GenNetHTTPMiddleware(serviceName + "/middleware")
+			failOnInitError(newFs, err, `generate middleware net/http  `)
+		}
+		if problemJSON {
+			err = newGen.GenRecoverMiddleware(serviceName+"/middleware", goModName, restServer)
+			failOnInitError(newFs, err, `generate middleware recover `)
+		}
 
-			err = newGen.GenGraphqlTransport(serviceName+"/transport/graphql", "example.go", goModName, par)
-			failOnInitError(err, `generate transport graphql `, serviceName)
+		// generate server of every transport
+		transport, err = generateServers(newGen, serviceName, goModName, dbHelper, restServer, graphqlOpt, grpcOpt, par)
+		failOnInitError(newFs, err, `generate server `)
 
-			err = newGen.GenNetHTTPMiddleware(serviceName + "/middleware")
-			failOnInitError(err, `generate middleware net/http  `, serviceName)
+		// generate main
+		err = newGen.GenMain(serviceName, goModName, dbHelper, transport)
+		failOnInitError(newFs, err, `generate main.go `)
+
+		// generate env
+		err = newGen.GenEnv(serviceName)
+		failOnInitError(newFs, err, `generate env `)
 
-			err = newGen.GenGraphqlServer(serviceName+"/server", serviceName, dbHelper, goModName, par)
-			failOnInitError(err, `generate server graphql`, serviceName)
+		// generate Readme
+		err = newGen.GenReadme(serviceName, dbHelper)
+		failOnInitError(newFs, err, `generate README.md `)
 
-			transport = append(transport, domain.Graphql)
+		// generate Dockerfile
+		err = newGen.GenDockerfile(serviceName)
+		failOnInitError(newFs, err, `generate Dockerfile `)
+
+		prj := &domain.Manifest{
+			GoModName:   goModName,
+			DbHelper:    dbHelper,
+			Dialect:     dialect,
+			RestServer:  restServer,
+			GraphqlOpt:  graphqlOpt,
+			GrpcOpt:     grpcOpt,
+			ProblemJSON: problemJSON,
+			Domains:     domainNames,
+		}
+		if existing != nil {
+			prj.Tables = existing.Tables
+			prj.Files = existing.Files
 		}
 
-		// generate tranport grpc
-		if grpcOpt {
-			err = newFs.CreateDir("./" + serviceName + "/transport/grpc")
-			failOnInitError(err, `create transport grpc directory `, serviceName)
+		// generate migration which create table of every entity,
+		// version of each migration is increased so they are applied in the declared order
+		if dbHelper != domain.Mongod {
+			err = newFs.CreateDir("./" + serviceName + "/database/migrations")
+			failOnInitError(newFs, err, `create migrations directory `)
+
+			err = newGen.GenMigrationRunner(serviceName+"/database/migrations", dbHelper)
+			failOnInitError(newFs, err, `generate migration runner `)
+
+			now := time.Now()
+			for i, d := range domainNames {
+				err = generateMigration(newGen, serviceName, d, prj, domain.SQLMigration, migrationDialect(dbHelper, dialect), now.Add(time.Duration(i)*time.Second), parsers[i])
+				if err == domain.ErrNoMigration {
+					log.Infof("Entity of `%s` has no change since the last migration", d)
+				} else if err != nil {
+					log.Warnf("migration of `%s` is not generated: %s", d, err)
+				}
+			}
+		}
 
-			err = newFs.CreateDir("./" + serviceName + "/proto")
-			failOnInitError(err, `create proto directory `, serviceName)
+		// write manifest, so the next commands know the choices of this project
+		err = saveManifest(newFs, manifest.NewManifestService(newFs), serviceName, prj, before)
+		failOnInitError(newFs, err, `write manifest `)
+	}
 
-			err = newGen.GenProtobuf(serviceName+"/proto", "example.go", goModName, par)
-			failOnInitError(err, `generate protobuf `, serviceName)
+	if dryRun {
+		printDryRun(os.Stdout, newFs)
+		return
+	}
+	log.Info("Congratulation, your `" + serviceName + "` service was successfully initiated !")
+}
 
-			// generate *.pb.go file
-			// cmd := exec.Command("protoc", "--go_out=plugins=grpc:.", "proto/*.proto")
-			// cmd.Dir = "./" + serviceName
-			// cmd.Stdout = &stdout
-			// cmd.Stderr = &stderr
-			// err = cmd.Run()
-			// failOnInitError(err, `generate *.pb.go file `, serviceName)
-			// soda g config
+// existingDirMode will return how to handle already existing directory based on the given flag, empty if not given
+func existingDirMode() string {
+	if mergeDir {
+		return "merge"
+	}
+	if overWrite {
+		return "overwrite"
+	}
+	return ""
+}
 
-			err = newGen.GenGrpcTransport(serviceName+"/transport/grpc", "example.go", goModName, par)
-			failOnInitError(err, `generate transport grpc `, serviceName)
+// prepareExistingDir will archive the existing directory, then remove it or return filesystem which merge into it
+func prepareExistingDir(newFs domain.FsService, dirName string, mode string, resolve func(fileName string) (bool, error)) (domain.FsService, error) {
+	if mode != "merge" && mode != "overwrite" {
+		return newFs, errors.New("Directory name `" + dirName + "` already exist")
+	}
 
-			err = newGen.GenGrpcServer(serviceName+"/server", serviceName, dbHelper, goModName, par)
-			failOnInitError(err, `generate server grpc `, serviceName)
+	backup := strings.TrimSuffix(filepath.Clean(dirName), string(filepath.Separator)) + ".backup-" + time.Now().Format("20060102150405") + ".tar.gz"
+	err := newFs.Archive(dirName, backup)
+	if err != nil {
+		return newFs, fmt.Errorf("archive existing directory: %s", err)
+	}
+	if dryRun {
+		log.Info("Existing directory `" + dirName + "` would be archived into " + backup)
+	} else {
+		log.Info("Existing directory `" + dirName + "` was archived into " + backup)
+	}
 
-			transport = append(transport, domain.Grpc)
+	if mode == "overwrite" {
+		err = newFs.RemoveDir(dirName)
+		if err != nil {
+			return newFs, fmt.Errorf("remove existing directory: %s", err)
 		}
+		return newFs, nil
+	}
 
-		// generate main
-		err = newGen.GenMain(serviceName, goModName, dbHelper, transport)
-		failOnInitError(err, `generate main.go `, serviceName)
+	return fs.NewMergeFsService(newFs, resolve), nil
+}
 
-		// generate env
-		err = newGen.GenEnv(serviceName)
-		failOnInitError(err, `generate env `, serviceName)
+// promptConflict will ask whether a file which already exist with different content is overwritten
+func promptConflict() func(fileName string) (bool, error) {
+	var (
+		conflictOpt = []domain.Option{
+			domain.Option{Title: "skip", Description: "The existing file will be kept"},
+			domain.Option{Title: "overwrite", Description: "The existing file will be replaced by the generated one"},
+			domain.Option{Title: "skip all", Description: "Every conflicting file will be kept"},
+			domain.Option{Title: "overwrite all", Description: "Every conflicting file will be replaced by the generated one"},
+		}
+		all string
+	)
 
-		// generate Readme
-		err = newGen.GenReadme(serviceName)
-		failOnInitError(err, `generate README.md `, serviceName)
+	return func(fileName string) (bool, error) {
+		opt := all
+		if opt == "" {
+			res, err := selectInit(conflictOpt, "File `"+fileName+"` already exist with different content")
+			if err != nil {
+				return false, err
+			}
+			opt = strings.TrimSuffix(res, " all")
+			if opt != res {
+				all = opt
+			}
+		}
 
-		// generate Dockerfile
-		err = newGen.GenDockerfile(serviceName)
-		failOnInitError(err, `generate Dockerfile `, serviceName)
+		if opt == "overwrite" {
+			return true, nil
+		}
+		log.Warnf("%s already exist, skipped", fileName)
+		return false, nil
 	}
-
-	log.Info("Congratulation, your `" + serviceName + "` service was successfully initiated !")
 }
 
-func failOnInitError(err error, msg string, svcName string) {
+// failOnInitError will undo every change of this run, so only files which were created by this run are removed
+func failOnInitError(newFs domain.FsService, err error, msg string) {
 	if err != nil {
-		newFs := fs.NewFsService()
 		log.Errorf("%s: %s", msg, err)
 
-		// remove directory of service
-		err = newFs.RemoveDir(svcName)
+		// nothing was written into disk on dry run
+		if dryRun {
+			os.Exit(1)
+		}
+
+		err = newFs.Rollback()
 		if err != nil {
 			log.Error(err)
 			os.Exit(1)
@@ -389,24 +552,6 @@ func failOnInitError(err error, msg string, svcName string) {
 	}
 }
 
-func execConfigDb(serviceName string, stdout bytes.Buffer, stderr bytes.Buffer) {
-	// create `database.yml` configuration
-	cmd := exec.Command("soda", "g", "config")
-	cmd.Dir = "./" + serviceName
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	err := cmd.Run()
-	failOnInitError(err, `create database.yml configuration error, you should install soda first. See : https://github.com/gobuffalo/pop`, serviceName)
-
-	// create example migration
-	cmd = exec.Command("soda", "generate", "-p", "./database/migrations", "fizz", "example")
-	cmd.Dir = "./" + serviceName
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	err = cmd.Run()
-	failOnInitError(err, `create example migration`, serviceName)
-}
-
 func promptInit(label string) (string, error) {
 	var result string
 
@@ -470,11 +615,16 @@ func init() {
 	initCmd.PersistentFlags().StringVar(&goModName, "gomod", "", "For initiate gomod name")
 	initCmd.PersistentFlags().StringVar(&serviceName, "service", "", "Name of service")
 	initCmd.PersistentFlags().StringVar(&dbHelper, "database", "", "Database helper library. Choose one of: gopg, gorm, sqlx, sql")
+	initCmd.PersistentFlags().StringVar(&sqlDialect, "dialect", "", "SQL dialect of sql and sqlx queries. Choose one of: mysql, postgres")
 	initCmd.PersistentFlags().StringVar(&restServer, "rest", "", "Rest API server library. Choose one of: echo, gin, gorilla mux, net/http")
+	initCmd.PersistentFlags().StringVar(&configFile, "config", "", "Specification file of service, every choice is taken from it without prompt")
 
-	initCmd.PersistentFlags().BoolVar(&overWrite, "overwrite", false, "True if will overwrite directory with the existing service name")
+	initCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the files which would be created without writing them")
+	initCmd.PersistentFlags().BoolVar(&overWrite, "overwrite", false, "True if will overwrite directory with the existing service name, it is archived before removed")
+	initCmd.PersistentFlags().BoolVar(&mergeDir, "merge", false, "True if will merge into directory with the existing service name, only missing files are written")
 	initCmd.PersistentFlags().BoolVar(&grpcOpt, "grpc", false, "True if generate grpc server")
 	initCmd.PersistentFlags().BoolVar(&graphqlOpt, "graphql", false, "True if will use graphql server")
+	initCmd.PersistentFlags().BoolVar(&problemJSONOpt, "problem-json", false, "True if rest server answers errors as application/problem+json of RFC 7807")
 
 	RootCmd.AddCommand(initCmd)
 }
@@ -0,0 +1,83 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wicaker/cacli/cmd"
+	"github.com/wicaker/cacli/fs"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitMergeCommand(t *testing.T) {
+	var (
+		newFs         = fs.NewFsService()
+		serviceName   = "test_generate"
+		migrationsDir = serviceName + "/database/migrations"
+		domainFile    = serviceName + "/domain/task.go"
+	)
+
+	specFile, err := ioutil.TempFile("", "cacli-spec-*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(specFile.Name())
+	_, err = specFile.WriteString(generateSpec)
+	assert.NoError(t, err)
+	specFile.Close()
+
+	cmd.RootCmd.SetArgs([]string{`init`, `--config=` + specFile.Name()})
+	cmd.RootCmd.Execute()
+
+	t.Run("success, should not migrate entity which has no change since the snapshot", func(t *testing.T) {
+		before, err := ioutil.ReadDir(migrationsDir)
+		assert.NoError(t, err)
+
+		cmd.RootCmd.SetArgs([]string{`init`, `--config=` + specFile.Name(), `--merge=true`})
+		cmd.RootCmd.Execute()
+
+		after, err := ioutil.ReadDir(migrationsDir)
+		assert.NoError(t, err)
+		assert.Equal(t, len(before), len(after))
+	})
+
+	t.Run("success, should only migrate the changes since the snapshot", func(t *testing.T) {
+		data, err := ioutil.ReadFile(domainFile)
+		assert.NoError(t, err)
+		data = []byte(strings.Replace(string(data), "type Task struct {", "type Task struct {\n\tDone bool `json:\"done\" db:\"done\"`", 1))
+		err = ioutil.WriteFile(domainFile, data, 0644)
+		assert.NoError(t, err)
+
+		cmd.RootCmd.SetArgs([]string{`init`, `--config=` + specFile.Name(), `--merge=true`})
+		cmd.RootCmd.Execute()
+
+		files, err := filepath.Glob(migrationsDir + "/*_alter_tasks.up.sql")
+		assert.NoError(t, err)
+		if assert.Len(t, files, 1) {
+			data, err = ioutil.ReadFile(files[0])
+			assert.NoError(t, err)
+			assert.Contains(t, string(data), "ADD COLUMN done")
+			assert.NotContains(t, string(data), "CREATE TABLE")
+		}
+	})
+
+	// clear the flags, so the next command of init neither merge nor run from the removed file
+	initCmd, _, err := cmd.RootCmd.Find([]string{`init`})
+	assert.NoError(t, err)
+	assert.NoError(t, initCmd.PersistentFlags().Set(`merge`, `false`))
+	resetConfigFlag(t)
+
+	// remove directory of service and its backups
+	backups, _ := filepath.Glob(serviceName + ".backup-*.tar.gz")
+	for _, b := range backups {
+		os.Remove(b)
+	}
+	err = newFs.RemoveDir(serviceName)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+}
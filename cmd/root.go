@@ -7,10 +7,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// Version of cacli, recorded into manifest of generated project
+var Version = "v0.1.0"
+
 // RootCmd root of cmd command
 var RootCmd = &cobra.Command{
-	Use:   "cacli",
-	Short: "Cacli help you to build clean code in golang project",
+	Use:     "cacli",
+	Short:   "Cacli help you to build clean code in golang project",
+	Version: Version,
 	Run: func(cmd *cobra.Command, args []string) {
 		cmd.Help()
 	},
@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/generator"
+	"github.com/wicaker/cacli/manifest"
+	"github.com/wicaker/cacli/parser"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// syncScratchDir is the directory of project which the layers are generated into before they are synced
+const syncScratchDir = ".cacli-sync"
+
+var (
+	syncPath string
+	syncCmd  = &cobra.Command{
+		Use:   "sync",
+		Short: "Add stubs of new domain methods into every layer and flag the removed ones",
+		Args:  cobra.NoArgs,
+		Run:   runSync,
+	}
+)
+
+// syncFile represent a file of layer which implement the domain interface
+type syncFile struct {
+	file string
+	hint func(method string) string
+}
+
+func runSync(cmd *cobra.Command, args []string) {
+	var (
+		newFs  = newFsService()
+		newGen = generator.NewGeneratorService(newFs)
+	)
+
+	// sync rewrites files which may have been edited by hand, so every change is undone on failure
+	prj, err := readProject(newFs, manifest.NewManifestService(newFs), syncPath)
+	failOnInitError(newFs, err, `read existing project `+syncPath)
+	newGen.Configure(domain.Generator{ProblemJSON: prj.ProblemJSON})
+
+	files, err := newFs.ReadDir(syncPath + "/domain")
+	failOnInitError(newFs, err, `read domain directory`)
+
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".go" {
+			continue
+		}
+		domainName := strings.TrimSuffix(f.Name(), ".go")
+
+		// only domain which already has usecase layer is synced
+		res, err := newFs.FindFile(syncPath + "/usecase/" + domainName + "_usecase.go")
+		failOnInitError(newFs, err, `find usecase of `+domainName)
+		if res == nil {
+			continue
+		}
+
+		err = syncDomain(newFs, newGen, syncPath, domainName, prj.GoModName, prj.Dialect)
+		failOnInitError(newFs, err, `sync domain `+domainName)
+	}
+
+	if dryRun {
+		printDryRun(os.Stdout, newFs)
+		return
+	}
+	log.Info("Project was successfully synced !")
+}
+
+// syncDomain will generate every layer of the domain into a scratch directory,
+// then copy the methods which are not exist yet in the project
+func syncDomain(newFs domain.FsService, newGen domain.GeneratorService, path string, domainName string, goModName string, dialect string) error {
+	var (
+		domainFile = domainName + ".go"
+		domainCap  = strings.ToUpper(string(domainName[0])) + domainName[1:]
+	)
+
+	par, err := parseDomain(newFs, path+"/domain/"+domainFile, domainName)
+	if err != nil {
+		return err
+	}
+
+	// mocks are not edited by hand, so they are regenerated instead of synced
+	err = generateMock(newFs, newGen, path, goModName, par)
+	if err != nil {
+		return err
+	}
+
+	// layers are generated through filesystem into scratch directory of the project, so dry run keeps them in memory,
+	// rollback on failure removes them with the other changes and removing them on return forgets them as changes
+	tmp := path + "/" + syncScratchDir
+	res, err := newFs.FindDir(tmp)
+	if err != nil {
+		return err
+	}
+	if res != nil {
+		// left by a sync which was killed
+		err = newFs.RemoveDir(tmp)
+		if err != nil {
+			return err
+		}
+	}
+	err = newFs.CreateDir(tmp)
+	if err != nil {
+		return err
+	}
+	defer newFs.RemoveDir(tmp)
+
+	for _, dir := range []string{"usecase", "repository", "transport", "transport/rest", "transport/graphql", "transport/grpc"} {
+		err = newFs.CreateDir(tmp + "/" + dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = generateUsecase(newGen, tmp, domainFile, goModName, par)
+	if err != nil {
+		return err
+	}
+
+	dbHelper, err := detectDbHelper(newFs, path+"/repository/"+domainName+"_repository.go", par.Repository.Name)
+	if err != nil {
+		return err
+	}
+	err = generateRepository(newGen, tmp, domainFile, goModName, dbHelper, dialect, par)
+	if err != nil {
+		return err
+	}
+
+	restServer, err := detectRestServer(newFs, path+"/transport/rest/"+domainName+"_handler.go")
+	if err != nil {
+		return err
+	}
+	err = generateTransport(newGen, tmp, domainFile, goModName, restServer, true, false, par)
+	if err != nil {
+		return err
+	}
+
+	// proto file is maintained by hand once it was compiled, only the handler is synced
+	err = newGen.GenGrpcTransport(tmp+"/transport/grpc", domainFile, goModName, par)
+	if err != nil {
+		return err
+	}
+
+	layers := []syncFile{
+		syncFile{file: "usecase/" + domainName + "_usecase.go"},
+		syncFile{file: "repository/" + domainName + "_repository.go"},
+		syncFile{file: "transport/rest/" + domainName + "_handler.go", hint: func(method string) string {
+			// net/http has no router, so the route is dispatched by ServeHTTP of the handler
+			if restServer == domain.NetHTTP {
+				return "dispatch the route of `" + method + "` in ServeHTTP of " + domainName + "Handler"
+			}
+			return "register the route of `" + method + "` in New" + domainCap + "Handler"
+		}},
+		syncFile{file: "transport/graphql/queries/" + domainName + ".go", hint: func(method string) string {
+			return "register `" + method + "` in GetRootQueryFields"
+		}},
+		syncFile{file: "transport/graphql/mutations/" + domainName + ".go", hint: func(method string) string {
+			return "register `" + method + "` in GetRootMutationFields"
+		}},
+		syncFile{file: "transport/grpc/" + domainName + "_handler.go", hint: func(method string) string {
+			return "add rpc " + method + " into proto/" + domainName + ".proto and regenerate it with protoc"
+		}},
+	}
+
+	for _, l := range layers {
+		err = syncLayer(newFs, newGen, path+"/"+l.file, tmp+"/"+l.file, path+"/domain/"+domainFile, l.hint)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncLayer will compare receiver methods of existing file with the generated one
+func syncLayer(newFs domain.FsService, newGen domain.GeneratorService, filePath string, stubPath string, domainPath string, hint func(method string) string) error {
+	for _, p := range []string{filePath, stubPath} {
+		res, err := newFs.FindFile(p)
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			return nil
+		}
+	}
+
+	existing, err := parser.NewParserGeneral(newFs).GeneralParser(filePath)
+	if err != nil {
+		return err
+	}
+	generated, err := parser.NewParserGeneral(newFs).GeneralParser(stubPath)
+	if err != nil {
+		return err
+	}
+
+	var missing []domain.Receiver
+	for _, g := range generated.Receiver {
+		e, ok := findReceiver(existing.Receiver, g.Name)
+		if !ok {
+			log.Warnf("%s: receiver %s not found, skipped", filePath, g.Name)
+			continue
+		}
+
+		recv := domain.Receiver{Name: g.Name}
+		for _, m := range g.Method {
+			em, ok := findMethod(e.Method, m.Name)
+			if !ok {
+				recv.Method = append(recv.Method, m)
+				continue
+			}
+			if !sameSignature(em, m) {
+				log.Warnf("%s: signature of `%s` is different with domain, update it manually", filePath, m.Name)
+			}
+		}
+		for _, m := range e.Method {
+			if _, ok := findMethod(g.Method, m.Name); !ok && isExported(m.Name) {
+				log.Warnf("%s: `%s` is no longer declared in domain, remove it manually", filePath, m.Name)
+			}
+		}
+
+		if len(recv.Method) > 0 {
+			missing = append(missing, recv)
+		}
+	}
+
+	err = newGen.GenStub(filePath, stubPath, missing, domainPath)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range missing {
+		for _, m := range r.Method {
+			log.Infof("%s: stub of `%s` was added", filePath, m.Name)
+
+			// unexported method is a helper of the handler, e.g. bind<Method>, so it is not registered anywhere
+			if hint != nil && isExported(m.Name) {
+				log.Warnf("%s: %s", filePath, hint(m.Name))
+			}
+		}
+	}
+
+	return nil
+}
+
+// detectDbHelper will detect database helper library from receiver name of repository layer
+func detectDbHelper(newFs domain.FsService, filePath string, repository string) (string, error) {
+	res, err := newFs.FindFile(filePath)
+	if err != nil || res == nil {
+		return "", err
+	}
+
+	par, err := parser.NewParserGeneral(newFs).GeneralParser(filePath)
+	if err != nil {
+		return "", err
+	}
+	for _, lib := range []string{domain.GoPg, domain.Gorm, domain.Sqlx, domain.SQL, domain.Mongod} {
+		if _, ok := findReceiver(par.Receiver, lib+repository); ok {
+			return lib, nil
+		}
+	}
+	return "", fmt.Errorf("%s: repository receiver not found", filePath)
+}
+
+// detectRestServer will detect rest server library from parameter of handler methods
+func detectRestServer(newFs domain.FsService, filePath string) (string, error) {
+	res, err := newFs.FindFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	if res == nil {
+		return "no", nil
+	}
+
+	par, err := parser.NewParserGeneral(newFs).GeneralParser(filePath)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range par.Receiver {
+		if _, ok := findMethod(r.Method, "ServeHTTP"); ok {
+			return domain.NetHTTP, nil
+		}
+		for _, m := range r.Method {
+			if len(m.ParameterList) == 0 {
+				continue
+			}
+			switch m.ParameterList[0].Type {
+			case "echo.Context":
+				return domain.Echo, nil
+			case "*gin.Context":
+				return domain.Gin, nil
+			case "http.ResponseWriter":
+				return domain.GorillaMux, nil
+			}
+		}
+	}
+	return "no", nil
+}
+
+func findReceiver(receiver []domain.Receiver, name string) (domain.Receiver, bool) {
+	for _, r := range receiver {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return domain.Receiver{}, false
+}
+
+func findMethod(method []domain.Method, name string) (domain.Method, bool) {
+	for _, m := range method {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return domain.Method{}, false
+}
+
+// sameSignature compare type of parameters and results, the name is ignored
+func sameSignature(a domain.Method, b domain.Method) bool {
+	if len(a.ParameterList) != len(b.ParameterList) || len(a.ResultList) != len(b.ResultList) {
+		return false
+	}
+	for i := range a.ParameterList {
+		if a.ParameterList[i].Type != b.ParameterList[i].Type {
+			return false
+		}
+	}
+	for i := range a.ResultList {
+		if a.ResultList[i].Type != b.ResultList[i].Type {
+			return false
+		}
+	}
+	return true
+}
+
+func isExported(name string) bool {
+	return name != "" && strings.ToUpper(name[:1]) == name[:1]
+}
+
+func init() {
+	syncCmd.Flags().StringVar(&syncPath, "path", ".", "Root directory of project which initiated by cacli")
+	syncCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the files which would be changed without writing them")
+
+	RootCmd.AddCommand(syncCmd)
+}
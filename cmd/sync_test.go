@@ -0,0 +1,156 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/wicaker/cacli/cmd"
+	"github.com/wicaker/cacli/fs"
+)
+
+const syncSpec = `service: test_sync
+module: github.com/wicaker/testsync
+database: sqlx
+dialect: postgres
+transports:
+  rest: echo
+entities:
+  - name: task
+    fields:
+      - name: ID
+        type: uint64
+      - name: Title
+        type: string
+`
+
+func TestSyncCommand(t *testing.T) {
+	var (
+		newFs       = fs.NewFsService()
+		serviceName = "test_sync"
+	)
+
+	specFile, err := ioutil.TempFile("", "cacli-spec-*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(specFile.Name())
+	_, err = specFile.WriteString(syncSpec)
+	assert.NoError(t, err)
+	specFile.Close()
+
+	cmd.RootCmd.SetArgs([]string{`init`, `--config=` + specFile.Name()})
+	cmd.RootCmd.Execute()
+
+	// declare a new method in usecase contract of domain
+	domainFile := serviceName + "/domain/task.go"
+	data, err := ioutil.ReadFile(domainFile)
+	assert.NoError(t, err)
+	data = []byte(strings.Replace(string(data), "type TaskUsecase interface {", "type TaskUsecase interface {\n\tArchive(ctx context.Context, id uint64) error", 1))
+	err = ioutil.WriteFile(domainFile, data, 0644)
+	assert.NoError(t, err)
+
+	t.Run("success, should not write any file on dry run", func(t *testing.T) {
+		before, err := ioutil.ReadFile(serviceName + "/usecase/task_usecase.go")
+		assert.NoError(t, err)
+
+		cmd.RootCmd.SetArgs([]string{`sync`, `--path=` + serviceName, `--dry-run=true`})
+		cmd.RootCmd.Execute()
+
+		after, err := ioutil.ReadFile(serviceName + "/usecase/task_usecase.go")
+		assert.NoError(t, err)
+		assert.Equal(t, string(before), string(after))
+
+		// layers of sync are generated in memory too
+		_, err = os.Stat(serviceName + "/.cacli-sync")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("success, should add stub of new domain method into project with rest transport", func(t *testing.T) {
+		cmd.RootCmd.SetArgs([]string{`sync`, `--path=` + serviceName, `--dry-run=false`})
+		cmd.RootCmd.Execute()
+
+		data, err := ioutil.ReadFile(serviceName + "/usecase/task_usecase.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "Archive(ctx context.Context, id uint64) error")
+		assert.Contains(t, string(data), `return errors.New("Archive is not implemented")`)
+		assert.Contains(t, string(data), `"errors"`)
+
+		data, err = ioutil.ReadFile(serviceName + "/transport/rest/task_handler.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "ArchiveHandler(")
+
+		_, err = os.Stat(serviceName + "/.cacli-sync")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	// remove directory of service
+	err = newFs.RemoveDir(serviceName)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+}
+
+func TestSyncCommandHint(t *testing.T) {
+	var (
+		newFs       = fs.NewFsService()
+		serviceName = "test_sync"
+	)
+
+	tests := []struct {
+		name       string
+		restServer string
+		wantHint   string
+	}{
+		{
+			name:       "success, should hint the registration in constructor of handler for echo",
+			restServer: "echo",
+			wantHint:   "register the route of `ArchiveHandler` in NewTaskHandler",
+		},
+		{
+			name:       "success, should hint the dispatch in ServeHTTP for net/http",
+			restServer: "net/http",
+			wantHint:   "dispatch the route of `ArchiveHandler` in ServeHTTP of taskHandler",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specFile, err := ioutil.TempFile("", "cacli-spec-*.yaml")
+			assert.NoError(t, err)
+			defer os.Remove(specFile.Name())
+			_, err = specFile.WriteString(strings.Replace(syncSpec, "rest: echo", "rest: "+tt.restServer, 1))
+			assert.NoError(t, err)
+			specFile.Close()
+
+			cmd.RootCmd.SetArgs([]string{`init`, `--config=` + specFile.Name()})
+			cmd.RootCmd.Execute()
+			defer newFs.RemoveDir(serviceName)
+
+			domainFile := serviceName + "/domain/task.go"
+			data, err := ioutil.ReadFile(domainFile)
+			assert.NoError(t, err)
+			data = []byte(strings.Replace(string(data), "type TaskUsecase interface {", "type TaskUsecase interface {\n\tArchive(ctx context.Context, id uint64) error", 1))
+			err = ioutil.WriteFile(domainFile, data, 0644)
+			assert.NoError(t, err)
+
+			hook := logtest.NewGlobal()
+			defer hook.Reset()
+
+			cmd.RootCmd.SetArgs([]string{`sync`, `--path=` + serviceName, `--dry-run=false`})
+			cmd.RootCmd.Execute()
+
+			var hints []string
+			for _, e := range hook.AllEntries() {
+				if e.Level == log.WarnLevel && strings.HasPrefix(e.Message, serviceName+"/transport/rest/") {
+					hints = append(hints, e.Message)
+				}
+			}
+			assert.Equal(t, []string{serviceName + "/transport/rest/task_handler.go: " + tt.wantHint}, hints)
+		})
+	}
+	resetConfigFlag(t)
+}
@@ -0,0 +1,27 @@
+package domain
+
+// Entity /
+type Entity struct {
+	Name   string
+	Doc    string
+	Fields []Field
+}
+
+// Field /
+// Type is written as it is seen from outside of domain package, e.g. *time.Time or domain.Status,
+// Pointer and Slice describe the outermost type of field
+type Field struct {
+	Name     string
+	Type     string
+	Tags     map[string]string
+	Pointer  bool
+	Slice    bool
+	Embedded bool
+	Doc      string
+	Comment  string
+}
+
+// Tag will return value of the given tag key, e.g. Tag("db") of `db:"created_at"` is created_at
+func (f Field) Tag(key string) string {
+	return f.Tags[key]
+}
@@ -9,4 +9,20 @@ type FsService interface {
 	CreateDir(dirName string) error
 	RemoveDir(dirName string) error
 	ReadDir(dirName string) ([]os.FileInfo, error)
+	ListFiles(dirName string) ([]string, error)
+	ReadFile(fileName string) ([]byte, error)
+	WriteFile(fileName string, data []byte) error
+	Archive(dirName string, fileName string) error
+	Changes() []FileChange
+	Rollback() error
+}
+
+// FileChange represent a file which was written or removed through FsService,
+// Before is the content of file before the first change and After is the latest content of file
+type FileChange struct {
+	Name    string
+	Before  []byte
+	After   []byte
+	Created bool
+	Removed bool
 }
@@ -1,30 +1,41 @@
 package domain
 
-// Generator domain /
+// Generator represent the options which change the generated codes
 type Generator struct {
+	// ProblemJSON makes the rest transports answer errors as application/problem+json of RFC 7807
+	ProblemJSON bool
 }
 
 // GeneratorService /
 type GeneratorService interface {
+	Configure(option Generator)
+
 	GenDomainErrors(dirName string) error
 	GenDomainStatusCode(dirName string) error
+	GenDomainProblem(dirName string) error
 	GenDomainSuccess(dirName string) error
 	GenDomainExample(dirName string) error
+	GenDomain(dirName string, domainName string) error
+	GenDomainSpec(dirName string, entity SpecEntity) error
 
 	GenUsecase(dirName string, domainName string, gomodName string, parser *Parser) error
+	GenMock(dirName string, gomodName string, parser *Parser) error
 
 	GenGopgRepository(dirName string, domainName string, gomodName string, parser *Parser) error
 	GenGormRepository(dirName string, domainName string, gomodName string, parser *Parser) error
-	GenSQLRepository(dirName string, domainName string, gomodName string, parser *Parser) error
-	GenSqlxRepository(dirName string, domainName string, gomodName string, parser *Parser) error
+	GenSQLRepository(dirName string, domainName string, gomodName string, dialect string, parser *Parser) error
+	GenSqlxRepository(dirName string, domainName string, gomodName string, dialect string, parser *Parser) error
 	GenMongodRepository(dirName string, domainName string, gomodName string, parser *Parser) error
 
 	GenGopgConfig(dirName string) error
 	GenGormConfig(dirName string) error
-	GenSQLConfig(dirName string) error
-	GenSqlxConfig(dirName string) error
+	GenSQLConfig(dirName string, dialect string) error
+	GenSqlxConfig(dirName string, dialect string) error
 	GenMongodConfig(dirName string) error
 
+	GenMigration(dirName string, version string, format string, dialect string, previous *Table, table *Table) error
+	GenMigrationRunner(dirName string, repoLib string) error
+
 	GenEchoTransport(dirName string, domainFile string, gomodName string, parser *Parser) error
 	GenGinTransport(dirName string, domainFile string, gomodName string, parser *Parser) error
 	GenGorillaMuxTransport(dirName string, domainFile string, gomodName string, parser *Parser) error
@@ -45,10 +56,15 @@ type GeneratorService interface {
 	GenGinMiddleware(dirName string) error
 	GenGorillaMuxMiddleware(dirName string) error
 	GenNetHTTPMiddleware(dirName string) error
+	GenRecoverMiddleware(dirName string, gomodName string, restServer string) error
+	GenValidationMiddleware(dirName string) error
 
+	GenGoMod(dirName string, gomodName string) error
 	GenMain(dirName string, gomodName string, repoLib string, transport []string) error
 	GenEnv(dirName string) error
-	GenReadme(dirName string) error
+	GenReadme(dirName string, repoLib string) error
 	GenDockerfile(dirName string) error
 	GenGitIgnore(dirName string) error
+
+	GenStub(filePath string, stubPath string, receiver []Receiver, importFrom ...string) error
 }
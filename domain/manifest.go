@@ -0,0 +1,27 @@
+package domain
+
+var (
+	// ManifestFile name of manifest file in root of project
+	ManifestFile = ".cacli.yaml"
+)
+
+// Manifest represent the choices which were made when the project was generated
+type Manifest struct {
+	Version     string   `yaml:"version" mapstructure:"version"`
+	GoModName   string   `yaml:"goModName" mapstructure:"goModName"`
+	DbHelper    string   `yaml:"dbHelper" mapstructure:"dbHelper"`
+	Dialect     string   `yaml:"dialect,omitempty" mapstructure:"dialect"`
+	RestServer  string   `yaml:"restServer" mapstructure:"restServer"`
+	GraphqlOpt  bool     `yaml:"graphqlOpt" mapstructure:"graphqlOpt"`
+	GrpcOpt     bool     `yaml:"grpcOpt" mapstructure:"grpcOpt"`
+	ProblemJSON bool     `yaml:"problemJSON,omitempty" mapstructure:"problemJSON"`
+	Domains     []string `yaml:"domains" mapstructure:"domains"`
+	Files       []string `yaml:"files" mapstructure:"files"`
+	Tables      []Table  `yaml:"tables,omitempty" mapstructure:"tables"`
+}
+
+// ManifestService /
+type ManifestService interface {
+	Read(dirName string) (*Manifest, error)
+	Write(dirName string, manifest *Manifest) error
+}
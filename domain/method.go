@@ -1,10 +1,12 @@
 package domain
 
 // Method /
+// Doc is the comment written above the method in interface, it may contain annotation such as @route
 type Method struct {
 	Name          string
 	ParameterList []MethodValue
 	ResultList    []MethodValue
+	Doc           string
 }
 
 // MethodValue /
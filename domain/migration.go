@@ -0,0 +1,29 @@
+package domain
+
+import "errors"
+
+var (
+	// SQLMigration migration format which written as plain sql of the chosen dialect
+	SQLMigration = "sql"
+	// FizzMigration migration format which run by soda, see https://gobuffalo.io/en/docs/db/fizz/
+	FizzMigration = "fizz"
+
+	// ErrNoMigration will throw if the entity has no change since the last migration
+	ErrNoMigration = errors.New("entity has no change since the last migration")
+)
+
+// Table represent the snapshot of entity table when its migration was generated,
+// the next migration alter the table based on the difference with this snapshot
+type Table struct {
+	Domain  string   `yaml:"domain" mapstructure:"domain"`
+	Name    string   `yaml:"name" mapstructure:"name"`
+	Columns []Column `yaml:"columns" mapstructure:"columns"`
+}
+
+// Column represent a field of entity which is stored as a column,
+// Type is the go type of field, e.g. *time.Time is a nullable timestamp
+type Column struct {
+	Name    string `yaml:"name" mapstructure:"name"`
+	Type    string `yaml:"type" mapstructure:"type"`
+	Primary bool   `yaml:"primary,omitempty" mapstructure:"primary"`
+}
@@ -17,6 +17,10 @@ var (
 	SQL = "sql"
 	// Mongod database handler option
 	Mongod = "mongod"
+	// MySQL dialect option of sql and sqlx database handler
+	MySQL = "mysql"
+	// Postgres dialect option of sql and sqlx database handler
+	Postgres = "postgres"
 	// Echo server handler option
 	Echo = "echo"
 	// Gin server handler option
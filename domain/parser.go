@@ -2,9 +2,13 @@ package domain
 
 // Parser /
 type Parser struct {
+	Entity
 	Repository
 	Usecase
 	Handler
+	Receiver []Receiver
+	// Imports is import path of the parsed file by its package name, so the types of methods are able to be qualified
+	Imports map[string]string
 }
 
 // ParserDomain /
@@ -20,6 +24,17 @@ type ParserGeneral interface {
 var (
 	// MockParser used for mock data testing
 	MockParser = &Parser{
+		Entity: Entity{
+			Name: "Example",
+			Doc:  "Example struct, models of example table",
+			Fields: []Field{
+				Field{Name: "ID", Type: "uint64", Tags: map[string]string{"json": "id", "bson": "_id"}},
+				Field{Name: "Name", Type: "string", Tags: map[string]string{"json": "name", "bson": "name"}},
+				Field{Name: "CreatedAt", Type: "time.Time", Tags: map[string]string{"db": "created_at", "json": "created_at", "bson": "created_at"}},
+				Field{Name: "UpdatedAt", Type: "time.Time", Tags: map[string]string{"db": "updated_at", "json": "updated_at", "bson": "updated_at"}},
+				Field{Name: "DeletedAt", Type: "*time.Time", Tags: map[string]string{"db": "deleted_at", "json": "deleted_at", "bson": "deleted_at", "pg": ",soft_delete"}, Pointer: true},
+			},
+		},
 		Usecase: Usecase{
 			Name: "ExampleUsecase",
 			Method: []Method{
@@ -0,0 +1,7 @@
+package domain
+
+// Receiver /
+type Receiver struct {
+	Name   string
+	Method []Method
+}
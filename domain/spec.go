@@ -0,0 +1,50 @@
+package domain
+
+// Spec represent the declarative specification of service which used by `init --config`
+type Spec struct {
+	Service    string
+	Module     string
+	Database   string
+	Dialect    string
+	Transports SpecTransport
+	Entities   []SpecEntity
+}
+
+// SpecTransport /
+// ProblemJSON makes the rest transport answer errors as application/problem+json of RFC 7807
+type SpecTransport struct {
+	Rest        string
+	Graphql     bool
+	Grpc        bool
+	ProblemJSON bool
+}
+
+// SpecEntity /
+type SpecEntity struct {
+	Name    string
+	Imports []string
+	Fields  []SpecField
+	Methods []SpecMethod
+}
+
+// SpecField /
+type SpecField struct {
+	Name    string
+	Type    string
+	Tags    map[string]string
+	Comment string
+}
+
+// SpecMethod /
+// Route override the rest endpoint of method, written as `VERB /path` e.g. `PUT /tasks/:id/done`
+type SpecMethod struct {
+	Name    string
+	Params  []string
+	Results []string
+	Route   string
+}
+
+// SpecService /
+type SpecService interface {
+	Read(fileName string) (*Spec, error)
+}
@@ -0,0 +1,68 @@
+package fs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// Archive is a method for compress every file inside a directory into a tar.gz file,
+// the archive is not recorded as a change, so it is kept on rollback
+func (f *caFs) Archive(dirName string, fileName string) error {
+	files, err := f.ListFiles(dirName)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	err = f.writeArchive(buf, dirName, files)
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(f.fs, fileName, buf.Bytes(), 0644)
+}
+
+func (f *caFs) writeArchive(w io.Writer, dirName string, files []string) error {
+	var (
+		gw   = gzip.NewWriter(w)
+		tw   = tar.NewWriter(gw)
+		base = filepath.Base(filepath.Clean(dirName))
+	)
+
+	for _, file := range files {
+		name := filepath.Join(dirName, filepath.FromSlash(file))
+		info, err := f.fs.Stat(name)
+		if err != nil {
+			return err
+		}
+		data, err := afero.ReadFile(f.fs, name)
+		if err != nil {
+			return err
+		}
+
+		err = tw.WriteHeader(&tar.Header{
+			Name:    base + "/" + file,
+			Mode:    int64(info.Mode() & os.ModePerm),
+			Size:    int64(len(data)),
+			ModTime: info.ModTime(),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := tw.Close()
+	if err != nil {
+		return err
+	}
+	return gw.Close()
+}
@@ -0,0 +1,146 @@
+package fs
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around every change
+const diffContext = 3
+
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// Diff will return the unified diff between before and after content of a file, empty if both are equal
+func Diff(fileName string, before []byte, after []byte) string {
+	if bytes.Equal(before, after) {
+		return ""
+	}
+
+	lines := diffLines(splitLines(before), splitLines(after))
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "--- a/%s\n+++ b/%s\n", fileName, fileName)
+
+	// line number of before and after at start of lines[i]
+	beforeLine := make([]int, len(lines)+1)
+	afterLine := make([]int, len(lines)+1)
+	for i, l := range lines {
+		beforeLine[i+1], afterLine[i+1] = beforeLine[i], afterLine[i]
+		if l.op != '+' {
+			beforeLine[i+1]++
+		}
+		if l.op != '-' {
+			afterLine[i+1]++
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			i++
+			continue
+		}
+
+		// extend hunk until the gap of unchanged lines is wider than both contexts
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for end < len(lines) {
+			if lines[end].op != ' ' {
+				end++
+				continue
+			}
+			gap := end
+			for gap < len(lines) && lines[gap].op == ' ' {
+				gap++
+			}
+			if gap == len(lines) || gap-end > 2*diffContext {
+				break
+			}
+			end = gap
+		}
+		stop := end + diffContext
+		if stop > len(lines) {
+			stop = len(lines)
+		}
+
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n",
+			hunkRange(beforeLine[start], beforeLine[stop]-beforeLine[start]),
+			hunkRange(afterLine[start], afterLine[stop]-afterLine[start]),
+		)
+		for _, l := range lines[start:stop] {
+			buf.WriteByte(l.op)
+			buf.WriteString(l.text)
+			buf.WriteByte('\n')
+		}
+		i = stop
+	}
+
+	return buf.String()
+}
+
+func hunkRange(start int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
+
+// diffLines will compare a and b line by line based on their longest common subsequence
+func diffLines(a []string, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var (
+		lines []diffLine
+		i, j  int
+	)
+	for i < len(a) && j < len(b) {
+		if a[i] == b[j] {
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		} else {
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
@@ -0,0 +1,46 @@
+package fs_test
+
+import (
+	"testing"
+
+	"github.com/wicaker/cacli/fs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("success, should return empty diff of equal content", func(t *testing.T) {
+		assert.Equal(t, "", fs.Diff("main.go", []byte("a\nb\n"), []byte("a\nb\n")))
+	})
+
+	t.Run("success, should return diff of new file", func(t *testing.T) {
+		expected := `--- a/main.go
++++ b/main.go
+@@ -0,0 +1,2 @@
++a
++b
+`
+		assert.Equal(t, expected, fs.Diff("main.go", nil, []byte("a\nb\n")))
+	})
+
+	t.Run("success, should split distant changes into hunks", func(t *testing.T) {
+		before := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n")
+		after := []byte("1\ntwo\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n")
+		expected := `--- a/main.go
++++ b/main.go
+@@ -1,5 +1,5 @@
+ 1
+-2
++two
+ 3
+ 4
+ 5
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`
+		assert.Equal(t, expected, fs.Diff("main.go", before, after))
+	})
+}
@@ -5,6 +5,8 @@ package fs
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/wicaker/cacli/domain"
 
@@ -12,14 +14,75 @@ import (
 )
 
 type caFs struct {
-	fs afero.Fs
+	fs      afero.Fs
+	changes []*domain.FileChange
+	written map[string]*domain.FileChange
+	dirs    []string
+
+	// only used by dry run
+	layer afero.Fs
+	mask  *maskFs
+}
+
+// maskFs hide the removed directories of the underlying filesystem,
+// so a dry run is able to remove a directory without touching the disk
+type maskFs struct {
+	afero.Fs
+	removed []string
+}
+
+func (m *maskFs) isRemoved(name string) bool {
+	name = filepath.Clean(name)
+	for _, r := range m.removed {
+		if name == r || strings.HasPrefix(name, r+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *maskFs) Open(name string) (afero.File, error) {
+	if m.isRemoved(name) {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return m.Fs.Open(name)
+}
+
+func (m *maskFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if m.isRemoved(name) {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return m.Fs.OpenFile(name, flag, perm)
+}
+
+func (m *maskFs) Stat(name string) (os.FileInfo, error) {
+	if m.isRemoved(name) {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+	return m.Fs.Stat(name)
 }
 
 // NewFsService will create new a caFs object representation of domain.FsService interface
 func NewFsService() domain.FsService {
 	fs := afero.NewOsFs()
 	return &caFs{
-		fs,
+		fs:      fs,
+		written: map[string]*domain.FileChange{},
+	}
+}
+
+// NewDryRunFsService will create new a caFs object representation of domain.FsService interface,
+// every file is read from the os filesystem but written into memory, so nothing is changed on disk
+func NewDryRunFsService() domain.FsService {
+	var (
+		mask  = &maskFs{Fs: afero.NewReadOnlyFs(afero.NewOsFs())}
+		layer = afero.NewMemMapFs()
+	)
+	return &caFs{
+		fs:      afero.NewCopyOnWriteFs(mask, layer),
+		written: map[string]*domain.FileChange{},
+		layer:   layer,
+		mask:    mask,
 	}
 }
 
@@ -49,18 +112,63 @@ func (f *caFs) CreateDir(dirName string) error {
 	if err != nil {
 		return err
 	}
+	f.dirs = append(f.dirs, filepath.Clean(dirName))
 	return nil
 }
 
-// RemoveDir is a method for remove a directory based on directory name
+// RemoveDir is a method for remove a directory based on directory name,
+// every removed file is recorded as a change
 func (f *caFs) RemoveDir(dirName string) error {
-	err := f.fs.RemoveAll(dirName)
+	dirName = filepath.Clean(dirName)
+
+	files, err := f.ListFiles(dirName)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, file := range files {
+		f.recordRemove(filepath.Join(dirName, filepath.FromSlash(file)))
+	}
+
+	if f.mask != nil {
+		f.mask.removed = append(f.mask.removed, dirName)
+		return f.layer.RemoveAll(dirName)
+	}
+
+	err = f.fs.RemoveAll(dirName)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// recordRemove will record a file as removed, a file which was created by this session is forgotten
+func (f *caFs) recordRemove(fileName string) {
+	change, ok := f.written[fileName]
+	if !ok {
+		before, err := afero.ReadFile(f.fs, fileName)
+		if err != nil {
+			return
+		}
+		change = &domain.FileChange{Name: fileName, Before: before}
+		f.written[fileName] = change
+		f.changes = append(f.changes, change)
+	}
+
+	if change.Created {
+		delete(f.written, fileName)
+		for i, c := range f.changes {
+			if c == change {
+				f.changes = append(f.changes[:i], f.changes[i+1:]...)
+				break
+			}
+		}
+		return
+	}
+
+	change.After = nil
+	change.Removed = true
+}
+
 // ReadDir is a method for read a directory based on directory name
 func (f *caFs) ReadDir(dirName string) ([]os.FileInfo, error) {
 	res, err := afero.ReadDir(f.fs, dirName)
@@ -70,3 +178,121 @@ func (f *caFs) ReadDir(dirName string) ([]os.FileInfo, error) {
 
 	return res, nil
 }
+
+// ListFiles is a method for list every file inside a directory recursively, path is relative to directory name
+func (f *caFs) ListFiles(dirName string) ([]string, error) {
+	var files []string
+	err := afero.Walk(f.fs, dirName, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(dirName, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// ReadFile is a method for read content of a file based on file name
+func (f *caFs) ReadFile(fileName string) ([]byte, error) {
+	return afero.ReadFile(f.fs, fileName)
+}
+
+// WriteFile is a method for write content into a file, the directory of file must already exist
+func (f *caFs) WriteFile(fileName string, data []byte) error {
+	fileName = filepath.Clean(fileName)
+
+	info, err := f.fs.Stat(filepath.Dir(fileName))
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return &os.PathError{Op: "open", Path: fileName, Err: os.ErrNotExist}
+	}
+
+	change, ok := f.written[fileName]
+	if !ok {
+		change = &domain.FileChange{Name: fileName, Created: true}
+		if before, err := afero.ReadFile(f.fs, fileName); err == nil {
+			change.Before = before
+			change.Created = false
+		}
+	}
+
+	err = afero.WriteFile(f.fs, fileName, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	change.After = data
+	change.Removed = false
+	if !ok {
+		f.written[fileName] = change
+		f.changes = append(f.changes, change)
+	}
+	return nil
+}
+
+// Changes is a method for get every file which was written, ordered by the first time it was written
+func (f *caFs) Changes() []domain.FileChange {
+	changes := make([]domain.FileChange, len(f.changes))
+	for i, c := range f.changes {
+		changes[i] = *c
+	}
+	return changes
+}
+
+// Rollback is a method for undo every change, files and directories which were created are removed,
+// files which were written or removed are restored to their content before the first change
+func (f *caFs) Rollback() error {
+	for i := len(f.changes) - 1; i >= 0; i-- {
+		c := f.changes[i]
+		if c.Created {
+			err := f.fs.Remove(c.Name)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+
+		err := f.fs.MkdirAll(filepath.Dir(c.Name), 0755)
+		if err != nil {
+			return err
+		}
+		err = afero.WriteFile(f.fs, c.Name, c.Before, 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	// directory is only removed when it is empty, so restored files are kept
+	for i := len(f.dirs) - 1; i >= 0; i-- {
+		res, err := afero.ReadDir(f.fs, f.dirs[i])
+		if err != nil || len(res) > 0 {
+			continue
+		}
+		err = f.fs.Remove(f.dirs[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	f.changes = nil
+	f.written = map[string]*domain.FileChange{}
+	f.dirs = nil
+	return nil
+}
@@ -0,0 +1,239 @@
+package fs_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/fs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFsWriteFile(t *testing.T) {
+	dirName := "testfswrite"
+	newFs := fs.NewFsService()
+
+	t.Run("success, should record the written files", func(t *testing.T) {
+		err := newFs.CreateDir(dirName)
+		assert.NoError(t, err)
+		defer newFs.RemoveDir(dirName)
+
+		err = newFs.WriteFile(dirName+"/a.go", []byte("package a\n"))
+		assert.NoError(t, err)
+		err = newFs.WriteFile(dirName+"/a.go", []byte("package b\n"))
+		assert.NoError(t, err)
+
+		res, err := newFs.ReadFile(dirName + "/a.go")
+		assert.NoError(t, err)
+		assert.Equal(t, "package b\n", string(res))
+		assert.Equal(t, []domain.FileChange{
+			{Name: filepath.Join(dirName, "a.go"), After: []byte("package b\n"), Created: true},
+		}, newFs.Changes())
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		err := newFs.WriteFile(dirName+"/b.go", []byte("package b\n"))
+		assert.Error(t, err)
+	})
+}
+
+func TestDryRunFs(t *testing.T) {
+	dirName := "testfsdryrun"
+	newFs := fs.NewFsService()
+
+	err := newFs.CreateDir(dirName)
+	assert.NoError(t, err)
+	defer newFs.RemoveDir(dirName)
+	err = newFs.WriteFile(dirName+"/a.go", []byte("package a\n"))
+	assert.NoError(t, err)
+
+	t.Run("success, should not touch the disk", func(t *testing.T) {
+		dryFs := fs.NewDryRunFsService()
+
+		err := dryFs.WriteFile(dirName+"/a.go", []byte("package b\n"))
+		assert.NoError(t, err)
+		err = dryFs.CreateDir(dirName + "/sub")
+		assert.NoError(t, err)
+		err = dryFs.WriteFile(dirName+"/sub/c.go", []byte("package c\n"))
+		assert.NoError(t, err)
+
+		res, err := dryFs.ReadFile(dirName + "/a.go")
+		assert.NoError(t, err)
+		assert.Equal(t, "package b\n", string(res))
+		assert.Equal(t, []domain.FileChange{
+			{Name: filepath.Join(dirName, "a.go"), Before: []byte("package a\n"), After: []byte("package b\n")},
+			{Name: filepath.Join(dirName, "sub", "c.go"), After: []byte("package c\n"), Created: true},
+		}, dryFs.Changes())
+
+		res, err = newFs.ReadFile(dirName + "/a.go")
+		assert.NoError(t, err)
+		assert.Equal(t, "package a\n", string(res))
+		_, err = os.Stat(dirName + "/sub")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("success, should remove directory without touching the disk", func(t *testing.T) {
+		dryFs := fs.NewDryRunFsService()
+
+		err := dryFs.RemoveDir(dirName)
+		assert.NoError(t, err)
+		res, err := dryFs.FindDir(dirName)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+		assert.Equal(t, []domain.FileChange{
+			{Name: filepath.Join(dirName, "a.go"), Before: []byte("package a\n"), Removed: true},
+		}, dryFs.Changes())
+
+		err = dryFs.CreateDir(dirName)
+		assert.NoError(t, err)
+		files, err := dryFs.ListFiles(dirName)
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+
+		_, err = os.Stat(dirName + "/a.go")
+		assert.NoError(t, err)
+	})
+}
+
+func TestFsRollback(t *testing.T) {
+	dirName := "testfsrollback"
+	newFs := fs.NewFsService()
+
+	err := newFs.CreateDir(dirName)
+	assert.NoError(t, err)
+	defer newFs.RemoveDir(dirName)
+	err = newFs.WriteFile(dirName+"/a.go", []byte("package a\n"))
+	assert.NoError(t, err)
+
+	t.Run("success, should only remove the created files", func(t *testing.T) {
+		runFs := fs.NewFsService()
+
+		err := runFs.WriteFile(dirName+"/a.go", []byte("package b\n"))
+		assert.NoError(t, err)
+		err = runFs.CreateDir(dirName + "/sub")
+		assert.NoError(t, err)
+		err = runFs.WriteFile(dirName+"/sub/c.go", []byte("package c\n"))
+		assert.NoError(t, err)
+
+		err = runFs.Rollback()
+		assert.NoError(t, err)
+
+		files, err := newFs.ListFiles(dirName)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.go"}, files)
+		res, err := newFs.ReadFile(dirName + "/a.go")
+		assert.NoError(t, err)
+		assert.Equal(t, "package a\n", string(res))
+		assert.Empty(t, runFs.Changes())
+	})
+
+	t.Run("success, should restore the removed directory", func(t *testing.T) {
+		runFs := fs.NewFsService()
+
+		err := runFs.RemoveDir(dirName)
+		assert.NoError(t, err)
+		err = runFs.Rollback()
+		assert.NoError(t, err)
+
+		res, err := newFs.ReadFile(dirName + "/a.go")
+		assert.NoError(t, err)
+		assert.Equal(t, "package a\n", string(res))
+	})
+}
+
+func TestFsArchive(t *testing.T) {
+	dirName := "testfsarchive"
+	newFs := fs.NewFsService()
+
+	err := newFs.CreateDir(dirName)
+	assert.NoError(t, err)
+	defer newFs.RemoveDir(dirName)
+	err = newFs.WriteFile(dirName+"/a.go", []byte("package a\n"))
+	assert.NoError(t, err)
+
+	t.Run("success, should archive every file of directory", func(t *testing.T) {
+		archive := dirName + ".tar.gz"
+		err := newFs.Archive(dirName, archive)
+		assert.NoError(t, err)
+		defer os.Remove(archive)
+
+		f, err := os.Open(archive)
+		assert.NoError(t, err)
+		defer f.Close()
+		gr, err := gzip.NewReader(f)
+		assert.NoError(t, err)
+		tr := tar.NewReader(gr)
+
+		hdr, err := tr.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, dirName+"/a.go", hdr.Name)
+		content, err := ioutil.ReadAll(tr)
+		assert.NoError(t, err)
+		assert.Equal(t, "package a\n", string(content))
+		_, err = tr.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		err := newFs.Archive(dirName+"/unknown", dirName+".tar.gz")
+		assert.Error(t, err)
+	})
+}
+
+func TestMergeFs(t *testing.T) {
+	dirName := "testfsmerge"
+	newFs := fs.NewFsService()
+
+	err := newFs.CreateDir(dirName)
+	assert.NoError(t, err)
+	defer newFs.RemoveDir(dirName)
+	err = newFs.WriteFile(dirName+"/a.go", []byte("package a\n"))
+	assert.NoError(t, err)
+	err = newFs.WriteFile(dirName+"/b.go", []byte("package b\n"))
+	assert.NoError(t, err)
+
+	t.Run("success, should only write the missing and resolved files", func(t *testing.T) {
+		var conflicts []string
+		mergeFs := fs.NewMergeFsService(fs.NewFsService(), func(fileName string) (bool, error) {
+			conflicts = append(conflicts, fileName)
+			return fileName == dirName+"/b.go", nil
+		})
+
+		err := mergeFs.CreateDir(dirName)
+		assert.NoError(t, err)
+		for _, name := range []string{"a.go", "b.go", "c.go"} {
+			err = mergeFs.WriteFile(dirName+"/"+name, []byte("package merged\n"))
+			assert.NoError(t, err)
+		}
+		err = mergeFs.WriteFile(dirName+"/c.go", []byte("package merged\n"))
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{dirName + "/a.go", dirName + "/b.go"}, conflicts)
+		for name, expected := range map[string]string{"a.go": "package a\n", "b.go": "package merged\n", "c.go": "package merged\n"} {
+			res, err := newFs.ReadFile(dirName + "/" + name)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, string(res))
+		}
+	})
+	t.Run("success, should always write the manifest without resolving", func(t *testing.T) {
+		err := newFs.WriteFile(dirName+"/"+domain.ManifestFile, []byte("domains: []\n"))
+		assert.NoError(t, err)
+
+		mergeFs := fs.NewMergeFsService(fs.NewFsService(), func(fileName string) (bool, error) {
+			return false, errors.New("should not be resolved")
+		})
+		err = mergeFs.WriteFile(dirName+"/"+domain.ManifestFile, []byte("domains:\n- task\n"))
+		assert.NoError(t, err)
+
+		res, err := newFs.ReadFile(dirName + "/" + domain.ManifestFile)
+		assert.NoError(t, err)
+		assert.Equal(t, "domains:\n- task\n", string(res))
+	})
+}
@@ -0,0 +1,59 @@
+package fs
+
+import (
+	"bytes"
+	"path/filepath"
+
+	"github.com/wicaker/cacli/domain"
+)
+
+// mergeFs only write the missing files into the underlying filesystem,
+// a file which already exist with different content is only overwritten if resolve return true
+type mergeFs struct {
+	domain.FsService
+	resolve func(fileName string) (bool, error)
+}
+
+// NewMergeFsService will create new a mergeFs object representation of domain.FsService interface,
+// every write is delegated to fs unless the file already exist
+func NewMergeFsService(fs domain.FsService, resolve func(fileName string) (bool, error)) domain.FsService {
+	return &mergeFs{
+		FsService: fs,
+		resolve:   resolve,
+	}
+}
+
+// CreateDir is a method for create a directory based on directory name, existing directory is kept
+func (m *mergeFs) CreateDir(dirName string) error {
+	res, err := m.FsService.FindDir(dirName)
+	if err != nil {
+		return err
+	}
+	if res != nil {
+		return nil
+	}
+	return m.FsService.CreateDir(dirName)
+}
+
+// WriteFile is a method for write content into a file, existing file is only written after resolved,
+// except the manifest which is always written, since it carry on the records of the existing one
+func (m *mergeFs) WriteFile(fileName string, data []byte) error {
+	res, err := m.FsService.FindFile(fileName)
+	if err != nil {
+		return err
+	}
+	if res != nil && filepath.Base(fileName) != domain.ManifestFile {
+		if bytes.Equal(res.([]byte), data) {
+			return nil
+		}
+
+		overwrite, err := m.resolve(fileName)
+		if err != nil {
+			return err
+		}
+		if !overwrite {
+			return nil
+		}
+	}
+	return m.FsService.WriteFile(fileName, data)
+}
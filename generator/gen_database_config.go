@@ -2,6 +2,7 @@ package generator
 
 import (
 	"github.com/dave/jennifer/jen"
+	"github.com/wicaker/cacli/domain"
 )
 
 func (gen *caGen) GenGopgConfig(dirName string) error {
@@ -20,7 +21,7 @@ func (gen *caGen) GenGopgConfig(dirName string) error {
 		jen.Return(jen.Id("db")),
 	)
 
-	err := f.Save(dirName + "/gopg_config.go")
+	err := gen.save(f, dirName+"/gopg_config.go")
 	if err != nil {
 		return err
 	}
@@ -48,30 +49,19 @@ func (gen *caGen) GenGormConfig(dirName string) error {
 		jen.Return(jen.Id("db")),
 	)
 
-	err := f.Save(dirName + "/gorm_config.go")
+	err := gen.save(f, dirName+"/gorm_config.go")
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (gen *caGen) GenSQLConfig(dirName string) error {
+func (gen *caGen) GenSQLConfig(dirName string, dialect string) error {
 	f := jen.NewFile("config")
 
-	f.Comment("SQLInit will connecting service to databsase using database/sql standard library")
-	f.Func().Id("SQLInit").Params().Op("*").Qual("database/sql", "DB").Block(
-		jen.Id("dbHost").Op(":=").Qual("os", "Getenv").Call(jen.Lit("DATABASE_HOST")),
-		jen.Id("dbPort").Op(":=").Qual("os", "Getenv").Call(jen.Lit("DATABASE_PORT")),
-		jen.Id("dbUser").Op(":=").Qual("os", "Getenv").Call(jen.Lit("DATABASE_USER")),
-		jen.Id("dbPass").Op(":=").Qual("os", "Getenv").Call(jen.Lit("DATABASE_PASSWORD")),
-		jen.Id("dbName").Op(":=").Qual("os", "Getenv").Call(jen.Lit("DATABASE_NAME")),
-		jen.Line(),
-		jen.Id("connection").Op(":=").Qual("fmt", "Sprintf").Call(jen.Lit("%s:%s@tcp(%s:%s)/%s"), jen.Id("dbUser"), jen.Id("dbPass"), jen.Id("dbHost"), jen.Id("dbPort"), jen.Id("dbName")),
-		jen.Id("val").Op(":=").Qual("net/url", "Values").Op("{}"),
-		jen.Id("val").Dot("Add").Call(jen.Lit("parseTime"), jen.Lit("1")),
-		jen.Id("val").Dot("Add").Call(jen.Lit("loc"), jen.Lit("Asia/Jakarta")),
-		jen.Id("dsn").Op(":=").Qual("fmt", "Sprintf").Call(jen.Lit("%s?%s"), jen.Id("connection"), jen.Id("val").Dot("Encode").Op("()")),
-		jen.List(jen.Id("dbConn"), jen.Err()).Op(":=").Qual("database/sql", "Open").Call(jen.Lit("mysql"), jen.Id("dsn")),
+	body := genSQLDsn(f, dialect)
+	body = append(body,
+		jen.List(jen.Id("dbConn"), jen.Err()).Op(":=").Qual("database/sql", "Open").Call(jen.Lit(sqlDriver(dialect)), jen.Id("dsn")),
 		jen.If(jen.Err().Op("!=").Nil()).Block(
 			jen.Panic(jen.Err()),
 		),
@@ -79,43 +69,74 @@ func (gen *caGen) GenSQLConfig(dirName string) error {
 		jen.Return(jen.Id("dbConn")),
 	)
 
-	err := f.Save(dirName + "/sql_config.go")
+	f.Comment("SQLInit will connecting service to databsase using database/sql standard library")
+	f.Func().Id("SQLInit").Params().Op("*").Qual("database/sql", "DB").Block(body...)
+
+	err := gen.save(f, dirName+"/sql_config.go")
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (gen *caGen) GenSqlxConfig(dirName string) error {
+func (gen *caGen) GenSqlxConfig(dirName string, dialect string) error {
 	f := jen.NewFile("config")
 	f.ImportName("github.com/jmoiron/sqlx", "sqlx")
 
+	body := genSQLDsn(f, dialect)
+	body = append(body,
+		jen.List(jen.Id("dbConn"), jen.Err()).Op(":=").Qual("github.com/jmoiron/sqlx", "Connect").Call(jen.Lit(sqlDriver(dialect)), jen.Id("dsn")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Panic(jen.Err()),
+		),
+		jen.Line(),
+		jen.Return(jen.Id("dbConn")),
+	)
+
 	f.Comment("SqlxInit will connecting service to databsase using sqlx library")
-	f.Func().Id("SqlxInit").Params().Op("*").Qual("github.com/jmoiron/sqlx", "DB").Block(
+	f.Func().Id("SqlxInit").Params().Op("*").Qual("github.com/jmoiron/sqlx", "DB").Block(body...)
+
+	err := gen.save(f, dirName+"/sqlx_config.go")
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// genSQLDsn will generate data source name of the chosen dialect from environment variables
+func genSQLDsn(f *jen.File, dialect string) []jen.Code {
+	body := []jen.Code{
 		jen.Id("dbHost").Op(":=").Qual("os", "Getenv").Call(jen.Lit("DATABASE_HOST")),
 		jen.Id("dbPort").Op(":=").Qual("os", "Getenv").Call(jen.Lit("DATABASE_PORT")),
 		jen.Id("dbUser").Op(":=").Qual("os", "Getenv").Call(jen.Lit("DATABASE_USER")),
 		jen.Id("dbPass").Op(":=").Qual("os", "Getenv").Call(jen.Lit("DATABASE_PASSWORD")),
 		jen.Id("dbName").Op(":=").Qual("os", "Getenv").Call(jen.Lit("DATABASE_NAME")),
 		jen.Line(),
+	}
+	if dialect == domain.Postgres {
+		// postgres driver is registered by config, mysql driver is registered by main
+		f.Anon("github.com/lib/pq")
+		return append(body,
+			jen.Id("dsn").Op(":=").Qual("fmt", "Sprintf").Call(jen.Lit("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable"), jen.Id("dbHost"), jen.Id("dbPort"), jen.Id("dbUser"), jen.Id("dbPass"), jen.Id("dbName")),
+		)
+	}
+	return append(body,
 		jen.Id("connection").Op(":=").Qual("fmt", "Sprintf").Call(jen.Lit("%s:%s@tcp(%s:%s)/%s"), jen.Id("dbUser"), jen.Id("dbPass"), jen.Id("dbHost"), jen.Id("dbPort"), jen.Id("dbName")),
 		jen.Id("val").Op(":=").Qual("net/url", "Values").Op("{}"),
 		jen.Id("val").Dot("Add").Call(jen.Lit("parseTime"), jen.Lit("1")),
 		jen.Id("val").Dot("Add").Call(jen.Lit("loc"), jen.Lit("Asia/Jakarta")),
+		// affected rows of update is counted even if nothing was changed, so not found row is detectable
+		jen.Id("val").Dot("Add").Call(jen.Lit("clientFoundRows"), jen.Lit("true")),
 		jen.Id("dsn").Op(":=").Qual("fmt", "Sprintf").Call(jen.Lit("%s?%s"), jen.Id("connection"), jen.Id("val").Dot("Encode").Op("()")),
-		jen.List(jen.Id("dbConn"), jen.Err()).Op(":=").Qual("github.com/jmoiron/sqlx", "Connect").Call(jen.Lit("mysql"), jen.Id("dsn")),
-		jen.If(jen.Err().Op("!=").Nil()).Block(
-			jen.Panic(jen.Err()),
-		),
-		jen.Line(),
-		jen.Return(jen.Id("dbConn")),
 	)
+}
 
-	err := f.Save(dirName + "/sqlx_config.go")
-	if err != nil {
-		return err
+// sqlDriver will return name of registered driver of the chosen dialect
+func sqlDriver(dialect string) string {
+	if dialect == domain.Postgres {
+		return "postgres"
 	}
-	return nil
+	return "mysql"
 }
 
 func (gen *caGen) GenMongodConfig(dirName string) error {
@@ -156,7 +177,7 @@ func (gen *caGen) GenMongodConfig(dirName string) error {
 		jen.Return(jen.Id("db")),
 	)
 
-	err := f.Save(dirName + "/mongod_config.go")
+	err := gen.save(f, dirName+"/mongod_config.go")
 	if err != nil {
 		return err
 	}
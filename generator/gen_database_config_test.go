@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/wicaker/cacli/domain"
 	"github.com/wicaker/cacli/fs"
 	"github.com/wicaker/cacli/generator"
 
@@ -80,6 +81,7 @@ func SQLInit() *sql.DB {
 	val := url.Values{}
 	val.Add("parseTime", "1")
 	val.Add("loc", "Asia/Jakarta")
+	val.Add("clientFoundRows", "true")
 	dsn := fmt.Sprintf("%s?%s", connection, val.Encode())
 	dbConn, err := sql.Open("mysql", dsn)
 	if err != nil {
@@ -90,6 +92,32 @@ func SQLInit() *sql.DB {
 }
 `
 
+	expected_sql_postgres_config = `package config
+
+import (
+	"database/sql"
+	"fmt"
+	_ "github.com/lib/pq"
+	"os"
+)
+
+// SQLInit will connecting service to databsase using database/sql standard library
+func SQLInit() *sql.DB {
+	dbHost := os.Getenv("DATABASE_HOST")
+	dbPort := os.Getenv("DATABASE_PORT")
+	dbUser := os.Getenv("DATABASE_USER")
+	dbPass := os.Getenv("DATABASE_PASSWORD")
+	dbName := os.Getenv("DATABASE_NAME")
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", dbHost, dbPort, dbUser, dbPass, dbName)
+	dbConn, err := sql.Open("postgres", dsn)
+	if err != nil {
+		panic(err)
+	}
+
+	return dbConn
+}
+`
 	expected_sqlx_config = `package config
 
 import (
@@ -111,6 +139,7 @@ func SqlxInit() *sqlx.DB {
 	val := url.Values{}
 	val.Add("parseTime", "1")
 	val.Add("loc", "Asia/Jakarta")
+	val.Add("clientFoundRows", "true")
 	dsn := fmt.Sprintf("%s?%s", connection, val.Encode())
 	dbConn, err := sqlx.Connect("mysql", dsn)
 	if err != nil {
@@ -119,6 +148,32 @@ func SqlxInit() *sqlx.DB {
 
 	return dbConn
 }
+`
+	expected_sqlx_postgres_config = `package config
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"os"
+)
+
+// SqlxInit will connecting service to databsase using sqlx library
+func SqlxInit() *sqlx.DB {
+	dbHost := os.Getenv("DATABASE_HOST")
+	dbPort := os.Getenv("DATABASE_PORT")
+	dbUser := os.Getenv("DATABASE_USER")
+	dbPass := os.Getenv("DATABASE_PASSWORD")
+	dbName := os.Getenv("DATABASE_NAME")
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", dbHost, dbPort, dbUser, dbPass, dbName)
+	dbConn, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		panic(err)
+	}
+
+	return dbConn
+}
 `
 	expected_mongod_config = `package config
 
@@ -191,7 +246,7 @@ func TestGenerateGopgConfig(t *testing.T) {
 		}
 
 		// generate gopg_config.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGopgConfig(dirName)
 		resGopg, err := newFs.FindFile(dirName + "/gopg_config.go")
 		assert.NoError(t, err)
@@ -220,7 +275,7 @@ func TestGenerateGopgConfig(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate gopg_config file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGopgConfig(serviceName)
 
 		assert.Error(t, err)
@@ -257,7 +312,7 @@ func TestGenerateGormConfig(t *testing.T) {
 		}
 
 		// generate gorm_config.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGormConfig(dirName)
 		resGorm, err := newFs.FindFile(dirName + "/gorm_config.go")
 		assert.NoError(t, err)
@@ -286,7 +341,7 @@ func TestGenerateGormConfig(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate gorm_config file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGopgConfig(serviceName)
 
 		assert.Error(t, err)
@@ -323,8 +378,8 @@ func TestGenerateSQLConfig(t *testing.T) {
 		}
 
 		// generate sql_config.go file
-		gen := generator.NewGeneratorService()
-		err = gen.GenSQLConfig(dirName)
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenSQLConfig(dirName, domain.MySQL)
 		resSql, err := newFs.FindFile(dirName + "/sql_config.go")
 		assert.NoError(t, err)
 		assert.NotEqual(t, nil, resSql)
@@ -350,9 +405,46 @@ func TestGenerateSQLConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("success, should generate an sql_config.go file with postgres dialect", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirDb)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirDb + "/" + dirConf)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate sql_config.go file
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenSQLConfig(dirName, domain.Postgres)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/sql_config.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected_sql_postgres_config, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate sql_config file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGopgConfig(serviceName)
 
 		assert.Error(t, err)
@@ -389,8 +481,8 @@ func TestGenerateSqlxConfig(t *testing.T) {
 		}
 
 		// generate sqlx_config.go file
-		gen := generator.NewGeneratorService()
-		err = gen.GenSqlxConfig(dirName)
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenSqlxConfig(dirName, domain.MySQL)
 		resSqlx, err := newFs.FindFile(dirName + "/sqlx_config.go")
 		assert.NoError(t, err)
 		assert.NotEqual(t, nil, resSqlx)
@@ -416,9 +508,46 @@ func TestGenerateSqlxConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("success, should generate an sqlx_config.go file with postgres dialect", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirDb)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirDb + "/" + dirConf)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate sqlx_config.go file
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenSqlxConfig(dirName, domain.Postgres)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/sqlx_config.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected_sqlx_postgres_config, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate sqlx_config file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGopgConfig(serviceName)
 
 		assert.Error(t, err)
@@ -455,7 +584,7 @@ func TestGenerateMongdConfig(t *testing.T) {
 		}
 
 		// generate mongod_config.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenMongodConfig(dirName)
 		resGopg, err := newFs.FindFile(dirName + "/mongod_config.go")
 		assert.NoError(t, err)
@@ -484,7 +613,7 @@ func TestGenerateMongdConfig(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate mongod_config file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenMongodConfig(serviceName)
 
 		assert.Error(t, err)
@@ -1,7 +1,5 @@
 package generator
 
-import "io/ioutil"
-
 func (gen *caGen) GenDockerfile(dirName string) error {
 	docker := []byte(`FROM golang:alpine AS builder
 
@@ -65,10 +63,14 @@ ENV DATABASE_USER=
 ENV DATABASE_PASSWORD=
 ENV DATABASE_NAME=
 
+ENV DATABASE_MONGO_URL=
+ENV DATABASE_MONGO_NAME=
+ENV DATABASE_MONGO_CREATE_INDEX=true
+
 ENTRYPOINT ["/go/bin/yourappname"]
 `)
 
-	err := ioutil.WriteFile("./"+dirName+"/Dockerfile", docker, 0644)
+	err := gen.fs.WriteFile(dirName+"/Dockerfile", docker)
 	if err != nil {
 		return err
 	}
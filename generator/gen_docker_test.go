@@ -24,7 +24,7 @@ func TestGenDockerFile(t *testing.T) {
 		}
 
 		// generate Dockerfile file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenDockerfile(serviceName)
 		resDocker, err := newFs.FindFile(serviceName + "/Dockerfile")
 
@@ -41,7 +41,7 @@ func TestGenDockerFile(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate Dockerfile file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenDockerfile(serviceName)
 
 		assert.Error(t, err)
@@ -1,6 +1,16 @@
 package generator
 
 import (
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/types"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/wicaker/cacli/domain"
+
 	"github.com/dave/jennifer/jen"
 )
 
@@ -8,23 +18,108 @@ func (gen *caGen) GenDomainErrors(dirName string) error {
 	f := jen.NewFile("domain")
 	f.Comment("ResponseError represent the response error struct")
 	f.Type().Id("ResponseError").Struct(
+		jen.Id("Code").Id("ErrorCode").Tag(map[string]string{"json": "code,omitempty"}),
 		jen.Id("Message").String().Tag(map[string]string{"json": "message"}),
+		jen.Id("Details").Map(jen.String()).String().Tag(map[string]string{"json": "details,omitempty"}),
+	)
+	f.Line()
+	f.Comment("NewResponseError will return the response of err, error which is not Error is responded as ErrInternalServerError")
+	f.Func().Id("NewResponseError").Params(jen.Err().Error()).Op("*").Id("ResponseError").Block(
+		jen.Id("e").Op(":=").Id("AsError").Call(jen.Err()),
+		jen.Return(jen.Op("&").Id("ResponseError").Values(jen.Dict{
+			jen.Id("Code"):    jen.Id("e").Dot("Code"),
+			jen.Id("Message"): jen.Id("e").Dot("Message"),
+			jen.Id("Details"): jen.Id("e").Dot("Details"),
+		})),
+	)
+
+	f.Line()
+	f.Comment("ErrorCode represent the kind of error, every transport maps it into its own status")
+	f.Type().Id("ErrorCode").String()
+	f.Line()
+	f.Const().Defs(
+		jen.Comment("CodeInternal is the code of ErrInternalServerError"),
+		jen.Id("CodeInternal").Id("ErrorCode").Op("=").Lit("internal"),
+		jen.Comment("CodeNotFound is the code of ErrNotFound"),
+		jen.Id("CodeNotFound").Id("ErrorCode").Op("=").Lit("not_found"),
+		jen.Comment("CodeConflict is the code of ErrConflict"),
+		jen.Id("CodeConflict").Id("ErrorCode").Op("=").Lit("conflict"),
+		jen.Comment("CodeBadParamInput is the code of ErrBadParamInput"),
+		jen.Id("CodeBadParamInput").Id("ErrorCode").Op("=").Lit("bad_param_input"),
+		jen.Comment("CodeUnauthorized is the code of ErrUnauthorized"),
+		jen.Id("CodeUnauthorized").Id("ErrorCode").Op("=").Lit("unauthorized"),
+	)
+
+	f.Line()
+	f.Comment("Error represent the error of domain, message is shown to the client while cause is only logged")
+	f.Type().Id("Error").Struct(
+		jen.Id("Code").Id("ErrorCode"),
+		jen.Id("Message").String(),
+		jen.Id("Cause").Error(),
+		jen.Id("Details").Map(jen.String()).String(),
+	)
+	f.Line()
+	f.Comment("NewError will initialize the error of domain with code and message")
+	f.Func().Id("NewError").Params(jen.Id("code").Id("ErrorCode"), jen.Id("message").String()).Op("*").Id("Error").Block(
+		jen.Return(jen.Op("&").Id("Error").Values(jen.Dict{
+			jen.Id("Code"):    jen.Id("code"),
+			jen.Id("Message"): jen.Id("message"),
+		})),
+	)
+	f.Line()
+	f.Comment("Error will return the message of error followed by its cause")
+	f.Func().Params(jen.Id("e").Op("*").Id("Error")).Id("Error").Params().String().Block(
+		jen.If(jen.Id("e").Dot("Cause").Op("==").Nil()).Block(jen.Return(jen.Id("e").Dot("Message"))),
+		jen.Return(jen.Id("e").Dot("Message").Op("+").Lit(": ").Op("+").Id("e").Dot("Cause").Dot("Error").Call()),
+	)
+	f.Line()
+	f.Comment("Unwrap will return the cause of error, it is used by errors.Is and errors.As")
+	f.Func().Params(jen.Id("e").Op("*").Id("Error")).Id("Unwrap").Params().Error().Block(
+		jen.Return(jen.Id("e").Dot("Cause")),
+	)
+	f.Line()
+	f.Comment("Is will report whether target is the error of domain with the same code, e.g. errors.Is(err, ErrNotFound)")
+	f.Func().Params(jen.Id("e").Op("*").Id("Error")).Id("Is").Params(jen.Id("target").Error()).Bool().Block(
+		jen.List(jen.Id("t"), jen.Id("ok")).Op(":=").Id("target").Assert(jen.Op("*").Id("Error")),
+		jen.Return(jen.Id("ok").Op("&&").Id("t").Op("!=").Nil().Op("&&").Id("t").Dot("Code").Op("==").Id("e").Dot("Code")),
+	)
+	f.Line()
+	f.Comment("Wrap will return the copy of error which wraps cause")
+	f.Func().Params(jen.Id("e").Op("*").Id("Error")).Id("Wrap").Params(jen.Id("cause").Error()).Op("*").Id("Error").Block(
+		jen.Id("c").Op(":=").Op("*").Id("e"),
+		jen.Id("c").Dot("Cause").Op("=").Id("cause"),
+		jen.Return(jen.Op("&").Id("c")),
+	)
+	f.Line()
+	f.Comment("WithDetails will return the copy of error which carries details, e.g. the reason of every invalid field")
+	f.Func().Params(jen.Id("e").Op("*").Id("Error")).Id("WithDetails").Params(jen.Id("details").Map(jen.String()).String()).Op("*").Id("Error").Block(
+		jen.Id("c").Op(":=").Op("*").Id("e"),
+		jen.Id("c").Dot("Details").Op("=").Id("details"),
+		jen.Return(jen.Op("&").Id("c")),
+	)
+	f.Line()
+	f.Comment("AsError will return the error of domain in err, error which is not Error is wrapped by ErrInternalServerError")
+	f.Func().Id("AsError").Params(jen.Err().Error()).Op("*").Id("Error").Block(
+		jen.Var().Id("e").Op("*").Id("Error"),
+		jen.If(jen.Qual("errors", "As").Call(jen.Err(), jen.Op("&").Id("e"))).Block(jen.Return(jen.Id("e"))),
+		jen.Return(jen.Id("ErrInternalServerError").Dot("Wrap").Call(jen.Err())),
 	)
 
+	f.Line()
 	f.Var().Defs(
 		jen.Comment("ErrInternalServerError will throw if any the Internal Server Error happen"),
-		jen.Id("ErrInternalServerError").Op("=").Qual("errors", "New").Call(jen.Lit("Internal Server Error")),
+		jen.Id("ErrInternalServerError").Op("=").Id("NewError").Call(jen.Id("CodeInternal"), jen.Lit("Internal Server Error")),
 		jen.Comment("ErrNotFound will throw if the requested item is not exists"),
-		jen.Id("ErrNotFound").Op("=").Qual("errors", "New").Call(jen.Lit("Your requested Item is not found")),
+		jen.Id("ErrNotFound").Op("=").Id("NewError").Call(jen.Id("CodeNotFound"), jen.Lit("Your requested Item is not found")),
 		jen.Comment("ErrConflict will throw if the current action already exists"),
-		jen.Id("ErrConflict").Op("=").Qual("errors", "New").Call(jen.Lit("Your Item already exist")),
+		jen.Id("ErrConflict").Op("=").Id("NewError").Call(jen.Id("CodeConflict"), jen.Lit("Your Item already exist")),
 		jen.Comment("ErrBadParamInput will throw if the given request-body or params is not valid"),
-		jen.Id("ErrBadParamInput").Op("=").Qual("errors", "New").Call(jen.Lit("Given Param is not valid")),
+		jen.Id("ErrBadParamInput").Op("=").Id("NewError").Call(jen.Id("CodeBadParamInput"), jen.Lit("Given Param is not valid")),
 		jen.Comment("ErrUnauthorized will throw if the given request-header token is not valid"),
-		jen.Id("ErrUnauthorized").Op("=").Qual("errors", "New").Call(jen.Lit("Unauthorized")),
+		jen.Id("ErrUnauthorized").Op("=").Id("NewError").Call(jen.Id("CodeUnauthorized"), jen.Lit("Unauthorized")),
 	)
 
-	err := f.Save(dirName + "/errors.go")
+	err := gen.save(f, dirName+"/errors.go")
 	if err != nil {
 		return err
 	}
@@ -36,22 +131,119 @@ func (gen *caGen) GenDomainStatusCode(dirName string) error {
 	f := jen.NewFile("domain")
 	f.ImportAlias("github.com/sirupsen/logrus", "log")
 
-	f.Comment("GetStatusCode will return status code based on type of error")
+	f.Comment("GetStatusCode will return http status code based on code of error")
 	f.Func().Id("GetStatusCode").Params(jen.Id("err").Error()).Int().Block(
 		jen.If(jen.Id("err").Op("==").Nil().Block(
 			jen.Return(jen.Qual("net/http", "StatusOK")),
 		)),
 		jen.Qual("github.com/sirupsen/logrus", "Error").Call(jen.Id("err")),
-		jen.Switch(jen.Id("err")).Block(
-			jen.Case(jen.Id("ErrInternalServerError")).Block(jen.Return(jen.Qual("net/http", "StatusInternalServerError"))),
-			jen.Case(jen.Id("ErrNotFound")).Block(jen.Return(jen.Qual("net/http", "StatusNotFound"))),
-			jen.Case(jen.Id("ErrConflict")).Block(jen.Return(jen.Qual("net/http", "StatusConflict"))),
-			jen.Case(jen.Id("ErrUnauthorized")).Block(jen.Return(jen.Qual("net/http", "StatusUnauthorized"))),
+		jen.Switch(jen.Id("AsError").Call(jen.Id("err")).Dot("Code")).Block(
+			jen.Case(jen.Id("CodeNotFound")).Block(jen.Return(jen.Qual("net/http", "StatusNotFound"))),
+			jen.Case(jen.Id("CodeConflict")).Block(jen.Return(jen.Qual("net/http", "StatusConflict"))),
+			jen.Case(jen.Id("CodeBadParamInput")).Block(jen.Return(jen.Qual("net/http", "StatusBadRequest"))),
+			jen.Case(jen.Id("CodeUnauthorized")).Block(jen.Return(jen.Qual("net/http", "StatusUnauthorized"))),
 			jen.Default().Block(jen.Return(jen.Qual("net/http", "StatusInternalServerError"))),
 		),
 	)
 
-	err := f.Save(dirName + "/status_code.go")
+	f.Line()
+	f.Comment("graphqlError represent the error which is resolved by graphql, code and details are written as its extensions")
+	f.Type().Id("graphqlError").Struct(jen.Id("err").Op("*").Id("Error"))
+	f.Line()
+	f.Comment("Error will return the message of error, cause is not shown to the client")
+	f.Func().Params(jen.Id("e").Id("graphqlError")).Id("Error").Params().String().Block(
+		jen.Return(jen.Id("e").Dot("err").Dot("Message")),
+	)
+	f.Line()
+	f.Comment("Extensions will return the code and details of error, graphql writes them as extensions of error")
+	f.Func().Params(jen.Id("e").Id("graphqlError")).Id("Extensions").Params().Map(jen.String()).Interface().Block(
+		jen.Id("extensions").Op(":=").Map(jen.String()).Interface().Values(jen.Dict{jen.Lit("code"): jen.Id("e").Dot("err").Dot("Code")}),
+		jen.If(jen.Len(jen.Id("e").Dot("err").Dot("Details")).Op(">").Lit(0)).Block(
+			jen.Id("extensions").Index(jen.Lit("details")).Op("=").Id("e").Dot("err").Dot("Details"),
+		),
+		jen.Return(jen.Id("extensions")),
+	)
+	f.Line()
+	f.Comment("GetGraphqlError will return error of graphql based on code of error, the code and details are written as its extensions")
+	f.Func().Id("GetGraphqlError").Params(jen.Id("err").Error()).Error().Block(
+		jen.If(jen.Id("err").Op("==").Nil().Block(
+			jen.Return(jen.Nil()),
+		)),
+		jen.Qual("github.com/sirupsen/logrus", "Error").Call(jen.Id("err")),
+		jen.Return(jen.Id("graphqlError").Values(jen.Dict{jen.Id("err"): jen.Id("AsError").Call(jen.Id("err"))})),
+	)
+
+	err := gen.save(f, dirName+"/status_code.go")
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GenDomainProblem will generate the problem details of RFC 7807 which answer error of rest as application/problem+json,
+// details of error are written as invalid-params extension
+func (gen *caGen) GenDomainProblem(dirName string) error {
+	f := jen.NewFile("domain")
+	f.ImportAlias("github.com/sirupsen/logrus", "log")
+
+	f.Comment("ProblemContentType is the content type of problem details")
+	f.Const().Id("ProblemContentType").Op("=").Lit("application/problem+json")
+	f.Line()
+	f.Comment("ProblemDetails represent the response error struct of RFC 7807, code and invalid params are its extensions")
+	f.Type().Id("ProblemDetails").Struct(
+		jen.Id("Type").String().Tag(map[string]string{"json": "type"}),
+		jen.Id("Title").String().Tag(map[string]string{"json": "title"}),
+		jen.Id("Status").Int().Tag(map[string]string{"json": "status"}),
+		jen.Id("Detail").String().Tag(map[string]string{"json": "detail,omitempty"}),
+		jen.Id("Instance").String().Tag(map[string]string{"json": "instance,omitempty"}),
+		jen.Id("Code").Id("ErrorCode").Tag(map[string]string{"json": "code,omitempty"}),
+		jen.Id("InvalidParams").Index().Id("InvalidParam").Tag(map[string]string{"json": "invalid-params,omitempty"}),
+	)
+	f.Line()
+	f.Comment("InvalidParam represent the reason of a param which is not valid")
+	f.Type().Id("InvalidParam").Struct(
+		jen.Id("Name").String().Tag(map[string]string{"json": "name"}),
+		jen.Id("Reason").String().Tag(map[string]string{"json": "reason"}),
+	)
+	f.Line()
+	f.Comment("NewProblemDetails will return the problem details of err which occurred on instance, status is based on code of error")
+	f.Func().Id("NewProblemDetails").Params(jen.Err().Error(), jen.Id("instance").String()).Op("*").Id("ProblemDetails").Block(
+		jen.Id("e").Op(":=").Id("AsError").Call(jen.Err()),
+		jen.Id("status").Op(":=").Id("GetStatusCode").Call(jen.Err()),
+		jen.Id("problem").Op(":=").Op("&").Id("ProblemDetails").Values(jen.Dict{
+			jen.Id("Type"):     jen.Lit("about:blank"),
+			jen.Id("Title"):    jen.Qual("net/http", "StatusText").Call(jen.Id("status")),
+			jen.Id("Status"):   jen.Id("status"),
+			jen.Id("Detail"):   jen.Id("e").Dot("Message"),
+			jen.Id("Instance"): jen.Id("instance"),
+			jen.Id("Code"):     jen.Id("e").Dot("Code"),
+		}),
+		jen.Line(),
+		jen.Id("names").Op(":=").Make(jen.Index().String(), jen.Lit(0), jen.Len(jen.Id("e").Dot("Details"))),
+		jen.For(jen.Id("name").Op(":=").Range().Id("e").Dot("Details")).Block(
+			jen.Id("names").Op("=").Append(jen.Id("names"), jen.Id("name")),
+		),
+		jen.Qual("sort", "Strings").Call(jen.Id("names")),
+		jen.For(jen.List(jen.Id("_"), jen.Id("name")).Op(":=").Range().Id("names")).Block(
+			jen.Id("problem").Dot("InvalidParams").Op("=").Append(jen.Id("problem").Dot("InvalidParams"), jen.Id("InvalidParam").Values(jen.Dict{
+				jen.Id("Name"):   jen.Id("name"),
+				jen.Id("Reason"): jen.Id("e").Dot("Details").Index(jen.Id("name")),
+			})),
+		),
+		jen.Return(jen.Id("problem")),
+	)
+	f.Line()
+	f.Comment("WriteProblem will write err as problem details of the request, instance is the path of request")
+	f.Func().Id("WriteProblem").Params(jen.Id("w").Qual("net/http", "ResponseWriter"), jen.Id("r").Op("*").Qual("net/http", "Request"), jen.Err().Error()).Block(
+		jen.Id("problem").Op(":=").Id("NewProblemDetails").Call(jen.Err(), jen.Id("r").Dot("URL").Dot("Path")),
+		jen.Id("w").Dot("Header").Call().Dot("Set").Call(jen.Lit("Content-Type"), jen.Id("ProblemContentType")),
+		jen.Id("w").Dot("WriteHeader").Call(jen.Id("problem").Dot("Status")),
+		jen.If(jen.Err().Op(":=").Qual("encoding/json", "NewEncoder").Call(jen.Id("w")).Dot("Encode").Call(jen.Id("problem")), jen.Err().Op("!=").Nil()).Block(
+			jen.Qual("github.com/sirupsen/logrus", "Error").Call(jen.Err()),
+		),
+	)
+
+	err := gen.save(f, dirName+"/problem.go")
 	if err != nil {
 		return err
 	}
@@ -66,12 +258,7 @@ func (gen *caGen) GenDomainSuccess(dirName string) error {
 		jen.Id("Data").Interface().Tag(map[string]string{"json": "data"}),
 	)
 
-	f.Var().Defs(
-		jen.Comment("ResponseData with type map used to response json if no error"),
-		jen.Id("ResponseData").Map(jen.String()).Interface(),
-	)
-
-	err := f.Save(dirName + "/success.go")
+	err := gen.save(f, dirName+"/success.go")
 	if err != nil {
 		return err
 	}
@@ -80,38 +267,297 @@ func (gen *caGen) GenDomainSuccess(dirName string) error {
 }
 
 func (gen *caGen) GenDomainExample(dirName string) error {
-	f := jen.NewFile("domain")
-	f.Comment("Example struct, models of example table")
-	f.Type().Id("Example").Struct(
-		jen.Id("ID").Uint64().Tag(map[string]string{"json": "id"}),
-		jen.Id("Name").String().Tag(map[string]string{"json": "name"}),
-		jen.Id("CreatedAt").Qual("time", "Time").Tag(map[string]string{"json": "created_at", "db": "created_at"}),
-		jen.Id("UpdatedAt").Qual("time", "Time").Tag(map[string]string{"json": "updated_at", "db": "updated_at"}),
-		jen.Id("DeletedAt").Op("*").Qual("time", "Time").Tag(map[string]string{"json": "deleted_at", "db": "deleted_at", "pg": ",soft_delete"}),
-	)
+	contract := defaultContract("example", "exp")
+	return gen.genDomainEntity(dirName, "example", defaultEntityFields(), contract, contract)
+}
 
-	f.Comment("ExampleUsecase represent the Example's usecases contract")
-	f.Type().Id("ExampleUsecase").Interface(
-		jen.Id("Fetch").Params(jen.Id("ctx").Qual("context", "Context")).Call(jen.Index().Op("*").Id("Example"), jen.Error()),
-		jen.Id("GetByID").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("id").Uint64()).Call(jen.Op("*").Id("Example"), jen.Error()),
-		jen.Id("Store").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("exp").Op("*").Id("Example")).Call(jen.Op("*").Id("Example"), jen.Error()),
-		jen.Id("Update").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("exp").Op("*").Id("Example")).Call(jen.Op("*").Id("Example"), jen.Error()),
-		jen.Id("Delete").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("id").Uint64()).Call(jen.Error()),
+func (gen *caGen) GenDomain(dirName string, domainName string) error {
+	contract := defaultContract(domainName, string(domainName[0]))
+	return gen.genDomainEntity(dirName, domainName, defaultEntityFields(), contract, contract)
+}
+
+// GenDomainSpec will generate domain based on entity which declared in specification file,
+// the default fields and contract are used if the entity doesn't declare them
+func (gen *caGen) GenDomainSpec(dirName string, entity domain.SpecEntity) error {
+	var (
+		fields          = defaultEntityFields()
+		contract        = defaultContract(entity.Name, string(entity.Name[0]))
+		usecaseContract = contract
+		imports         = map[string]string{}
 	)
 
-	f.Comment("ExampleRepository represent the Example's repository contract")
-	f.Type().Id("ExampleRepository").Interface(
-		jen.Id("Fetch").Params(jen.Id("ctx").Qual("context", "Context")).Call(jen.Index().Op("*").Id("Example"), jen.Error()),
-		jen.Id("GetByID").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("id").Uint64()).Call(jen.Op("*").Id("Example"), jen.Error()),
-		jen.Id("Store").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("exp").Op("*").Id("Example")).Call(jen.Op("*").Id("Example"), jen.Error()),
-		jen.Id("Update").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("exp").Op("*").Id("Example")).Call(jen.Op("*").Id("Example"), jen.Error()),
+	for _, i := range entity.Imports {
+		imports[path.Base(i)] = i
+	}
+
+	if len(entity.Fields) > 0 {
+		fields = []jen.Code{}
+		for _, i := range entity.Fields {
+			typ, err := genTypeCode(i.Type, imports)
+			if err != nil {
+				return err
+			}
+			tags := i.Tags
+			if len(tags) == 0 {
+				column := toSnakeCase(i.Name)
+				tags = map[string]string{"json": column, "db": column, "bson": column}
+				// mongo store the primary key of document as _id
+				if column == "id" {
+					tags["bson"] = "_id"
+				}
+			}
+			if i.Comment != "" {
+				fields = append(fields, jen.Comment(i.Comment))
+			}
+			fields = append(fields, jen.Id(i.Name).Add(typ).Tag(tags))
+		}
+	}
+
+	if len(entity.Methods) > 0 {
+		contract = []jen.Code{}
+		usecaseContract = []jen.Code{}
+		for _, i := range entity.Methods {
+			var params, results []jen.Code
+			for _, j := range i.Params {
+				param := strings.Fields(j)
+				if len(param) < 2 {
+					return fmt.Errorf("parameter %q of %s must be written as `name type`", j, i.Name)
+				}
+				typ, err := genTypeCode(strings.Join(param[1:], " "), imports)
+				if err != nil {
+					return err
+				}
+				params = append(params, jen.Id(param[0]).Add(typ))
+			}
+			for _, j := range i.Results {
+				typ, err := genTypeCode(j, imports)
+				if err != nil {
+					return err
+				}
+				results = append(results, typ)
+			}
+			contract = append(contract, jen.Id(i.Name).Params(params...).Call(results...))
+
+			// route only matter to transport, so it is annotated on usecase contract only
+			if i.Route != "" {
+				usecaseContract = append(usecaseContract, jen.Comment("@route "+i.Route))
+			}
+			usecaseContract = append(usecaseContract, jen.Id(i.Name).Params(params...).Call(results...))
+		}
+	}
+
+	return gen.genDomainEntity(dirName, entity.Name, fields, usecaseContract, contract)
+}
+
+// defaultEntityFields will return fields of entity which used if no field is declared
+func defaultEntityFields() []jen.Code {
+	return []jen.Code{
+		jen.Id("ID").Uint64().Tag(map[string]string{"json": "id", "bson": "_id"}),
+		jen.Id("Name").String().Tag(map[string]string{"json": "name", "bson": "name"}),
+		jen.Id("CreatedAt").Qual("time", "Time").Tag(map[string]string{"json": "created_at", "db": "created_at", "bson": "created_at"}),
+		jen.Id("UpdatedAt").Qual("time", "Time").Tag(map[string]string{"json": "updated_at", "db": "updated_at", "bson": "updated_at"}),
+		jen.Id("DeletedAt").Op("*").Qual("time", "Time").Tag(map[string]string{"json": "deleted_at", "db": "deleted_at", "bson": "deleted_at", "pg": ",soft_delete"}),
+	}
+}
+
+// defaultContract will return the CRUD methods which used by usecase & repository contract if no method is declared
+func defaultContract(domainName string, paramName string) []jen.Code {
+	entity := strings.ToUpper(string(domainName[0])) + domainName[1:]
+	return []jen.Code{
+		jen.Id("Fetch").Params(jen.Id("ctx").Qual("context", "Context")).Call(jen.Index().Op("*").Id(entity), jen.Error()),
+		jen.Id("GetByID").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("id").Uint64()).Call(jen.Op("*").Id(entity), jen.Error()),
+		jen.Id("Store").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id(paramName).Op("*").Id(entity)).Call(jen.Op("*").Id(entity), jen.Error()),
+		jen.Id("Update").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id(paramName).Op("*").Id(entity)).Call(jen.Op("*").Id(entity), jen.Error()),
 		jen.Id("Delete").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("id").Uint64()).Call(jen.Error()),
+	}
+}
+
+// genDomainEntity will generate the entity struct of domain and the usecase & repository contract of it
+func (gen *caGen) genDomainEntity(dirName string, domainName string, fields []jen.Code, usecaseContract []jen.Code, repositoryContract []jen.Code) error {
+	var (
+		entity     = strings.ToUpper(string(domainName[0])) + domainName[1:]
+		useCase    = entity + "Usecase"
+		repository = entity + "Repository"
+		f          = jen.NewFile("domain")
 	)
 
-	err := f.Save(dirName + "/example.go")
+	f.Comment(fmt.Sprintf("%s struct, models of %s table", entity, domainName))
+	f.Type().Id(entity).Struct(fields...)
+
+	f.Comment(fmt.Sprintf("%s represent the %s's usecases contract", useCase, entity))
+	f.Type().Id(useCase).Interface(usecaseContract...)
+
+	f.Comment(fmt.Sprintf("%s represent the %s's repository contract", repository, entity))
+	f.Type().Id(repository).Interface(repositoryContract...)
+
+	err := gen.save(f, fmt.Sprintf("%s/%s.go", dirName, domainName))
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// genTypeCode will convert type which written in go syntax into jen code, so the imports are tracked by jen,
+// package which is not listed in imports is assumed as standard library
+func genTypeCode(typ string, imports map[string]string) (*jen.Statement, error) {
+	// variadic parameter is not an expression, e.g. ...string
+	if strings.HasPrefix(typ, "...") {
+		elt, err := genTypeCode(strings.TrimPrefix(typ, "..."), imports)
+		if err != nil {
+			return nil, err
+		}
+		return jen.Op("...").Add(elt), nil
+	}
+	expr, err := goparser.ParseExpr(typ)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid go type", typ)
+	}
+	return genExprCode(expr, imports)
+}
+
+// genExprCode will convert type expression into jen code,
+// expression which is not supported is written as it is printed, the imports of it are not tracked
+func genExprCode(expr ast.Expr, imports map[string]string) (*jen.Statement, error) {
+	switch d := expr.(type) {
+	case *ast.Ident:
+		return jen.Id(d.Name), nil
+	case *ast.SelectorExpr:
+		pkg, ok := d.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %T", d.X)
+		}
+		importPath, ok := imports[pkg.Name]
+		if !ok {
+			importPath = stdImports[pkg.Name]
+		}
+		if importPath == "" {
+			importPath = pkg.Name
+		}
+		return jen.Qual(importPath, d.Sel.Name), nil
+	case *ast.StarExpr:
+		x, err := genExprCode(d.X, imports)
+		if err != nil {
+			return nil, err
+		}
+		return jen.Op("*").Add(x), nil
+	case *ast.ParenExpr:
+		x, err := genExprCode(d.X, imports)
+		if err != nil {
+			return nil, err
+		}
+		return jen.Parens(x), nil
+	case *ast.Ellipsis:
+		elt, err := genExprCode(d.Elt, imports)
+		if err != nil {
+			return nil, err
+		}
+		return jen.Op("...").Add(elt), nil
+	case *ast.ArrayType:
+		elt, err := genExprCode(d.Elt, imports)
+		if err != nil {
+			return nil, err
+		}
+		if d.Len == nil {
+			return jen.Index().Add(elt), nil
+		}
+		lit, ok := d.Len.(*ast.BasicLit)
+		if !ok {
+			return jen.Op(types.ExprString(expr)), nil
+		}
+		return jen.Index(jen.Op(lit.Value)).Add(elt), nil
+	case *ast.MapType:
+		key, err := genExprCode(d.Key, imports)
+		if err != nil {
+			return nil, err
+		}
+		value, err := genExprCode(d.Value, imports)
+		if err != nil {
+			return nil, err
+		}
+		return jen.Map(key).Add(value), nil
+	case *ast.ChanType:
+		value, err := genExprCode(d.Value, imports)
+		if err != nil {
+			return nil, err
+		}
+		switch d.Dir {
+		case ast.SEND:
+			return jen.Chan().Op("<-").Add(value), nil
+		case ast.RECV:
+			return jen.Op("<-").Chan().Add(value), nil
+		}
+		return jen.Chan().Add(value), nil
+	case *ast.FuncType:
+		params, err := genFieldListCode(d.Params, imports)
+		if err != nil {
+			return nil, err
+		}
+		results, err := genFieldListCode(d.Results, imports)
+		if err != nil {
+			return nil, err
+		}
+		code := jen.Func().Params(params...)
+		if len(results) == 1 && len(d.Results.List[0].Names) == 0 {
+			return code.Add(results[0]), nil
+		}
+		if len(results) > 0 {
+			return code.Params(results...), nil
+		}
+		return code, nil
+	case *ast.InterfaceType:
+		if d.Methods == nil || len(d.Methods.List) == 0 {
+			return jen.Interface(), nil
+		}
+		return jen.Op(types.ExprString(expr)), nil
+	default:
+		return jen.Op(types.ExprString(expr)), nil
+	}
+}
+
+// genFieldListCode will convert parameters or results of function type into jen code, one code per field name
+func genFieldListCode(fields *ast.FieldList, imports map[string]string) ([]jen.Code, error) {
+	var codes []jen.Code
+	if fields == nil {
+		return codes, nil
+	}
+	for _, f := range fields.List {
+		typ, err := genExprCode(f.Type, imports)
+		if err != nil {
+			return nil, err
+		}
+		if len(f.Names) == 0 {
+			codes = append(codes, typ)
+			continue
+		}
+		for _, n := range f.Names {
+			codes = append(codes, jen.Id(n.Name).Add(typ.Clone()))
+		}
+	}
+	return codes, nil
+}
+
+// stdImports is import path of standard library which the package name is different with the path
+var stdImports = map[string]string{
+	"json": "encoding/json",
+	"sql":  "database/sql",
+	"url":  "net/url",
+	"http": "net/http",
+}
+
+// toSnakeCase will convert field name into column name, e.g. CreatedAt become created_at
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -8,6 +8,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/wicaker/cacli/domain"
 	"github.com/wicaker/cacli/fs"
 	"github.com/wicaker/cacli/generator"
 )
@@ -19,20 +20,106 @@ import "errors"
 
 // ResponseError represent the response error struct
 type ResponseError struct {
-	Message string ` + "`json:" + `"` + "message" + `"` + "`" + `
+	Code    ErrorCode         ` + "`" + `json:"code,omitempty"` + "`" + `
+	Message string            ` + "`" + `json:"message"` + "`" + `
+	Details map[string]string ` + "`" + `json:"details,omitempty"` + "`" + `
+}
+
+// NewResponseError will return the response of err, error which is not Error is responded as ErrInternalServerError
+func NewResponseError(err error) *ResponseError {
+	e := AsError(err)
+	return &ResponseError{
+		Code:    e.Code,
+		Details: e.Details,
+		Message: e.Message,
+	}
+}
+
+// ErrorCode represent the kind of error, every transport maps it into its own status
+type ErrorCode string
+
+const (
+	// CodeInternal is the code of ErrInternalServerError
+	CodeInternal ErrorCode = "internal"
+	// CodeNotFound is the code of ErrNotFound
+	CodeNotFound ErrorCode = "not_found"
+	// CodeConflict is the code of ErrConflict
+	CodeConflict ErrorCode = "conflict"
+	// CodeBadParamInput is the code of ErrBadParamInput
+	CodeBadParamInput ErrorCode = "bad_param_input"
+	// CodeUnauthorized is the code of ErrUnauthorized
+	CodeUnauthorized ErrorCode = "unauthorized"
+)
+
+// Error represent the error of domain, message is shown to the client while cause is only logged
+type Error struct {
+	Code    ErrorCode
+	Message string
+	Cause   error
+	Details map[string]string
+}
+
+// NewError will initialize the error of domain with code and message
+func NewError(code ErrorCode, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error will return the message of error followed by its cause
+func (e *Error) Error() string {
+	if e.Cause == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Cause.Error()
+}
+
+// Unwrap will return the cause of error, it is used by errors.Is and errors.As
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is will report whether target is the error of domain with the same code, e.g. errors.Is(err, ErrNotFound)
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t != nil && t.Code == e.Code
+}
+
+// Wrap will return the copy of error which wraps cause
+func (e *Error) Wrap(cause error) *Error {
+	c := *e
+	c.Cause = cause
+	return &c
+}
+
+// WithDetails will return the copy of error which carries details, e.g. the reason of every invalid field
+func (e *Error) WithDetails(details map[string]string) *Error {
+	c := *e
+	c.Details = details
+	return &c
+}
+
+// AsError will return the error of domain in err, error which is not Error is wrapped by ErrInternalServerError
+func AsError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return ErrInternalServerError.Wrap(err)
 }
 
 var (
 	// ErrInternalServerError will throw if any the Internal Server Error happen
-	ErrInternalServerError = errors.New("Internal Server Error")
+	ErrInternalServerError = NewError(CodeInternal, "Internal Server Error")
 	// ErrNotFound will throw if the requested item is not exists
-	ErrNotFound = errors.New("Your requested Item is not found")
+	ErrNotFound = NewError(CodeNotFound, "Your requested Item is not found")
 	// ErrConflict will throw if the current action already exists
-	ErrConflict = errors.New("Your Item already exist")
+	ErrConflict = NewError(CodeConflict, "Your Item already exist")
 	// ErrBadParamInput will throw if the given request-body or params is not valid
-	ErrBadParamInput = errors.New("Given Param is not valid")
+	ErrBadParamInput = NewError(CodeBadParamInput, "Given Param is not valid")
 	// ErrUnauthorized will throw if the given request-header token is not valid
-	ErrUnauthorized = errors.New("Unauthorized")
+	ErrUnauthorized = NewError(CodeUnauthorized, "Unauthorized")
 )
 `
 	expected_domain_status_code = `package domain
@@ -42,38 +129,125 @@ import (
 	"net/http"
 )
 
-// GetStatusCode will return status code based on type of error
+// GetStatusCode will return http status code based on code of error
 func GetStatusCode(err error) int {
 	if err == nil {
 		return http.StatusOK
 	}
 	log.Error(err)
-	switch err {
-	case ErrInternalServerError:
-		return http.StatusInternalServerError
-	case ErrNotFound:
+	switch AsError(err).Code {
+	case CodeNotFound:
 		return http.StatusNotFound
-	case ErrConflict:
+	case CodeConflict:
 		return http.StatusConflict
-	case ErrUnauthorized:
+	case CodeBadParamInput:
+		return http.StatusBadRequest
+	case CodeUnauthorized:
 		return http.StatusUnauthorized
 	default:
 		return http.StatusInternalServerError
 	}
 }
+
+// graphqlError represent the error which is resolved by graphql, code and details are written as its extensions
+type graphqlError struct {
+	err *Error
+}
+
+// Error will return the message of error, cause is not shown to the client
+func (e graphqlError) Error() string {
+	return e.err.Message
+}
+
+// Extensions will return the code and details of error, graphql writes them as extensions of error
+func (e graphqlError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.err.Code}
+	if len(e.err.Details) > 0 {
+		extensions["details"] = e.err.Details
+	}
+	return extensions
+}
+
+// GetGraphqlError will return error of graphql based on code of error, the code and details are written as its extensions
+func GetGraphqlError(err error) error {
+	if err == nil {
+		return nil
+	}
+	log.Error(err)
+	return graphqlError{err: AsError(err)}
+}
 `
 	expected_domain_success = `package domain
 
 // ResponseSuccess represent the reseponse success struct
 type ResponseSuccess struct {
-	Message string      ` + "`json:" + `"` + "message" + `"` + "`" + `
-	Data    interface{} ` + "`json:" + `"` + "data" + `"` + "`" + `
+	Message string      ` + "`" + `json:"message"` + "`" + `
+	Data    interface{} ` + "`" + `json:"data"` + "`" + `
 }
+`
+	expected_domain_order = `package domain
 
-var (
-	// ResponseData with type map used to response json if no error
-	ResponseData map[string]interface{}
+import (
+	"context"
+	"time"
+)
+
+// Order struct, models of order table
+type Order struct {
+	ID        uint64     ` + "`" + `bson:"_id" json:"id"` + "`" + `
+	Name      string     ` + "`" + `bson:"name" json:"name"` + "`" + `
+	CreatedAt time.Time  ` + "`" + `bson:"created_at" db:"created_at" json:"created_at"` + "`" + `
+	UpdatedAt time.Time  ` + "`" + `bson:"updated_at" db:"updated_at" json:"updated_at"` + "`" + `
+	DeletedAt *time.Time ` + "`" + `bson:"deleted_at" db:"deleted_at" json:"deleted_at" pg:",soft_delete"` + "`" + `
+}
+
+// OrderUsecase represent the Order's usecases contract
+type OrderUsecase interface {
+	Fetch(ctx context.Context) ([]*Order, error)
+	GetByID(ctx context.Context, id uint64) (*Order, error)
+	Store(ctx context.Context, o *Order) (*Order, error)
+	Update(ctx context.Context, o *Order) (*Order, error)
+	Delete(ctx context.Context, id uint64) error
+}
+
+// OrderRepository represent the Order's repository contract
+type OrderRepository interface {
+	Fetch(ctx context.Context) ([]*Order, error)
+	GetByID(ctx context.Context, id uint64) (*Order, error)
+	Store(ctx context.Context, o *Order) (*Order, error)
+	Update(ctx context.Context, o *Order) (*Order, error)
+	Delete(ctx context.Context, id uint64) error
+}
+`
+	expected_domain_task_spec = `package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
 )
+
+// Task struct, models of task table
+type Task struct {
+	ID uint64 ` + "`" + `bson:"_id" db:"id" json:"id"` + "`" + `
+	// Title of task
+	Title     string          ` + "`" + `bson:"title" db:"title" json:"title"` + "`" + `
+	Meta      json.RawMessage ` + "`" + `bson:"meta" db:"meta" json:"meta"` + "`" + `
+	DueAt     *time.Time      ` + "`" + `db:"due_at" json:"due_at,omitempty"` + "`" + `
+	CreatedBy string          ` + "`" + `bson:"created_by" db:"created_by" json:"created_by"` + "`" + `
+}
+
+// TaskUsecase represent the Task's usecases contract
+type TaskUsecase interface {
+	Fetch(ctx context.Context, filter map[string]interface{}) ([]*Task, error)
+	Archive(ctx context.Context, id uint64) error
+}
+
+// TaskRepository represent the Task's repository contract
+type TaskRepository interface {
+	Fetch(ctx context.Context, filter map[string]interface{}) ([]*Task, error)
+	Archive(ctx context.Context, id uint64) error
+}
 `
 )
 
@@ -100,7 +274,7 @@ func TestGenerateDomainErrors(t *testing.T) {
 		}
 
 		// generate errors.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenDomainErrors(dirName)
 		resGopg, err := newFs.FindFile(dirName + "/errors.go")
 		assert.NoError(t, err)
@@ -123,7 +297,7 @@ func TestGenerateDomainErrors(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate errors.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenDomainErrors(serviceName)
 
 		assert.Error(t, err)
@@ -153,7 +327,7 @@ func TestGenerateDomainStatusCode(t *testing.T) {
 		}
 
 		// generate status_code.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenDomainStatusCode(dirName)
 		resGopg, err := newFs.FindFile(dirName + "/status_code.go")
 		assert.NoError(t, err)
@@ -176,13 +350,67 @@ func TestGenerateDomainStatusCode(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate status_code.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenDomainStatusCode(serviceName)
 
 		assert.Error(t, err)
 	})
 }
 
+func TestGenerateDomainProblem(t *testing.T) {
+	var (
+		serviceName = "test_domain_problem"
+		dirLayer    = "domain"
+		dirName     = fmt.Sprintf("%s/%s", serviceName, dirLayer)
+		newFs       = fs.NewFsService()
+	)
+
+	t.Run("success, should generate a problem.go file", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate problem.go file
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenDomainProblem(dirName)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/problem.go")
+		if err != nil {
+			log.Error("File reading error", err)
+			os.Exit(1)
+		}
+		assert.Contains(t, string(data), `const ProblemContentType = "application/problem+json"`)
+		assert.Contains(t, string(data), "type ProblemDetails struct")
+		assert.Contains(t, string(data), `json:"invalid-params,omitempty"`)
+		assert.Contains(t, string(data), "func WriteProblem(w http.ResponseWriter, r *http.Request, err error)")
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		// generate problem.go file
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenDomainProblem(dirName)
+
+		assert.Error(t, err)
+	})
+}
+
 func TestGenerateDomainSuccess(t *testing.T) {
 	var (
 		serviceName = "test_domain_success"
@@ -206,7 +434,7 @@ func TestGenerateDomainSuccess(t *testing.T) {
 		}
 
 		// generate success.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenDomainSuccess(dirName)
 		resGopg, err := newFs.FindFile(dirName + "/success.go")
 		assert.NoError(t, err)
@@ -229,7 +457,7 @@ func TestGenerateDomainSuccess(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate success.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenDomainSuccess(serviceName)
 
 		assert.Error(t, err)
@@ -259,7 +487,7 @@ func TestGenerateDomainExample(t *testing.T) {
 		}
 
 		// generate example.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenDomainExample(dirName)
 		resGopg, err := newFs.FindFile(dirName + "/example.go")
 		assert.NoError(t, err)
@@ -275,9 +503,184 @@ func TestGenerateDomainExample(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenDomainExample(serviceName)
 
 		assert.Error(t, err)
 	})
 }
+
+func TestGenerateDomain(t *testing.T) {
+	var (
+		serviceName = "test_domain_order"
+		dirLayer    = "domain"
+		dirName     = fmt.Sprintf("%s/%s", serviceName, dirLayer)
+		newFs       = fs.NewFsService()
+	)
+
+	t.Run("success, should generate an order.go file", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate order.go file
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenDomain(dirName, "order")
+		resOrder, err := newFs.FindFile(dirName + "/order.go")
+		assert.NoError(t, err)
+		assert.NotEqual(t, nil, resOrder)
+
+		data, err := ioutil.ReadFile(dirName + "/order.go")
+		if err != nil {
+			log.Error("File reading error", err)
+			// remove directory of service
+			err = newFs.RemoveDir(serviceName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+			os.Exit(1)
+		}
+		assert.Equal(t, expected_domain_order, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		// generate order.go file
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenDomain(serviceName, "order")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGenerateDomainSpec(t *testing.T) {
+	var (
+		serviceName = "test_domain_spec"
+		dirLayer    = "domain"
+		dirName     = fmt.Sprintf("%s/%s", serviceName, dirLayer)
+		newFs       = fs.NewFsService()
+		entity      = domain.SpecEntity{
+			Name: "task",
+			Fields: []domain.SpecField{
+				domain.SpecField{Name: "ID", Type: "uint64"},
+				domain.SpecField{Name: "Title", Type: "string", Comment: "Title of task"},
+				domain.SpecField{Name: "Meta", Type: "json.RawMessage"},
+				domain.SpecField{Name: "DueAt", Type: "*time.Time", Tags: map[string]string{"json": "due_at,omitempty", "db": "due_at"}},
+				domain.SpecField{Name: "CreatedBy", Type: "string"},
+			},
+			Methods: []domain.SpecMethod{
+				domain.SpecMethod{Name: "Fetch", Params: []string{"ctx context.Context", "filter map[string]interface{}"}, Results: []string{"[]*Task", "error"}},
+				domain.SpecMethod{Name: "Archive", Params: []string{"ctx context.Context", "id uint64"}, Results: []string{"error"}},
+			},
+		}
+	)
+
+	t.Run("success, should generate a task.go file based on the entity", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate task.go file
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenDomainSpec(dirName, entity)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/task.go")
+		if err != nil {
+			log.Error("File reading error", err)
+			// remove directory of service
+			err = newFs.RemoveDir(serviceName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+			os.Exit(1)
+		}
+		assert.Equal(t, expected_domain_task_spec, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, should accept func, chan and variadic parameter", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate task.go file
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenDomainSpec(dirName, domain.SpecEntity{
+			Name: "task",
+			Methods: []domain.SpecMethod{
+				domain.SpecMethod{Name: "Each", Params: []string{"ctx context.Context", "fn func(*Task) (bool, error)", "done <-chan struct{}", "tags ...string"}, Results: []string{"error"}},
+			},
+		})
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/task.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "Each(ctx context.Context, fn func(*Task) (bool, error), done <-chan struct{}, tags ...string) error")
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("failed, because type is not valid", func(t *testing.T) {
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenDomainSpec(dirName, domain.SpecEntity{
+			Name:   "task",
+			Fields: []domain.SpecField{domain.SpecField{Name: "ID", Type: "map[string"}},
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenDomainSpec(serviceName, entity)
+
+		assert.Error(t, err)
+	})
+}
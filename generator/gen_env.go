@@ -1,9 +1,5 @@
 package generator
 
-import (
-	"io/ioutil"
-)
-
 func (gen *caGen) GenEnv(dirName string) error {
 	configEnv := []byte(`DB_USER_TEST=
 DB_PASSWORD_TEST=
@@ -21,6 +17,7 @@ DATABASE_NAME=
 
 DATABASE_MONGO_URL=
 DATABASE_MONGO_NAME=
+DATABASE_MONGO_CREATE_INDEX=true
 
 SERVER_ECHO_PORT=9090
 SERVER_GIN_PORT=8090
@@ -29,12 +26,12 @@ SERVER_NET_HTTP_SERVER_MUX_PORT=6090
 SERVER_GRAPHQL_SERVER_MUX_PORT=5090
 SERVER_GRPC_PORT=50051`)
 
-	err := ioutil.WriteFile("./"+dirName+"/.env", configEnv, 0644)
+	err := gen.fs.WriteFile(dirName+"/.env", configEnv)
 	if err != nil {
 		return err
 	}
 
-	err = ioutil.WriteFile("./"+dirName+"/.env.example", configEnv, 0644)
+	err = gen.fs.WriteFile(dirName+"/.env.example", configEnv)
 	if err != nil {
 		return err
 	}
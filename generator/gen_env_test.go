@@ -24,7 +24,7 @@ func TestGenerateEnvFile(t *testing.T) {
 		}
 
 		// generate env file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenEnv(serviceName)
 		resEnv, err := newFs.FindFile(serviceName + "/.env")
 		resExample, err := newFs.FindFile(serviceName + "/.env.example")
@@ -43,7 +43,7 @@ func TestGenerateEnvFile(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate env file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenEnv(serviceName)
 
 		assert.Error(t, err)
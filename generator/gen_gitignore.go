@@ -1,13 +1,11 @@
 package generator
 
-import "io/ioutil"
-
 func (gen *caGen) GenGitIgnore(dirName string) error {
 	gitignore := []byte(`vendor
 .env
 .DS_Store`)
 
-	err := ioutil.WriteFile("./"+dirName+"/.gitignore", gitignore, 0644)
+	err := gen.fs.WriteFile(dirName+"/.gitignore", gitignore)
 	if err != nil {
 		return err
 	}
@@ -24,7 +24,7 @@ func TestGenerateGitIgnoreFile(t *testing.T) {
 		}
 
 		// generate gitignore file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGitIgnore(serviceName)
 		resgitignore, err := newFs.FindFile(serviceName + "/.gitignore")
 
@@ -41,7 +41,7 @@ func TestGenerateGitIgnoreFile(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate gitignore file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGitIgnore(serviceName)
 
 		assert.Error(t, err)
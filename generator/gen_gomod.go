@@ -0,0 +1,26 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+)
+
+var goVersion = regexp.MustCompile(`^go(1\.[0-9]+)`)
+
+// GenGoMod will generate go.mod of the given module, go directive follow the version of running go toolchain
+func (gen *caGen) GenGoMod(dirName string, gomodName string) error {
+	version := "1.13"
+	if m := goVersion.FindStringSubmatch(runtime.Version()); m != nil {
+		version = m[1]
+	}
+
+	gomod := []byte(fmt.Sprintf("module %s\n\ngo %s\n", gomodName, version))
+
+	err := gen.fs.WriteFile(dirName+"/go.mod", gomod)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
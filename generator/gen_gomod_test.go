@@ -0,0 +1,50 @@
+package generator_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/wicaker/cacli/fs"
+	"github.com/wicaker/cacli/generator"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateGoMod(t *testing.T) {
+	serviceName := "testgomod"
+	newFs := fs.NewFsService()
+
+	t.Run("success, should generate go.mod file", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate go.mod file
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenGoMod(serviceName, "github.com/wicaker/testgomod")
+		resGomod, err := newFs.FindFile(serviceName + "/go.mod")
+
+		assert.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^module github.com/wicaker/testgomod\n\ngo 1\.[0-9]+\n$`), string(resGomod.([]byte)))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		// generate go.mod file
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenGoMod(serviceName, "github.com/wicaker/testgomod")
+
+		assert.Error(t, err)
+	})
+}
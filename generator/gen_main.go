@@ -42,6 +42,22 @@ func (gen *caGen) GenMain(dirName string, gomodName string, repoLib string, tran
 
 	server = append(server, dbConfig)
 	server = append(server, jen.Line())
+
+	// migrations are embedded into binary, so they are run by `migrate` subcommand of the service
+	if migrationConn := genMigrationConn(gomodName, repoLib, dbConf); migrationConn != nil {
+		importName[gomodName+"/database/migrations"] = "migrations"
+		server = append(server,
+			jen.If(jen.Len(jen.Qual("os", "Args")).Op(">").Lit(1).Op("&&").Qual("os", "Args").Index(jen.Lit(1)).Op("==").Lit("migrate")).Block(
+				jen.Err().Op(":=").Qual(gomodName+"/database/migrations", "Run").Call(migrationConn, jen.Qual("os", "Args").Index(jen.Lit(2).Op(":"))),
+				jen.If(jen.Err().Op("!=").Nil()).Block(
+					jen.Qual("github.com/sirupsen/logrus", "Fatalln").Call(jen.Err()),
+				),
+				jen.Return(),
+			),
+			jen.Line(),
+		)
+	}
+
 	server = append(server, jen.Id("errChan").Op(":=").Make(jen.Chan().Error()))
 	server = append(server, jen.Line())
 	for i := range transport {
@@ -91,7 +107,7 @@ func (gen *caGen) GenMain(dirName string, gomodName string, repoLib string, tran
 	)
 
 	fileDir := fmt.Sprintf("%s/main.go", dirName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -99,6 +115,22 @@ func (gen *caGen) GenMain(dirName string, gomodName string, repoLib string, tran
 	return nil
 }
 
+// genMigrationConn will return connection of migrations from database of the helper, nil if migration is not supported
+func genMigrationConn(gomodName string, repoLib string, dbConfig string) jen.Code {
+	migrations := gomodName + "/database/migrations"
+	switch repoLib {
+	case domain.GoPg:
+		return jen.Qual(migrations, "NewGopgConn").Call(jen.Id(dbConfig))
+	case domain.Gorm:
+		return jen.Qual(migrations, "NewSQLConn").Call(jen.Id(dbConfig).Dot("DB").Call())
+	case domain.Sqlx:
+		return jen.Qual(migrations, "NewSQLConn").Call(jen.Id(dbConfig).Dot("DB"))
+	case domain.SQL:
+		return jen.Qual(migrations, "NewSQLConn").Call(jen.Id(dbConfig))
+	}
+	return nil
+}
+
 func (g *goServer) EchoServer(gomodName string, dbConfig string) (code jen.Code) {
 	code = (jen.Go().Func().Params().Block(
 		jen.Id("eServer").Op(":=").Qual(gomodName+"/server", "EchoServer").Call(jen.Id(dbConfig)),
@@ -17,6 +17,7 @@ const (
 
 import (
 	"github.com/example/examplemain/database/config"
+	"github.com/example/examplemain/database/migrations"
 	"github.com/example/examplemain/server"
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
@@ -38,6 +39,14 @@ func init() {
 func main() {
 	dbgopg := config.GopgInit()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := migrations.Run(migrations.NewGopgConn(dbgopg), os.Args[2:])
+		if err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+
 	errChan := make(chan error)
 
 	go func() {
@@ -140,7 +149,7 @@ func TestGenerateMain(t *testing.T) {
 		}
 
 		// generate main.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenMain(serviceName, gomodName, domain.GoPg, transport)
 		resMain, err := newFs.FindFile(serviceName + "/main.go")
 		assert.NoError(t, err)
@@ -169,7 +178,7 @@ func TestGenerateMain(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate main.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenMain(serviceName, gomodName, domain.GoPg, transport)
 
 		assert.Error(t, err)
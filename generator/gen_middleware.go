@@ -4,8 +4,11 @@ import (
 	"fmt"
 
 	"github.com/dave/jennifer/jen"
+	"github.com/wicaker/cacli/domain"
 )
 
+const validatorPath = "gopkg.in/go-playground/validator.v9"
+
 func (gen *caGen) GenEchoMiddleware(dirName string) error {
 	f := jen.NewFile("middleware")
 	f.ImportAlias("github.com/sirupsen/logrus", "log")
@@ -50,7 +53,7 @@ func (gen *caGen) GenEchoMiddleware(dirName string) error {
 	)
 
 	fileDir := fmt.Sprintf("%s/echo_middleware.go", dirName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -115,7 +118,7 @@ func (gen *caGen) GenGinMiddleware(dirName string) error {
 	)
 
 	fileDir := fmt.Sprintf("%s/gin_middleware.go", dirName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -165,7 +168,7 @@ func (gen *caGen) GenGorillaMuxMiddleware(dirName string) error {
 	)
 
 	fileDir := fmt.Sprintf("%s/gorilla_mux_middleware.go", dirName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -215,7 +218,117 @@ func (gen *caGen) GenNetHTTPMiddleware(dirName string) error {
 	)
 
 	fileDir := fmt.Sprintf("%s/net_http_middleware.go", dirName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GenRecoverMiddleware will generate Recover middleware of rest server which answer the panic of handler
+// as problem details of domain.ErrInternalServerError, the panic is logged as its cause
+func (gen *caGen) GenRecoverMiddleware(dirName string, gomodName string, restServer string) error {
+	var (
+		f         = jen.NewFile("middleware")
+		panicErr  = jen.Qual(gomodName+"/domain", "ErrInternalServerError").Dot("Wrap").Call(jen.Qual("fmt", "Errorf").Call(jen.Lit("panic: %v"), jen.Id("rec")))
+		recovered = func(writer jen.Code, request jen.Code, abort ...jen.Code) jen.Code {
+			return jen.Defer().Func().Params().Block(
+				jen.If(jen.Id("rec").Op(":=").Recover(), jen.Id("rec").Op("!=").Nil()).Block(
+					append([]jen.Code{jen.Qual(gomodName+"/domain", "WriteProblem").Call(writer, request, panicErr)}, abort...)...,
+				),
+			).Call()
+		}
+	)
+	f.ImportName(gomodName+"/domain", "domain")
+	f.Comment("Recover will answer the panic of handler as problem details of domain.ErrInternalServerError")
+
+	switch restServer {
+	case domain.Echo:
+		f.Func().Params(jen.Id("m").Op("*").Id("EchoMiddleware")).Id("Recover").Params(jen.Id("next").Qual("github.com/labstack/echo", "HandlerFunc")).Qual("github.com/labstack/echo", "HandlerFunc").Block(
+			jen.Return(jen.Func().Params(jen.Id("c").Qual("github.com/labstack/echo", "Context")).Error().Block(
+				recovered(jen.Id("c").Dot("Response").Call(), jen.Id("c").Dot("Request").Call()),
+				jen.Return(jen.Id("next").Call(jen.Id("c"))),
+			)),
+		)
+	case domain.Gin:
+		f.Func().Params(jen.Id("m").Op("*").Id("GinMiddleware")).Id("Recover").Params().Qual("github.com/gin-gonic/gin", "HandlerFunc").Block(
+			jen.Return(jen.Func().Params(jen.Id("c").Op("*").Qual("github.com/gin-gonic/gin", "Context")).Block(
+				recovered(jen.Id("c").Dot("Writer"), jen.Id("c").Dot("Request"), jen.Id("c").Dot("Abort").Call()),
+				jen.Id("c").Dot("Next").Call(),
+			)),
+		)
+	case domain.GorillaMux, domain.NetHTTP:
+		middleware := "GorillaMuxMiddleware"
+		if restServer == domain.NetHTTP {
+			middleware = "NetHTTPMiddleware"
+		}
+		f.Func().Params(jen.Id("m").Op("*").Id(middleware)).Id("Recover").Params(jen.Id("next").Qual("net/http", "Handler")).Qual("net/http", "Handler").Block(
+			jen.Return(jen.Qual("net/http", "HandlerFunc").Call(
+				jen.Func().Params(jen.Id("w").Qual("net/http", "ResponseWriter"), jen.Id("r").Op("*").Qual("net/http", "Request")).Block(
+					recovered(jen.Id("w"), jen.Id("r")),
+					jen.Id("next").Dot("ServeHTTP").Call(jen.Id("w"), jen.Id("r")),
+				),
+			)),
+		)
+	default:
+		return fmt.Errorf("rest server %s is not supported", restServer)
+	}
+
+	fileDir := fmt.Sprintf("%s/recover_middleware.go", dirName)
+	err := gen.save(f, fileDir)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GenValidationMiddleware will generate validation of request which is shared by every transport,
+// name of invalid field is reported as it is written in json
+func (gen *caGen) GenValidationMiddleware(dirName string) error {
+	f := jen.NewFile("middleware")
+	f.ImportName(validatorPath, "validator")
+
+	f.Var().Id("validate").Op("=").Id("newValidator").Call()
+
+	f.Func().Id("newValidator").Params().Op("*").Qual(validatorPath, "Validate").Block(
+		jen.Id("v").Op(":=").Qual(validatorPath, "New").Call(),
+		jen.Id("v").Dot("RegisterTagNameFunc").Call(jen.Func().Params(jen.Id("fld").Qual("reflect", "StructField")).String().Block(
+			jen.Id("name").Op(":=").Qual("strings", "SplitN").Call(jen.Id("fld").Dot("Tag").Dot("Get").Call(jen.Lit("json")), jen.Lit(","), jen.Lit(2)).Index(jen.Lit(0)),
+			jen.If(jen.Id("name").Op("==").Lit("").Op("||").Id("name").Op("==").Lit("-")).Block(
+				jen.Return(jen.Id("fld").Dot("Name")),
+			),
+			jen.Return(jen.Id("name")),
+		)),
+		jen.Return(jen.Id("v")),
+	)
+
+	f.Comment("IsRequestValid will validate a incoming request, only struct is validated by the tags of its fields")
+	f.Func().Id("IsRequestValid").Params(jen.Id("m").Interface()).Params(jen.Bool(), jen.Error()).Block(
+		jen.Err().Op(":=").Id("validate").Dot("Struct").Call(jen.Id("m")),
+		jen.If(jen.List(jen.Id("_"), jen.Id("ok")).Op(":=").Err().Assert(jen.Op("*").Qual(validatorPath, "InvalidValidationError")), jen.Id("ok")).Block(
+			jen.Return(jen.True(), jen.Nil()),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.False(), jen.Err()),
+		),
+		jen.Return(jen.True(), jen.Nil()),
+	)
+
+	f.Comment("ValidationDetails will return the reason of every invalid field in error of IsRequestValid")
+	f.Func().Id("ValidationDetails").Params(jen.Err().Error()).Map(jen.String()).String().Block(
+		jen.Id("details").Op(":=").Map(jen.String()).String().Values(),
+		jen.If(jen.List(jen.Id("errs"), jen.Id("ok")).Op(":=").Err().Assert(jen.Qual(validatorPath, "ValidationErrors")), jen.Id("ok")).Block(
+			jen.For(jen.List(jen.Id("_"), jen.Id("e")).Op(":=").Range().Id("errs")).Block(
+				jen.Id("details").Index(jen.Id("e").Dot("Field").Call()).Op("=").Qual("fmt", "Sprintf").Call(jen.Lit("failed on the %s validation"), jen.Id("e").Dot("Tag").Call()),
+			),
+		),
+		jen.Return(jen.Id("details")),
+	)
+
+	fileDir := fmt.Sprintf("%s/validation_request.go", dirName)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -177,6 +177,53 @@ func (m *NetHTTPMiddleware) MiddlewareLogging(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+`
+
+	expected_validation_middleware = `package middleware
+
+import (
+	"fmt"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+	"strings"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return fld.Name
+		}
+		return name
+	})
+	return v
+}
+
+// IsRequestValid will validate a incoming request, only struct is validated by the tags of its fields
+func IsRequestValid(m interface{}) (bool, error) {
+	err := validate.Struct(m)
+	if _, ok := err.(*validator.InvalidValidationError); ok {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ValidationDetails will return the reason of every invalid field in error of IsRequestValid
+func ValidationDetails(err error) map[string]string {
+	details := map[string]string{}
+	if errs, ok := err.(validator.ValidationErrors); ok {
+		for _, e := range errs {
+			details[e.Field()] = fmt.Sprintf("failed on the %s validation", e.Tag())
+		}
+	}
+	return details
+}
 `
 )
 
@@ -203,7 +250,7 @@ func TestGenerateEchoMiddleware(t *testing.T) {
 		}
 
 		// generate echo_middleware.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenEchoMiddleware(dirName)
 		resGopg, err := newFs.FindFile(dirName + "/echo_middleware.go")
 		assert.NoError(t, err)
@@ -226,7 +273,7 @@ func TestGenerateEchoMiddleware(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate echo_middleware.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenEchoMiddleware(serviceName)
 
 		assert.Error(t, err)
@@ -256,7 +303,7 @@ func TestGenerateGinMiddleware(t *testing.T) {
 		}
 
 		// generate gin_middleware.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGinMiddleware(dirName)
 		resGopg, err := newFs.FindFile(dirName + "/gin_middleware.go")
 		assert.NoError(t, err)
@@ -279,7 +326,7 @@ func TestGenerateGinMiddleware(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate gin_middleware.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGinMiddleware(serviceName)
 
 		assert.Error(t, err)
@@ -309,7 +356,7 @@ func TestGenerateGorillaMuxMiddleware(t *testing.T) {
 		}
 
 		// generate gorilla_mux_middleware.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGorillaMuxMiddleware(dirName)
 		resGopg, err := newFs.FindFile(dirName + "/gorilla_mux_middleware.go")
 		assert.NoError(t, err)
@@ -332,7 +379,7 @@ func TestGenerateGorillaMuxMiddleware(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate gorilla_mux_middleware.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGorillaMuxMiddleware(serviceName)
 
 		assert.Error(t, err)
@@ -362,7 +409,7 @@ func TestGenerateNetHTTPMiddleware(t *testing.T) {
 		}
 
 		// generate net_http_middleware.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenNetHTTPMiddleware(dirName)
 		resGopg, err := newFs.FindFile(dirName + "/net_http_middleware.go")
 		assert.NoError(t, err)
@@ -385,9 +432,134 @@ func TestGenerateNetHTTPMiddleware(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate net_http_middleware.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenNetHTTPMiddleware(serviceName)
 
 		assert.Error(t, err)
 	})
 }
+
+func TestGenerateValidationMiddleware(t *testing.T) {
+	var (
+		serviceName = "test_validation_middleware"
+		dirLayer    = "middleware"
+		dirName     = fmt.Sprintf("%s/%s", serviceName, dirLayer)
+		newFs       = fs.NewFsService()
+	)
+
+	t.Run("success, should generate a validation_request.go file", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(dirName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate validation_request.go file
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenValidationMiddleware(dirName)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/validation_request.go")
+		if err != nil {
+			log.Error("File reading error", err)
+			os.Exit(1)
+		}
+		assert.Equal(t, expected_validation_middleware, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		// generate validation_request.go file
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenValidationMiddleware(dirName)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGenerateRecoverMiddleware(t *testing.T) {
+	var (
+		serviceName = "test_recover_middleware"
+		dirLayer    = "middleware"
+		gomodName   = "github.com/example/examplerecover"
+		dirName     = fmt.Sprintf("%s/%s", serviceName, dirLayer)
+		newFs       = fs.NewFsService()
+	)
+
+	tests := []struct {
+		restServer string
+		recover    string
+	}{
+		{restServer: "echo", recover: "func (m *EchoMiddleware) Recover(next echo.HandlerFunc) echo.HandlerFunc"},
+		{restServer: "gin", recover: "func (m *GinMiddleware) Recover() gin.HandlerFunc"},
+		{restServer: "gorilla mux", recover: "func (m *GorillaMuxMiddleware) Recover(next http.Handler) http.Handler"},
+		{restServer: "net/http", recover: "func (m *NetHTTPMiddleware) Recover(next http.Handler) http.Handler"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run("success, should generate a recover_middleware.go file of "+tt.restServer, func(t *testing.T) {
+			// create directory of service
+			err := newFs.CreateDir(serviceName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			err = newFs.CreateDir(dirName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			// generate recover_middleware.go file
+			gen := generator.NewGeneratorService(newFs)
+			err = gen.GenRecoverMiddleware(dirName, gomodName, tt.restServer)
+			assert.NoError(t, err)
+
+			data, err := ioutil.ReadFile(dirName + "/recover_middleware.go")
+			if err != nil {
+				log.Error("File reading error", err)
+				os.Exit(1)
+			}
+			assert.Contains(t, string(data), tt.recover)
+			assert.Contains(t, string(data), "domain.WriteProblem(")
+
+			// remove directory of service
+			err = newFs.RemoveDir(serviceName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+		})
+	}
+
+	t.Run("failed, because rest server is not supported", func(t *testing.T) {
+		// generate recover_middleware.go file
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenRecoverMiddleware(dirName, gomodName, "iris")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		// generate recover_middleware.go file
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenRecoverMiddleware(dirName, gomodName, "echo")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,390 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wicaker/cacli/domain"
+)
+
+// migrationChange represent a difference between the snapshot and the current table
+type migrationChange struct {
+	previous *domain.Column
+	current  *domain.Column
+}
+
+// MigrationTable will build the table of entity which is migrated,
+// column of every field is named the same way as the repository of database helper
+func MigrationTable(domainName string, dbHelper string, entity domain.Entity) (*domain.Table, error) {
+	columnNames := map[string]func(f domain.Field) string{
+		domain.GoPg: gopgColumnName,
+		domain.Gorm: gormColumnName,
+		domain.Sqlx: columnName,
+		domain.SQL:  columnName,
+	}
+	name, ok := columnNames[dbHelper]
+	if !ok {
+		return nil, fmt.Errorf("migration is not supported by %s database helper", dbHelper)
+	}
+
+	e := newSQLEntity(entity, name)
+	if e == nil {
+		return nil, fmt.Errorf("entity of %s must have an id field", domainName)
+	}
+	if e.embedded {
+		return nil, fmt.Errorf("entity of %s has embedded fields, write its migration manually", domainName)
+	}
+
+	table := &domain.Table{Domain: domainName, Name: e.table}
+	for _, c := range e.columns {
+		table.Columns = append(table.Columns, domain.Column{
+			Name:    c.name,
+			Type:    c.field.Type,
+			Primary: c.name == e.primary.name,
+		})
+	}
+	return table, nil
+}
+
+// GenMigration will generate up and down migration of the table,
+// the table is created if there is no snapshot, otherwise it is altered based on the changed columns
+func (gen *caGen) GenMigration(dirName string, version string, format string, dialect string, previous *domain.Table, table *domain.Table) error {
+	var (
+		name     = "create_" + table.Name
+		up, down string
+	)
+
+	if format != domain.SQLMigration && format != domain.FizzMigration {
+		return fmt.Errorf("migration format %q is not supported, choose one of: sql, fizz", format)
+	}
+	if format == domain.SQLMigration && dialect != domain.MySQL && dialect != domain.Postgres {
+		return errors.New("dialect of sql migration must be one of: mysql, postgres")
+	}
+
+	if previous == nil {
+		if format == domain.FizzMigration {
+			up, down = fizzCreateTable(table), fmt.Sprintf("drop_table(\"%s\")\n", table.Name)
+		} else {
+			up, down = sqlCreateTable(dialect, table), fmt.Sprintf("DROP TABLE %s;\n", table.Name)
+		}
+	} else {
+		changes := diffTable(previous, table)
+		if len(changes) == 0 && previous.Name == table.Name {
+			return domain.ErrNoMigration
+		}
+
+		name = "alter_" + table.Name
+		if format == domain.FizzMigration {
+			up, down = fizzAlterTable(previous, table, changes)
+		} else {
+			up, down = sqlAlterTable(dialect, previous, table, changes)
+		}
+
+		// go type was changed, but it is stored as the same column
+		if strings.TrimSpace(up) == "" {
+			return domain.ErrNoMigration
+		}
+	}
+
+	fileName := fmt.Sprintf("%s/%s_%s", dirName, version, name)
+	err := gen.fs.WriteFile(fileName+".up."+format, []byte(up))
+	if err != nil {
+		return err
+	}
+
+	err = gen.fs.WriteFile(fileName+".down."+format, []byte(down))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// diffTable will return the added, changed and removed columns in order of the table
+func diffTable(previous *domain.Table, table *domain.Table) []migrationChange {
+	var changes []migrationChange
+
+	find := func(t *domain.Table, name string) *domain.Column {
+		for i := range t.Columns {
+			if t.Columns[i].Name == name {
+				return &t.Columns[i]
+			}
+		}
+		return nil
+	}
+
+	for i := range table.Columns {
+		c := &table.Columns[i]
+		p := find(previous, c.Name)
+		if p == nil || p.Type != c.Type {
+			changes = append(changes, migrationChange{previous: p, current: c})
+		}
+	}
+	for i := range previous.Columns {
+		p := &previous.Columns[i]
+		if find(table, p.Name) == nil {
+			changes = append(changes, migrationChange{previous: p})
+		}
+	}
+	return changes
+}
+
+// sqlCreateTable will write create table statement of the dialect
+func sqlCreateTable(dialect string, table *domain.Table) string {
+	var columns []string
+	for _, c := range table.Columns {
+		columns = append(columns, "    "+sqlColumnDefinition(dialect, c, c.Primary))
+	}
+
+	sql := fmt.Sprintf("CREATE TABLE %s (\n%s\n)", table.Name, strings.Join(columns, ",\n"))
+	if dialect == domain.MySQL {
+		sql += " ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+	}
+	return sql + ";\n"
+}
+
+// sqlAlterTable will write alter table statements of the changes, down migration revert them in reverse order
+func sqlAlterTable(dialect string, previous *domain.Table, table *domain.Table, changes []migrationChange) (up string, down string) {
+	var ups, downs []string
+
+	if previous.Name != table.Name {
+		ups = append(ups, fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", previous.Name, table.Name))
+		downs = append(downs, fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", table.Name, previous.Name))
+	}
+
+	for _, c := range changes {
+		switch {
+		case c.previous == nil:
+			ups = append(ups, sqlAddColumn(dialect, table.Name, *c.current))
+			downs = append(downs, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table.Name, c.current.Name))
+		case c.current == nil:
+			ups = append(ups, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table.Name, c.previous.Name))
+			downs = append(downs, sqlAddColumn(dialect, table.Name, *c.previous))
+		default:
+			ups = append(ups, sqlModifyColumn(dialect, table.Name, *c.previous, *c.current)...)
+			downs = append(downs, sqlModifyColumn(dialect, table.Name, *c.current, *c.previous)...)
+		}
+	}
+
+	for i, j := 0, len(downs)-1; i < j; i, j = i+1, j-1 {
+		downs[i], downs[j] = downs[j], downs[i]
+	}
+	return strings.Join(ups, "\n") + "\n", strings.Join(downs, "\n") + "\n"
+}
+
+// sqlAddColumn will add the column, rows which already exist are filled with zero value of the go type
+func sqlAddColumn(dialect string, table string, c domain.Column) string {
+	definition := sqlColumnDefinition(dialect, c, false)
+	if _, null := sqlType(dialect, c.Type); !null {
+		definition += " DEFAULT " + sqlZeroValue(dialect, c.Type)
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, definition)
+}
+
+// sqlModifyColumn will change type and nullability of the column from the previous one
+func sqlModifyColumn(dialect string, table string, from domain.Column, to domain.Column) []string {
+	if dialect == domain.MySQL {
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", table, sqlColumnDefinition(dialect, to, false))}
+	}
+
+	var (
+		fromType, fromNull = sqlType(dialect, from.Type)
+		toType, toNull     = sqlType(dialect, to.Type)
+		sql                []string
+	)
+	if fromType != toType {
+		sql = append(sql, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", table, to.Name, toType))
+	}
+	if fromNull != toNull {
+		nullable := "SET NOT NULL"
+		if toNull {
+			nullable = "DROP NOT NULL"
+		}
+		sql = append(sql, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", table, to.Name, nullable))
+	}
+	return sql
+}
+
+// sqlColumnDefinition will write the column with its type, integer primary key is generated by database
+func sqlColumnDefinition(dialect string, c domain.Column, primary bool) string {
+	typ, null := sqlType(dialect, c.Type)
+	if primary {
+		if !isIntegerType(c.Type) {
+			return fmt.Sprintf("%s %s PRIMARY KEY", c.Name, typ)
+		}
+		if dialect == domain.MySQL {
+			return fmt.Sprintf("%s %s NOT NULL AUTO_INCREMENT PRIMARY KEY", c.Name, typ)
+		}
+		if typ == "BIGINT" {
+			return c.Name + " BIGSERIAL PRIMARY KEY"
+		}
+		return c.Name + " SERIAL PRIMARY KEY"
+	}
+
+	if null {
+		return fmt.Sprintf("%s %s NULL", c.Name, typ)
+	}
+	return fmt.Sprintf("%s %s NOT NULL", c.Name, typ)
+}
+
+// sqlType will return column type of the go type and whether the column is nullable,
+// pointer and sql.Null types are nullable, unknown type is stored as text
+func sqlType(dialect string, goType string) (typ string, null bool) {
+	null = strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "sql.Null")
+	goType = strings.TrimPrefix(goType, "*")
+
+	mysql := dialect == domain.MySQL
+	pick := func(postgres string, mysqlType string) string {
+		if mysql {
+			return mysqlType
+		}
+		return postgres
+	}
+
+	switch goType {
+	case "int8", "int16", "uint8", "uint16":
+		typ = pick("SMALLINT", "SMALLINT")
+	case "int32", "sql.NullInt32":
+		typ = "INTEGER"
+	case "uint32":
+		typ = pick("INTEGER", "INTEGER UNSIGNED")
+	case "int", "int64", "sql.NullInt64":
+		typ = "BIGINT"
+	case "uint", "uint64":
+		typ = pick("BIGINT", "BIGINT UNSIGNED")
+	case "string", "sql.NullString":
+		typ = "VARCHAR(255)"
+	case "bool", "sql.NullBool":
+		typ = "BOOLEAN"
+	case "float32":
+		typ = pick("REAL", "FLOAT")
+	case "float64", "sql.NullFloat64":
+		typ = pick("DOUBLE PRECISION", "DOUBLE")
+	case "time.Time", "sql.NullTime", "pq.NullTime", "mysql.NullTime":
+		typ = pick("TIMESTAMP", "DATETIME")
+	case "[]byte":
+		typ = pick("BYTEA", "BLOB")
+	case "json.RawMessage":
+		typ = pick("JSONB", "JSON")
+	default:
+		typ = "TEXT"
+	}
+	return typ, null
+}
+
+// sqlZeroValue will return literal of the zero value of go type, used as default of new column
+func sqlZeroValue(dialect string, goType string) string {
+	typ, _ := sqlType(dialect, goType)
+	switch {
+	case isIntegerType(goType), goType == "float32", goType == "float64":
+		return "0"
+	case goType == "bool":
+		return "FALSE"
+	case typ == "TIMESTAMP", typ == "DATETIME":
+		return "CURRENT_TIMESTAMP"
+	case typ == "JSONB", typ == "JSON":
+		return "'null'"
+	}
+	return "''"
+}
+
+// fizzCreateTable will write create_table of fizz,
+// timestamp is disabled if the entity doesn't have created_at and updated_at because fizz add them by default
+func fizzCreateTable(table *domain.Table) string {
+	var (
+		b          strings.Builder
+		timestamps = 0
+	)
+
+	fmt.Fprintf(&b, "create_table(\"%s\") {\n", table.Name)
+	for _, c := range table.Columns {
+		fmt.Fprintf(&b, "\t%s\n", fizzColumn("t.Column", "", c, c.Primary))
+		if (c.Name == "created_at" || c.Name == "updated_at") && isTimeType(c.Type) {
+			timestamps++
+		}
+	}
+	if timestamps < 2 {
+		b.WriteString("\tt.DisableTimestamps()\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// fizzAlterTable will write fizz of the changes, down migration revert them in reverse order
+func fizzAlterTable(previous *domain.Table, table *domain.Table, changes []migrationChange) (up string, down string) {
+	var ups, downs []string
+
+	if previous.Name != table.Name {
+		ups = append(ups, fmt.Sprintf("rename_table(\"%s\", \"%s\")", previous.Name, table.Name))
+		downs = append(downs, fmt.Sprintf("rename_table(\"%s\", \"%s\")", table.Name, previous.Name))
+	}
+
+	for _, c := range changes {
+		switch {
+		case c.previous == nil:
+			ups = append(ups, fizzColumn("add_column", table.Name, *c.current, false))
+			downs = append(downs, fmt.Sprintf("drop_column(\"%s\", \"%s\")", table.Name, c.current.Name))
+		case c.current == nil:
+			ups = append(ups, fmt.Sprintf("drop_column(\"%s\", \"%s\")", table.Name, c.previous.Name))
+			downs = append(downs, fizzColumn("add_column", table.Name, *c.previous, false))
+		default:
+			ups = append(ups, fizzColumn("change_column", table.Name, *c.current, false))
+			downs = append(downs, fizzColumn("change_column", table.Name, *c.previous, false))
+		}
+	}
+
+	for i, j := 0, len(downs)-1; i < j; i, j = i+1, j-1 {
+		downs[i], downs[j] = downs[j], downs[i]
+	}
+	return strings.Join(ups, "\n") + "\n", strings.Join(downs, "\n") + "\n"
+}
+
+// fizzColumn will write a column instruction of fizz, e.g. t.Column("title", "string", {})
+func fizzColumn(instruction string, table string, c domain.Column, primary bool) string {
+	var (
+		typ, null = sqlType("", c.Type)
+		options   = "{}"
+		args      []string
+	)
+
+	switch {
+	case isIntegerType(strings.TrimPrefix(c.Type, "*")) && typ == "BIGINT":
+		typ = "bigint"
+	case isIntegerType(strings.TrimPrefix(c.Type, "*")):
+		typ = "integer"
+	case typ == "VARCHAR(255)":
+		typ = "string"
+	case typ == "BOOLEAN":
+		typ = "bool"
+	case typ == "REAL", typ == "DOUBLE PRECISION":
+		typ = "float"
+	case typ == "BYTEA":
+		typ = "blob"
+	case typ == "JSONB":
+		typ = "json"
+	default:
+		typ = strings.ToLower(typ)
+	}
+
+	switch {
+	case primary:
+		options = "{primary: true}"
+	case null:
+		options = `{"null": true}`
+	}
+
+	if table != "" {
+		args = append(args, fmt.Sprintf("%q", table))
+	}
+	args = append(args, fmt.Sprintf("%q", c.Name), fmt.Sprintf("%q", typ), options)
+	return fmt.Sprintf("%s(%s)", instruction, strings.Join(args, ", "))
+}
+
+func isIntegerType(typ string) bool {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,275 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/wicaker/cacli/domain"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// GenMigrationRunner will generate migrations package which embed the sql migrations into binary of service,
+// the migrations are run on the connection of database helper without any external tool
+func (gen *caGen) GenMigrationRunner(dirName string, repoLib string) error {
+	if repoLib != domain.GoPg && repoLib != domain.Gorm && repoLib != domain.Sqlx && repoLib != domain.SQL {
+		return fmt.Errorf("migration is not supported by %s database helper", repoLib)
+	}
+
+	var (
+		f           = jen.NewFile("migrations")
+		errUsage    = jen.Id("errUsage")
+		migrationID = func(m jen.Code) *jen.Statement {
+			return jen.Add(m).Dot("Version").Op("+").Lit("_").Op("+").Add(m).Dot("Name")
+		}
+	)
+	f.ImportNames(map[string]string{"embed": "embed", "github.com/go-pg/pg/v9": "pg"})
+
+	f.Comment("files are the migrations inside this directory, only the sql migrations are run")
+	f.Comment("//go:embed *")
+	f.Var().Id("files").Qual("embed", "FS")
+
+	f.Line()
+	f.Var().Defs(
+		jen.Id("fileName").Op("=").Qual("regexp", "MustCompile").Call(jen.Lit(`^([0-9]{14})_([a-z0-9_]+)\.(up|down)\.sql$`)),
+		errUsage.Clone().Op("=").Qual("errors", "New").Call(jen.Lit("usage: migrate up|down [steps]|status")),
+	)
+
+	f.Line()
+	f.Const().Id("createTable").Op("=").Lit("CREATE TABLE IF NOT EXISTS schema_migrations (version VARCHAR(191) NOT NULL PRIMARY KEY)")
+
+	f.Line()
+	f.Comment("Migration represent up and down migration of a version")
+	f.Type().Id("Migration").Struct(
+		jen.Id("Version").String(),
+		jen.Id("Name").String(),
+		jen.Id("Up").String(),
+		jen.Id("Down").String(),
+		jen.Id("Applied").Bool(),
+	)
+
+	f.Line()
+	f.Comment("Conn is the database connection which the migrations are run on")
+	f.Type().Id("Conn").Interface(
+		jen.Comment("Exec run the statements in a transaction"),
+		jen.Id("Exec").Params(jen.Id("statements").Index().String()).Error(),
+		jen.Comment("Versions return the migrations which were applied"),
+		jen.Id("Versions").Params().Params(jen.Index().String(), jen.Error()),
+	)
+
+	f.Line()
+	f.Comment("Run will run subcommand of migrate, which is one of: up, down [steps] or status")
+	f.Func().Id("Run").Params(jen.Id("conn").Id("Conn"), jen.Id("args").Index().String()).Error().Block(
+		jen.If(jen.Len(jen.Id("args")).Op("==").Lit(0)).Block(jen.Return(errUsage)),
+		jen.Line(),
+		jen.Err().Op(":=").Id("conn").Dot("Exec").Call(jen.Index().String().Values(jen.Id("createTable"))),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+		jen.Line(),
+		jen.List(jen.Id("migrations"), jen.Err()).Op(":=").Id("Load").Call(jen.Id("conn")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+		jen.Line(),
+		jen.Switch().Block(
+			jen.Case(jen.Len(jen.Id("args")).Op("==").Lit(1).Op("&&").Id("args").Index(jen.Lit(0)).Op("==").Lit("up")).Block(
+				jen.For(jen.List(jen.Id("_"), jen.Id("m")).Op(":=").Range().Id("migrations")).Block(
+					jen.If(jen.Id("m").Dot("Applied")).Block(jen.Continue()),
+					jen.Err().Op("=").Id("conn").Dot("Exec").Call(jen.Append(
+						jen.Id("statements").Call(jen.Id("m").Dot("Up")),
+						jen.Qual("fmt", "Sprintf").Call(jen.Lit("INSERT INTO schema_migrations (version) VALUES ('%s')"), migrationID(jen.Id("m"))),
+					)),
+					jen.If(jen.Err().Op("!=").Nil()).Block(
+						jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("migrate up %s: %s"), migrationID(jen.Id("m")), jen.Err())),
+					),
+					jen.Qual("fmt", "Println").Call(jen.Lit("applied"), migrationID(jen.Id("m"))),
+				),
+				jen.Return(jen.Nil()),
+			),
+			jen.Case(jen.Len(jen.Id("args")).Op("<=").Lit(2).Op("&&").Id("args").Index(jen.Lit(0)).Op("==").Lit("down")).Block(
+				jen.Id("steps").Op(":=").Lit(1),
+				jen.If(jen.Len(jen.Id("args")).Op("==").Lit(2)).Block(
+					jen.List(jen.Id("steps"), jen.Err()).Op("=").Qual("strconv", "Atoi").Call(jen.Id("args").Index(jen.Lit(1))),
+					jen.If(jen.Err().Op("!=").Nil().Op("||").Id("steps").Op("<").Lit(1)).Block(
+						jen.Return(jen.Qual("errors", "New").Call(jen.Lit("steps of migrate down must be a positive number"))),
+					),
+				),
+				jen.For(jen.Id("i").Op(":=").Len(jen.Id("migrations")).Op("-").Lit(1), jen.Id("i").Op(">=").Lit(0).Op("&&").Id("steps").Op(">").Lit(0), jen.Id("i").Op("--")).Block(
+					jen.Id("m").Op(":=").Id("migrations").Index(jen.Id("i")),
+					jen.If(jen.Op("!").Id("m").Dot("Applied")).Block(jen.Continue()),
+					jen.Err().Op("=").Id("conn").Dot("Exec").Call(jen.Append(
+						jen.Id("statements").Call(jen.Id("m").Dot("Down")),
+						jen.Qual("fmt", "Sprintf").Call(jen.Lit("DELETE FROM schema_migrations WHERE version = '%s'"), migrationID(jen.Id("m"))),
+					)),
+					jen.If(jen.Err().Op("!=").Nil()).Block(
+						jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("migrate down %s: %s"), migrationID(jen.Id("m")), jen.Err())),
+					),
+					jen.Qual("fmt", "Println").Call(jen.Lit("reverted"), migrationID(jen.Id("m"))),
+					jen.Id("steps").Op("--"),
+				),
+				jen.Return(jen.Nil()),
+			),
+			jen.Case(jen.Len(jen.Id("args")).Op("==").Lit(1).Op("&&").Id("args").Index(jen.Lit(0)).Op("==").Lit("status")).Block(
+				jen.For(jen.List(jen.Id("_"), jen.Id("m")).Op(":=").Range().Id("migrations")).Block(
+					jen.Id("status").Op(":=").Lit("pending"),
+					jen.If(jen.Id("m").Dot("Applied")).Block(jen.Id("status").Op("=").Lit("applied")),
+					jen.Qual("fmt", "Printf").Call(jen.Lit("%-8s %s\n"), jen.Id("status"), migrationID(jen.Id("m"))),
+				),
+				jen.Return(jen.Nil()),
+			),
+		),
+		jen.Return(errUsage),
+	)
+
+	f.Line()
+	f.Comment("Load will read the embedded migrations in order of version, then mark the applied ones")
+	f.Func().Id("Load").Params(jen.Id("conn").Id("Conn")).Params(jen.Index().Op("*").Id("Migration"), jen.Error()).Block(
+		jen.List(jen.Id("entries"), jen.Err()).Op(":=").Id("files").Dot("ReadDir").Call(jen.Lit(".")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+		jen.Line(),
+		jen.Var().Defs(
+			jen.Id("migrations").Index().Op("*").Id("Migration"),
+			jen.Id("byID").Op("=").Map(jen.String()).Op("*").Id("Migration").Values(),
+		),
+		jen.For(jen.List(jen.Id("_"), jen.Id("e")).Op(":=").Range().Id("entries")).Block(
+			jen.Id("match").Op(":=").Id("fileName").Dot("FindStringSubmatch").Call(jen.Id("e").Dot("Name").Call()),
+			jen.If(jen.Id("match").Op("==").Nil()).Block(jen.Continue()),
+			jen.Line(),
+			jen.List(jen.Id("data"), jen.Err()).Op(":=").Id("files").Dot("ReadFile").Call(jen.Id("e").Dot("Name").Call()),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+			jen.Line(),
+			jen.List(jen.Id("m"), jen.Id("ok")).Op(":=").Id("byID").Index(jen.Id("match").Index(jen.Lit(1)).Op("+").Lit("_").Op("+").Id("match").Index(jen.Lit(2))),
+			jen.If(jen.Op("!").Id("ok")).Block(
+				jen.Id("m").Op("=").Op("&").Id("Migration").Values(jen.Dict{
+					jen.Id("Version"): jen.Id("match").Index(jen.Lit(1)),
+					jen.Id("Name"):    jen.Id("match").Index(jen.Lit(2)),
+				}),
+				jen.Id("byID").Index(migrationID(jen.Id("m"))).Op("=").Id("m"),
+				jen.Id("migrations").Op("=").Append(jen.Id("migrations"), jen.Id("m")),
+			),
+			jen.If(jen.Id("match").Index(jen.Lit(3)).Op("==").Lit("up")).Block(
+				jen.Id("m").Dot("Up").Op("=").String().Call(jen.Id("data")),
+			).Else().Block(
+				jen.Id("m").Dot("Down").Op("=").String().Call(jen.Id("data")),
+			),
+		),
+		jen.Qual("sort", "Slice").Call(jen.Id("migrations"), jen.Func().Params(jen.List(jen.Id("i"), jen.Id("j")).Int()).Bool().Block(
+			jen.Return(migrationID(jen.Id("migrations").Index(jen.Id("i"))).Op("<").Add(migrationID(jen.Id("migrations").Index(jen.Id("j"))))),
+		)),
+		jen.Line(),
+		jen.List(jen.Id("versions"), jen.Err()).Op(":=").Id("conn").Dot("Versions").Call(),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+		jen.For(jen.List(jen.Id("_"), jen.Id("v")).Op(":=").Range().Id("versions")).Block(
+			jen.If(jen.List(jen.Id("m"), jen.Id("ok")).Op(":=").Id("byID").Index(jen.Id("v")), jen.Id("ok")).Block(
+				jen.Id("m").Dot("Applied").Op("=").True(),
+			),
+		),
+		jen.Line(),
+		jen.Return(jen.Id("migrations"), jen.Nil()),
+	)
+
+	f.Line()
+	f.Comment("statements will split the migration, every statement is ended by semicolon at the end of line")
+	f.Func().Id("statements").Params(jen.Id("migration").String()).Index().String().Block(
+		jen.Var().Defs(
+			jen.Id("result").Index().String(),
+			jen.Id("statement").Qual("strings", "Builder"),
+		),
+		jen.For(jen.List(jen.Id("_"), jen.Id("line")).Op(":=").Range().Qual("strings", "Split").Call(jen.Id("migration"), jen.Lit("\n"))).Block(
+			jen.Id("statement").Dot("WriteString").Call(jen.Id("line").Op("+").Lit("\n")),
+			jen.If(jen.Qual("strings", "HasSuffix").Call(jen.Qual("strings", "TrimSpace").Call(jen.Id("line")), jen.Lit(";"))).Block(
+				jen.Id("result").Op("=").Append(jen.Id("result"), jen.Qual("strings", "TrimSpace").Call(jen.Id("statement").Dot("String").Call())),
+				jen.Id("statement").Dot("Reset").Call(),
+			),
+		),
+		jen.If(jen.Id("rest").Op(":=").Qual("strings", "TrimSpace").Call(jen.Id("statement").Dot("String").Call()), jen.Id("rest").Op("!=").Lit("")).Block(
+			jen.Id("result").Op("=").Append(jen.Id("result"), jen.Id("rest")),
+		),
+		jen.Return(jen.Id("result")),
+	)
+
+	f.Line()
+	if repoLib == domain.GoPg {
+		genGopgMigrationConn(f)
+	} else {
+		genSQLMigrationConn(f)
+	}
+
+	err := gen.save(f, dirName+"/migrations.go")
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// genSQLMigrationConn will generate Conn of *sql.DB, which is also used by sqlx and gorm
+func genSQLMigrationConn(f *jen.File) {
+	f.Type().Id("sqlConn").Struct(
+		jen.Id("db").Op("*").Qual("database/sql", "DB"),
+	)
+
+	f.Line()
+	f.Comment("NewSQLConn will create new a sqlConn object representation of Conn interface")
+	f.Func().Id("NewSQLConn").Params(jen.Id("db").Op("*").Qual("database/sql", "DB")).Id("Conn").Block(
+		jen.Return(jen.Op("&").Id("sqlConn").Values(jen.Dict{jen.Id("db"): jen.Id("db")})),
+	)
+
+	f.Line()
+	f.Func().Params(jen.Id("c").Op("*").Id("sqlConn")).Id("Exec").Params(jen.Id("statements").Index().String()).Error().Block(
+		jen.List(jen.Id("tx"), jen.Err()).Op(":=").Id("c").Dot("db").Dot("Begin").Call(),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+		jen.Line(),
+		jen.For(jen.List(jen.Id("_"), jen.Id("s")).Op(":=").Range().Id("statements")).Block(
+			jen.List(jen.Id("_"), jen.Err()).Op("=").Id("tx").Dot("Exec").Call(jen.Id("s")),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.Id("tx").Dot("Rollback").Call(),
+				jen.Return(jen.Err()),
+			),
+		),
+		jen.Return(jen.Id("tx").Dot("Commit").Call()),
+	)
+
+	f.Line()
+	f.Func().Params(jen.Id("c").Op("*").Id("sqlConn")).Id("Versions").Params().Params(jen.Index().String(), jen.Error()).Block(
+		jen.List(jen.Id("rows"), jen.Err()).Op(":=").Id("c").Dot("db").Dot("Query").Call(jen.Lit("SELECT version FROM schema_migrations")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+		jen.Defer().Id("rows").Dot("Close").Call(),
+		jen.Line(),
+		jen.Var().Id("versions").Index().String(),
+		jen.For(jen.Id("rows").Dot("Next").Call()).Block(
+			jen.Var().Id("v").String(),
+			jen.If(jen.Err().Op(":=").Id("rows").Dot("Scan").Call(jen.Op("&").Id("v")), jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Nil(), jen.Err()),
+			),
+			jen.Id("versions").Op("=").Append(jen.Id("versions"), jen.Id("v")),
+		),
+		jen.Return(jen.Id("versions"), jen.Id("rows").Dot("Err").Call()),
+	)
+}
+
+// genGopgMigrationConn will generate Conn of *pg.DB
+func genGopgMigrationConn(f *jen.File) {
+	f.Type().Id("gopgConn").Struct(
+		jen.Id("db").Op("*").Qual("github.com/go-pg/pg/v9", "DB"),
+	)
+
+	f.Line()
+	f.Comment("NewGopgConn will create new a gopgConn object representation of Conn interface")
+	f.Func().Id("NewGopgConn").Params(jen.Id("db").Op("*").Qual("github.com/go-pg/pg/v9", "DB")).Id("Conn").Block(
+		jen.Return(jen.Op("&").Id("gopgConn").Values(jen.Dict{jen.Id("db"): jen.Id("db")})),
+	)
+
+	f.Line()
+	f.Func().Params(jen.Id("c").Op("*").Id("gopgConn")).Id("Exec").Params(jen.Id("statements").Index().String()).Error().Block(
+		jen.Return(jen.Id("c").Dot("db").Dot("RunInTransaction").Call(jen.Func().Params(jen.Id("tx").Op("*").Qual("github.com/go-pg/pg/v9", "Tx")).Error().Block(
+			jen.For(jen.List(jen.Id("_"), jen.Id("s")).Op(":=").Range().Id("statements")).Block(
+				jen.List(jen.Id("_"), jen.Err()).Op(":=").Id("tx").Dot("Exec").Call(jen.Id("s")),
+				jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+			),
+			jen.Return(jen.Nil()),
+		))),
+	)
+
+	f.Line()
+	f.Func().Params(jen.Id("c").Op("*").Id("gopgConn")).Id("Versions").Params().Params(jen.Index().String(), jen.Error()).Block(
+		jen.Var().Id("versions").Qual("github.com/go-pg/pg/v9", "Strings"),
+		jen.List(jen.Id("_"), jen.Err()).Op(":=").Id("c").Dot("db").Dot("Query").Call(jen.Op("&").Id("versions"), jen.Lit("SELECT version FROM schema_migrations")),
+		jen.Return(jen.Id("versions"), jen.Err()),
+	)
+}
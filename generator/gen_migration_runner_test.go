@@ -0,0 +1,310 @@
+package generator_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/fs"
+	"github.com/wicaker/cacli/generator"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	expected_migration_runner = `package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// files are the migrations inside this directory, only the sql migrations are run
+//
+//go:embed *
+var files embed.FS
+
+var (
+	fileName = regexp.MustCompile("^([0-9]{14})_([a-z0-9_]+)\\.(up|down)\\.sql$")
+	errUsage = errors.New("usage: migrate up|down [steps]|status")
+)
+
+const createTable = "CREATE TABLE IF NOT EXISTS schema_migrations (version VARCHAR(191) NOT NULL PRIMARY KEY)"
+
+// Migration represent up and down migration of a version
+type Migration struct {
+	Version string
+	Name    string
+	Up      string
+	Down    string
+	Applied bool
+}
+
+// Conn is the database connection which the migrations are run on
+type Conn interface {
+	// Exec run the statements in a transaction
+	Exec(statements []string) error
+	// Versions return the migrations which were applied
+	Versions() ([]string, error)
+}
+
+// Run will run subcommand of migrate, which is one of: up, down [steps] or status
+func Run(conn Conn, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	err := conn.Exec([]string{createTable})
+	if err != nil {
+		return err
+	}
+
+	migrations, err := Load(conn)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(args) == 1 && args[0] == "up":
+		for _, m := range migrations {
+			if m.Applied {
+				continue
+			}
+			err = conn.Exec(append(statements(m.Up), fmt.Sprintf("INSERT INTO schema_migrations (version) VALUES ('%s')", m.Version+"_"+m.Name)))
+			if err != nil {
+				return fmt.Errorf("migrate up %s: %s", m.Version+"_"+m.Name, err)
+			}
+			fmt.Println("applied", m.Version+"_"+m.Name)
+		}
+		return nil
+	case len(args) <= 2 && args[0] == "down":
+		steps := 1
+		if len(args) == 2 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return errors.New("steps of migrate down must be a positive number")
+			}
+		}
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			m := migrations[i]
+			if !m.Applied {
+				continue
+			}
+			err = conn.Exec(append(statements(m.Down), fmt.Sprintf("DELETE FROM schema_migrations WHERE version = '%s'", m.Version+"_"+m.Name)))
+			if err != nil {
+				return fmt.Errorf("migrate down %s: %s", m.Version+"_"+m.Name, err)
+			}
+			fmt.Println("reverted", m.Version+"_"+m.Name)
+			steps--
+		}
+		return nil
+	case len(args) == 1 && args[0] == "status":
+		for _, m := range migrations {
+			status := "pending"
+			if m.Applied {
+				status = "applied"
+			}
+			fmt.Printf("%-8s %s\n", status, m.Version+"_"+m.Name)
+		}
+		return nil
+	}
+	return errUsage
+}
+
+// Load will read the embedded migrations in order of version, then mark the applied ones
+func Load(conn Conn) ([]*Migration, error) {
+	entries, err := files.ReadDir(".")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		migrations []*Migration
+		byID       = map[string]*Migration{}
+	)
+	for _, e := range entries {
+		match := fileName.FindStringSubmatch(e.Name())
+		if match == nil {
+			continue
+		}
+
+		data, err := files.ReadFile(e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byID[match[1]+"_"+match[2]]
+		if !ok {
+			m = &Migration{
+				Name:    match[2],
+				Version: match[1],
+			}
+			byID[m.Version+"_"+m.Name] = m
+			migrations = append(migrations, m)
+		}
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version+"_"+migrations[i].Name < migrations[j].Version+"_"+migrations[j].Name
+	})
+
+	versions, err := conn.Versions()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if m, ok := byID[v]; ok {
+			m.Applied = true
+		}
+	}
+
+	return migrations, nil
+}
+
+// statements will split the migration, every statement is ended by semicolon at the end of line
+func statements(migration string) []string {
+	var (
+		result    []string
+		statement strings.Builder
+	)
+	for _, line := range strings.Split(migration, "\n") {
+		statement.WriteString(line + "\n")
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			result = append(result, strings.TrimSpace(statement.String()))
+			statement.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(statement.String()); rest != "" {
+		result = append(result, rest)
+	}
+	return result
+}
+
+type sqlConn struct {
+	db *sql.DB
+}
+
+// NewSQLConn will create new a sqlConn object representation of Conn interface
+func NewSQLConn(db *sql.DB) Conn {
+	return &sqlConn{db: db}
+}
+
+func (c *sqlConn) Exec(statements []string) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range statements {
+		_, err = tx.Exec(s)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (c *sqlConn) Versions() ([]string, error) {
+	rows, err := c.db.Query("SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+`
+)
+
+func TestGenerateMigrationRunner(t *testing.T) {
+	var (
+		serviceName = "testmigrationrunner"
+		newFs       = fs.NewFsService()
+	)
+
+	t.Run("success, should generate a migrations.go file", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate migrations.go file
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenMigrationRunner(serviceName, domain.SQL)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(serviceName + "/migrations.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected_migration_runner, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, should run the migrations on connection of go-pg", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate migrations.go file
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenMigrationRunner(serviceName, domain.GoPg)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(serviceName + "/migrations.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "func NewGopgConn(db *pg.DB) Conn {")
+		assert.NotContains(t, string(data), "database/sql")
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("failed, because migration of mongo is not supported", func(t *testing.T) {
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenMigrationRunner(serviceName, domain.Mongod)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenMigrationRunner(serviceName, domain.SQL)
+
+		assert.Error(t, err)
+	})
+}
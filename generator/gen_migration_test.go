@@ -0,0 +1,177 @@
+package generator_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/fs"
+	"github.com/wicaker/cacli/generator"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	expected_migration_create_postgres_up = `CREATE TABLE examples (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP NULL
+);
+`
+
+	expected_migration_create_postgres_down = `DROP TABLE examples;
+`
+
+	expected_migration_create_mysql_up = `CREATE TABLE examples (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    deleted_at DATETIME NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+`
+
+	expected_migration_create_mysql_down = `DROP TABLE examples;
+`
+
+	expected_migration_create_fizz_up = `create_table("examples") {
+	t.Column("id", "bigint", {primary: true})
+	t.Column("name", "string", {})
+	t.Column("created_at", "timestamp", {})
+	t.Column("updated_at", "timestamp", {})
+	t.Column("deleted_at", "timestamp", {"null": true})
+}
+`
+
+	expected_migration_create_fizz_down = `drop_table("examples")
+`
+
+	expected_migration_alter_postgres_up = `ALTER TABLE examples ALTER COLUMN name SET NOT NULL;
+ALTER TABLE examples ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE examples DROP COLUMN title;
+`
+
+	expected_migration_alter_postgres_down = `ALTER TABLE examples ADD COLUMN title VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE examples DROP COLUMN updated_at;
+ALTER TABLE examples ALTER COLUMN name DROP NOT NULL;
+`
+
+	expected_migration_alter_mysql_up = `ALTER TABLE examples MODIFY COLUMN name VARCHAR(255) NOT NULL;
+ALTER TABLE examples ADD COLUMN updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE examples DROP COLUMN title;
+`
+
+	expected_migration_alter_mysql_down = `ALTER TABLE examples ADD COLUMN title VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE examples DROP COLUMN updated_at;
+ALTER TABLE examples MODIFY COLUMN name VARCHAR(255) NULL;
+`
+
+	expected_migration_alter_fizz_up = `change_column("examples", "name", "string", {})
+add_column("examples", "updated_at", "timestamp", {})
+drop_column("examples", "title")
+`
+
+	expected_migration_alter_fizz_down = `add_column("examples", "title", "string", {})
+drop_column("examples", "updated_at")
+change_column("examples", "name", "string", {"null": true})
+`
+)
+
+func TestGenerateMigration(t *testing.T) {
+	var (
+		serviceName = "testmigration"
+		dirName     = serviceName + "/migrations"
+		version     = "20191229073809"
+		newFs       = fs.NewFsService()
+		previous    = &domain.Table{Domain: "example", Name: "examples", Columns: []domain.Column{
+			domain.Column{Name: "id", Type: "uint64", Primary: true},
+			domain.Column{Name: "name", Type: "*string"},
+			domain.Column{Name: "title", Type: "string"},
+			domain.Column{Name: "created_at", Type: "time.Time"},
+			domain.Column{Name: "deleted_at", Type: "*time.Time"},
+		}}
+		tests = []struct {
+			name     string
+			format   string
+			dialect  string
+			previous *domain.Table
+			file     string
+			up       string
+			down     string
+		}{
+			{"create table of postgres", domain.SQLMigration, domain.Postgres, nil, "create_examples", expected_migration_create_postgres_up, expected_migration_create_postgres_down},
+			{"create table of mysql", domain.SQLMigration, domain.MySQL, nil, "create_examples", expected_migration_create_mysql_up, expected_migration_create_mysql_down},
+			{"create table of fizz", domain.FizzMigration, "", nil, "create_examples", expected_migration_create_fizz_up, expected_migration_create_fizz_down},
+			{"alter table of postgres", domain.SQLMigration, domain.Postgres, previous, "alter_examples", expected_migration_alter_postgres_up, expected_migration_alter_postgres_down},
+			{"alter table of mysql", domain.SQLMigration, domain.MySQL, previous, "alter_examples", expected_migration_alter_mysql_up, expected_migration_alter_mysql_down},
+			{"alter table of fizz", domain.FizzMigration, "", previous, "alter_examples", expected_migration_alter_fizz_up, expected_migration_alter_fizz_down},
+		}
+	)
+
+	table, err := generator.MigrationTable("example", domain.Sqlx, domain.MockParser.Entity)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	for _, tc := range tests {
+		t.Run("success, should generate migration which "+tc.name, func(t *testing.T) {
+			// create directory of service
+			err := newFs.CreateDir(serviceName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			err = newFs.CreateDir(dirName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			// generate up and down migration
+			gen := generator.NewGeneratorService(newFs)
+			err = gen.GenMigration(dirName, version, tc.format, tc.dialect, tc.previous, table)
+			assert.NoError(t, err)
+
+			up, err := ioutil.ReadFile(dirName + "/" + version + "_" + tc.file + ".up." + tc.format)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.up, string(up))
+
+			down, err := ioutil.ReadFile(dirName + "/" + version + "_" + tc.file + ".down." + tc.format)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.down, string(down))
+
+			// remove directory of service
+			err = newFs.RemoveDir(serviceName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+		})
+	}
+
+	t.Run("failed, because the entity has no change", func(t *testing.T) {
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenMigration(dirName, version, domain.SQLMigration, domain.Postgres, table, table)
+
+		assert.Equal(t, domain.ErrNoMigration, err)
+	})
+
+	t.Run("failed, because migration of mongo is not supported", func(t *testing.T) {
+		_, err := generator.MigrationTable("example", domain.Mongod, domain.MockParser.Entity)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenMigration(dirName, version, domain.SQLMigration, domain.Postgres, nil, table)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wicaker/cacli/domain"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// GenMock will generate testify mock of usecase and repository interface of the domain,
+// each of them is written into its own file inside dirName, e.g. domain/mocks/ExampleUsecase.go
+func (gen *caGen) GenMock(dirName string, gomodName string, parser *domain.Parser) error {
+	imports := parserImports(gomodName, parser)
+
+	err := gen.genMockInterface(dirName, parser.Usecase.Name, parser.Usecase.Method, imports)
+	if err != nil {
+		return err
+	}
+
+	return gen.genMockInterface(dirName, parser.Repository.Name, parser.Repository.Method, imports)
+}
+
+// genMockInterface will generate the mock type of a single interface in mockery style
+func (gen *caGen) genMockInterface(dirName string, name string, methods []domain.Method, imports map[string]string) error {
+	if name == "" {
+		return nil
+	}
+
+	f := jen.NewFile("mocks")
+	f.HeaderComment("Code generated by cacli. DO NOT EDIT.")
+	f.ImportName("github.com/stretchr/testify/mock", "mock")
+	for pkg, importPath := range imports {
+		f.ImportName(importPath, pkg)
+	}
+
+	f.Comment(fmt.Sprintf("%s is an autogenerated mock type for the %s type", name, name))
+	f.Type().Id(name).Struct(
+		jen.Qual("github.com/stretchr/testify/mock", "Mock"),
+	)
+
+	for _, m := range methods {
+		var (
+			params     []jen.Code
+			paramTypes []jen.Code
+			args       []jen.Code
+			calledArgs []jen.Code
+			argNames   []string
+			results    []jen.Code
+			body       []jen.Code
+			returns    []jen.Code
+		)
+
+		for idx, p := range m.ParameterList {
+			argName := p.Name
+			if argName == "" || argName == "_" {
+				argName = fmt.Sprintf("_a%d", idx)
+			}
+			typ, err := genTypeCode(p.Type, imports)
+			if err != nil {
+				return err
+			}
+			params = append(params, jen.Id(argName).Add(typ))
+			paramTypes = append(paramTypes, typ.Clone())
+			argNames = append(argNames, argName)
+			if strings.HasPrefix(p.Type, "...") {
+				args = append(args, jen.Id(argName).Op("..."))
+				continue
+			}
+			args = append(args, jen.Id(argName))
+		}
+
+		// like mockery, every element of variadic parameter is passed into Called as its own argument
+		calledArgs = args
+		if len(m.ParameterList) > 0 && strings.HasPrefix(m.ParameterList[len(m.ParameterList)-1].Type, "...") {
+			variadic := argNames[len(argNames)-1]
+			body = append(body,
+				jen.Id("_va").Op(":=").Make(jen.Index().Interface(), jen.Len(jen.Id(variadic))),
+				jen.For(jen.Id("_i").Op(":=").Range().Id(variadic)).Block(
+					jen.Id("_va").Index(jen.Id("_i")).Op("=").Id(variadic).Index(jen.Id("_i")),
+				),
+				jen.Var().Id("_ca").Index().Interface(),
+			)
+			for _, a := range argNames[:len(argNames)-1] {
+				body = append(body, jen.Id("_ca").Op("=").Append(jen.Id("_ca"), jen.Id(a)))
+			}
+			body = append(body, jen.Id("_ca").Op("=").Append(jen.Id("_ca"), jen.Id("_va").Op("...")))
+			calledArgs = []jen.Code{jen.Id("_ca").Op("...")}
+		}
+
+		for _, r := range m.ResultList {
+			typ, err := genTypeCode(r.Type, imports)
+			if err != nil {
+				return err
+			}
+			results = append(results, typ)
+		}
+
+		if len(results) == 0 {
+			body = append(body, jen.Id("_m").Dot("Called").Call(calledArgs...))
+		} else {
+			body = append(body, jen.Id("ret").Op(":=").Id("_m").Dot("Called").Call(calledArgs...), jen.Line())
+		}
+
+		for idx, r := range m.ResultList {
+			var (
+				rN   = fmt.Sprintf("r%d", idx)
+				get  = jen.Id("ret").Dot("Get").Call(jen.Lit(idx))
+				typ  = results[idx].(*jen.Statement)
+				orig jen.Code
+			)
+
+			if r.Type == "error" {
+				orig = jen.Id(rN).Op("=").Id("ret").Dot("Error").Call(jen.Lit(idx))
+			} else if isBasicType(r.Type) {
+				orig = jen.Id(rN).Op("=").Add(get.Clone()).Assert(typ.Clone())
+			} else {
+				orig = jen.If(get.Clone().Op("!=").Nil()).Block(
+					jen.Id(rN).Op("=").Add(get.Clone()).Assert(typ.Clone()),
+				)
+			}
+
+			body = append(body,
+				jen.Var().Id(rN).Add(typ.Clone()),
+				jen.If(
+					jen.List(jen.Id("rf"), jen.Id("ok")).Op(":=").Add(get.Clone()).Assert(jen.Func().Params(paramTypes...).Add(typ.Clone())),
+					jen.Id("ok"),
+				).Block(
+					jen.Id(rN).Op("=").Id("rf").Call(args...),
+				).Else().Block(orig),
+				jen.Line(),
+			)
+			returns = append(returns, jen.Id(rN))
+		}
+
+		if len(returns) > 0 {
+			body = append(body, jen.Return(returns...))
+		}
+
+		f.Line()
+		f.Comment(fmt.Sprintf("%s provides a mock function with given fields: %s", m.Name, strings.Join(argNames, ", ")))
+		f.Func().Params(jen.Id("_m").Op("*").Id(name)).Id(m.Name).Params(params...).Call(results...).Block(body...)
+	}
+
+	return gen.save(f, fmt.Sprintf("%s/%s.go", dirName, name))
+}
+
+// isBasicType will check whether typ is a predeclared type which never be nil
+func isBasicType(typ string) bool {
+	switch typ {
+	case "string", "bool", "byte", "rune",
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"float32", "float64", "complex64", "complex128":
+		return true
+	}
+	return false
+}
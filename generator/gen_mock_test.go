@@ -0,0 +1,275 @@
+package generator_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/fs"
+	"github.com/wicaker/cacli/generator"
+)
+
+const (
+	expected_example_usecase_mock = `// Code generated by cacli. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"github.com/example/examplemock/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// ExampleUsecase is an autogenerated mock type for the ExampleUsecase type
+type ExampleUsecase struct {
+	mock.Mock
+}
+
+// Fetch provides a mock function with given fields: ctx
+func (_m *ExampleUsecase) Fetch(ctx context.Context) ([]*domain.Example, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*domain.Example
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Example); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Example)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *ExampleUsecase) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Example
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *domain.Example); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Example)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, exp
+func (_m *ExampleUsecase) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	ret := _m.Called(ctx, exp)
+
+	var r0 *domain.Example
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Example) *domain.Example); ok {
+		r0 = rf(ctx, exp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Example)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Example) error); ok {
+		r1 = rf(ctx, exp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, exp
+func (_m *ExampleUsecase) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	ret := _m.Called(ctx, exp)
+
+	var r0 *domain.Example
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Example) *domain.Example); ok {
+		r0 = rf(ctx, exp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Example)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Example) error); ok {
+		r1 = rf(ctx, exp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *ExampleUsecase) Delete(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+`
+)
+
+func TestGenerateMock(t *testing.T) {
+	var (
+		serviceName = "testmock"
+		gomodName   = "github.com/example/examplemock"
+		newFs       = fs.NewFsService()
+	)
+
+	t.Run("success, should generate mock of usecase and repository interface", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate mock files
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenMock(serviceName, gomodName, domain.MockParser)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(serviceName + "/ExampleUsecase.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected_example_usecase_mock, string(data))
+
+		data, err = ioutil.ReadFile(serviceName + "/ExampleRepository.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "type ExampleRepository struct {")
+		assert.Contains(t, string(data), "func (_m *ExampleRepository) Delete(ctx context.Context, id uint64) error {")
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, should forward variadic parameter and accept func and chan parameter", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate mock files of domain which has variadic, func and chan parameter
+		par := &domain.Parser{Usecase: domain.Usecase{
+			Name: "ExampleUsecase",
+			Method: []domain.Method{
+				domain.Method{
+					Name: "Tag",
+					ParameterList: []domain.MethodValue{
+						domain.MethodValue{Name: "ctx", Type: "context.Context"},
+						domain.MethodValue{Name: "tags", Type: "...string"},
+					},
+					ResultList: []domain.MethodValue{domain.MethodValue{Type: "error"}},
+				},
+				domain.Method{
+					Name: "Watch",
+					ParameterList: []domain.MethodValue{
+						domain.MethodValue{Name: "fn", Type: "func(*domain.Example) error"},
+						domain.MethodValue{Name: "done", Type: "<-chan struct{}"},
+					},
+				},
+			},
+		}}
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenMock(serviceName, gomodName, par)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(serviceName + "/ExampleUsecase.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `func (_m *ExampleUsecase) Tag(ctx context.Context, tags ...string) error {
+	_va := make([]interface{}, len(tags))
+	for _i := range tags {
+		_va[_i] = tags[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, tags...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}`)
+		assert.Contains(t, string(data), `func (_m *ExampleUsecase) Watch(fn func(*domain.Example) error, done <-chan struct{}) {
+	_m.Called(fn, done)
+}`)
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, should skip interface which is not declared", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate mock files of domain without repository
+		par := &domain.Parser{Usecase: domain.MockParser.Usecase}
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenMock(serviceName, gomodName, par)
+		assert.NoError(t, err)
+
+		_, err = ioutil.ReadFile(serviceName + "/ExampleUsecase.go")
+		assert.NoError(t, err)
+		_, err = ioutil.ReadFile(serviceName + "/ExampleRepository.go")
+		assert.Error(t, err)
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenMock(serviceName, gomodName, domain.MockParser)
+
+		assert.Error(t, err)
+	})
+}
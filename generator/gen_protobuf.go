@@ -1,7 +1,7 @@
 package generator
 
 import (
-	"io/ioutil"
+	"fmt"
 	"path"
 	"path/filepath"
 	"strings"
@@ -14,16 +14,36 @@ func (gen *caGen) GenProtobuf(dirName string, domainFile string, gomodName strin
 		file            = path.Base(domainFile)
 		domainName      = strings.TrimSuffix(file, filepath.Ext(file))
 		domainNameInCap = strings.ToUpper(string(domainName[0])) + domainName[1:]
+		protoEntity     = protoMessage(domainNameInCap, protoEntityFields(parser, domainNameInCap))
 		protoUsecase    = ``
 		protoService    = ``
 	)
-	for _, i := range parser.Usecase.Method {
-		protoUsecase += `message ` + i.Name + domainNameInCap + `Req{}`
-		protoUsecase += `
+	// entity without field keeps the default fields
+	if len(protoEntityFields(parser, domainNameInCap)) == 0 {
+		protoEntity = `message ` + domainNameInCap + ` {
+	uint64 id = 1;
+	google.protobuf.Timestamp createdAt = 2;
+	google.protobuf.Timestamp updatedAt = 3;
+}
 `
-		protoUsecase += `message ` + i.Name + domainNameInCap + `Resp{}`
-		protoUsecase += `
+	}
+
+	for _, i := range parser.Usecase.Method {
+		var req, resp []protoField
+		for _, p := range protoParams(parser, domainNameInCap, i) {
+			if p.supported {
+				req = append(req, p.protoField)
+			}
+		}
+		for _, r := range protoResults(parser, domainNameInCap, i) {
+			if r.supported {
+				resp = append(resp, r.protoField)
+			}
+		}
 
+		protoUsecase += protoMessage(i.Name+domainNameInCap+`Req`, req)
+		protoUsecase += protoMessage(i.Name+domainNameInCap+`Resp`, resp)
+		protoUsecase += `
 `
 		protoService += `
 `
@@ -36,19 +56,157 @@ package proto;
 
 import "google/protobuf/timestamp.proto";
 
-message ` + domainNameInCap + ` {
-	uint64 id = 1;
-	google.protobuf.Timestamp createdAt = 2;
-	google.protobuf.Timestamp updatedAt = 3;
-}
-
-` + protoUsecase + `service ExampleService {` + protoService + `
+` + protoEntity + `
+` + protoUsecase + `service ` + domainNameInCap + `Service {` + protoService + `
 }
 `)
 
-	err := ioutil.WriteFile("./"+dirName+"/"+domainName+".proto", genProtobuf, 0644)
+	err := gen.fs.WriteFile(dirName+"/"+domainName+".proto", genProtobuf)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// protoField represent a field of protobuf message, name is written in snake case
+type protoField struct {
+	name string
+	typ  string
+}
+
+// protoValue represent a parameter or a result of usecase method which is carried by protobuf message,
+// it is not carried if its type is not representable in protobuf
+type protoValue struct {
+	protoField
+	goName    string
+	goType    string
+	variadic  bool
+	supported bool
+}
+
+// protoMessage will write the protobuf message, fields are numbered as they are ordered
+func protoMessage(name string, fields []protoField) string {
+	if len(fields) == 0 {
+		return `message ` + name + ` {}
+`
+	}
+	message := `message ` + name + ` {
+`
+	for idx, f := range fields {
+		message += fmt.Sprintf("\t%s %s = %d;\n", f.typ, f.name, idx+1)
+	}
+	return message + `}
+`
+}
+
+// protoType will return the type of go type in protobuf, entity of domain is written as its message.
+// False is returned if the type is not representable in protobuf
+func protoType(typ string, parser *domain.Parser, message string) (string, bool) {
+	if strings.HasPrefix(typ, "...") {
+		typ = "[]" + typ[3:]
+	}
+	if strings.HasPrefix(typ, "[]") && typ != "[]byte" {
+		elem, ok := protoType(typ[2:], parser, message)
+		if !ok || strings.HasPrefix(elem, "repeated ") {
+			return "", false
+		}
+		return "repeated " + elem, true
+	}
+
+	switch typ {
+	case "domain." + parser.Entity.Name, "*domain." + parser.Entity.Name:
+		return message, true
+	case "time.Time", "*time.Time":
+		return "google.protobuf.Timestamp", true
+	case "[]byte":
+		return "bytes", true
+	case "string", "bool":
+		return typ, true
+	case "int", "int64":
+		return "int64", true
+	case "int8", "int16", "int32", "rune":
+		return "int32", true
+	case "uint", "uint64", "uintptr":
+		return "uint64", true
+	case "uint8", "byte", "uint16", "uint32":
+		return "uint32", true
+	case "float64":
+		return "double", true
+	case "float32":
+		return "float", true
+	}
+	return "", false
+}
+
+// protoEntityFields will return the fields of entity which are representable in protobuf
+func protoEntityFields(parser *domain.Parser, message string) []protoField {
+	var fields []protoField
+	for _, f := range parser.Entity.Fields {
+		typ, ok := protoType(f.Type, parser, message)
+		if !ok || f.Embedded {
+			continue
+		}
+		fields = append(fields, protoField{name: toSnakeCase(f.Name), typ: typ})
+	}
+	return fields
+}
+
+// protoParams will return the parameters of usecase method except context, they are carried by request message
+func protoParams(parser *domain.Parser, message string, m domain.Method) []protoValue {
+	var params []protoValue
+	for idx, p := range m.ParameterList {
+		if p.Type == "context.Context" {
+			continue
+		}
+		param := protoValue{goName: p.Name, goType: p.Type}
+		if param.goName == "" {
+			param.goName = fmt.Sprintf("param%d", idx)
+		}
+		if strings.HasPrefix(param.goType, "...") {
+			param.goType = "[]" + param.goType[3:]
+			param.variadic = true
+		}
+		param.name = toSnakeCase(param.goName)
+		param.typ, param.supported = protoType(param.goType, parser, message)
+		params = append(params, param)
+	}
+	return params
+}
+
+// protoResults will return the results of usecase method except error, they are carried by response message
+// as data, or as data0, data1 and so on if the method returns several values
+func protoResults(parser *domain.Parser, message string, m domain.Method) []protoValue {
+	var results []protoValue
+	values := valueResults(m)
+	for idx, r := range values {
+		result := protoValue{goName: "res", goType: r.Type}
+		result.name = "data"
+		if len(values) > 1 {
+			result.goName = fmt.Sprintf("res%d", idx)
+			result.name = fmt.Sprintf("data%d", idx)
+		}
+		result.typ, result.supported = protoType(result.goType, parser, message)
+		results = append(results, result)
+	}
+	return results
+}
+
+// protoGoName will return the name of protobuf field in go code which is generated by protoc-gen-go,
+// e.g. user_id become UserId
+func protoGoName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
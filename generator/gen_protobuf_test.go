@@ -22,24 +22,42 @@ import "google/protobuf/timestamp.proto";
 
 message Example {
 	uint64 id = 1;
-	google.protobuf.Timestamp createdAt = 2;
-	google.protobuf.Timestamp updatedAt = 3;
+	string name = 2;
+	google.protobuf.Timestamp created_at = 3;
+	google.protobuf.Timestamp updated_at = 4;
+	google.protobuf.Timestamp deleted_at = 5;
 }
 
-message FetchExampleReq{}
-message FetchExampleResp{}
+message FetchExampleReq {}
+message FetchExampleResp {
+	repeated Example data = 1;
+}
 
-message GetByIDExampleReq{}
-message GetByIDExampleResp{}
+message GetByIDExampleReq {
+	uint64 id = 1;
+}
+message GetByIDExampleResp {
+	Example data = 1;
+}
 
-message StoreExampleReq{}
-message StoreExampleResp{}
+message StoreExampleReq {
+	Example exp = 1;
+}
+message StoreExampleResp {
+	Example data = 1;
+}
 
-message UpdateExampleReq{}
-message UpdateExampleResp{}
+message UpdateExampleReq {
+	Example exp = 1;
+}
+message UpdateExampleResp {
+	Example data = 1;
+}
 
-message DeleteExampleReq{}
-message DeleteExampleResp{}
+message DeleteExampleReq {
+	uint64 id = 1;
+}
+message DeleteExampleResp {}
 
 service ExampleService {
 	rpc FetchExample(FetchExampleReq) returns (FetchExampleResp);
@@ -76,7 +94,7 @@ func TestGenerateProtobuf(t *testing.T) {
 		}
 
 		// generate example.proto file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenProtobuf(dirName, domainFile, gomodName, domain.MockParser)
 		resGopg, err := newFs.FindFile(dirName + "/example.proto")
 		assert.NoError(t, err)
@@ -105,7 +123,7 @@ func TestGenerateProtobuf(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example.proto file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenProtobuf(dirName, domainFile, gomodName, domain.MockParser)
 
 		assert.Error(t, err)
@@ -1,26 +1,62 @@
 package generator
 
-import (
-	"io/ioutil"
-)
+import "github.com/wicaker/cacli/domain"
+
+func (gen *caGen) GenReadme(dirName string, repoLib string) error {
+	problem := ""
+	if gen.gen.ProblemJSON {
+		problem = `- ` + "error of rest is answered as `application/problem+json` of RFC 7807 by `domain.WriteProblem`, code and details of error are written as `code` and `invalid-params` extension, and panic of handler is recovered into the same format by `Recover` middleware" + `
+`
+	}
+
+	repositoryTest := `- ` + "tests of repository run without a database, against sqlmock of [go-sqlmock](https://github.com/DATA-DOG/go-sqlmock)" + `
+`
+	switch repoLib {
+	case domain.Mongod:
+		repositoryTest = `- ` + "tests of repository run without a database, against the mock deployment of `mtest` in mongo-driver" + `
+`
+	case domain.GoPg:
+		repositoryTest = `- ` + "tests of repository run without a database, against `gopgDB` in `repository/gopg_db_test.go`, a stand-in of `orm.DB` which replies the queued rows to the queries built by go-pg" + `
+`
+	}
 
-func (gen *caGen) GenReadme(dirName string) error {
 	readme := []byte(`# README
 ## Go Clean Architecture
 
 ## Database
-- ` + "`soda g config` to create `database.yml` configuration" + `
-- ` + "`soda create -e development` (to create database development) [more](https://gobuffalo.io/en/docs/db/toolbox/)" + `
-- ` + "`soda generate -p ./database/migrations fizz name_of_migration` to create new migrations." + `
-- ` + "`soda drop -e development` (to drop or delete database) [more](https://gobuffalo.io/en/docs/db/toolbox/)" + `
-- ` + "migration up : `soda migrate -p database up` [more](https://gobuffalo.io/en/docs/db/migrations/)" + `
-- ` + "migration down : `soda migrate -p database down -s {number of database want to down}`. For example: `soda migrate -p database down -s 9`" + `
+- ` + "`cacli generate migration {domain}` to create migration of the domain entity, it creates the table at first and alters it based on the changed fields after that." + `
+- ` + "migration up : `go run . migrate up`, the migrations are embedded, so the binary of service is able to run them, e.g. `./yourappname migrate up`" + `
+- ` + "migration down : `go run . migrate down {number of migration want to down}`. For example: `go run . migrate down 2`" + `
+- ` + "migration status : `go run . migrate status`" + `
+- ` + "migration which generated with `--format fizz` is run by soda : `soda migrate -p database up` [more](https://gobuffalo.io/en/docs/db/migrations/)" + `
+
+## Errors
+- ` + "usecase and repository report failures with `domain.Error`, which carries a code, a message, the wrapped cause and details, e.g. `domain.ErrNotFound.Wrap(err)` or `domain.NewError(domain.CodeConflict, \"title already exist\")`, and it is checked with `errors.Is(err, domain.ErrNotFound)`" + `
+- ` + "every transport maps the code of error into its own status: `domain.GetStatusCode` for rest, `domain.GetGraphqlError` which writes `code` and `details` as extensions of graphql error, and `grpcError` in `transport/grpc` which carries details as `errdetails.BadRequest`" + `
+- ` + "error which is not `domain.Error` is answered as `domain.ErrInternalServerError`, the cause is only logged and never shown to the client" + `
+` + problem + `
+## Rest
+- ` + "conventional methods of usecase are served restfully, e.g. `GET /examples`, `GET /examples/:id`, `POST /examples`, `PUT /examples/:id` and `DELETE /examples/:id`, other methods are served as `POST /examples/{method}`" + `
+- ` + "route of a method is overridden by annotating the method of usecase interface, e.g. `// @route PUT /examples/:id/done`" + `
+- ` + "parameter of usecase is bound from the path parameter of the same name, else from query for basic types, and the others are decoded from json body" + `
+- ` + "body of domain type is validated by `validate` tags of [go-playground/validator](https://github.com/go-playground/validator), invalid request is answered with status 400 and the reason of every invalid field in `details`" + `
+- ` + "result of usecase is answered as `data` of `domain.ResponseSuccess`, a method which returns several values answers them as a list, and error of usecase is answered as `domain.ResponseError` with the status of `domain.GetStatusCode`" + `
+
+## GraphQL
+- ` + "methods which are served by `GET` in rest are served as queries, the others as mutations, arguments are named as the parameters of usecase and the entity is passed as its input type" + `
+- ` + "a method which returns several values is resolved as an object of `data0`, `data1` and so on" + `
+
+## Test
+- ` + "mocks of usecase and repository interface are generated in `domain/mocks`, they are regenerated by `cacli sync` after the domain was changed, so do not edit them by hand" + `
+` + repositoryTest + `- ` + "tests of rest handler serve the request through `httptest` on top of the mocked usecase" + `
+- ` + "run test : `go test ./...`" + `
 
 ## protobuf
 - protoc --go_out=plugins=grpc:. proto/*.proto
+- ` + "request message carries the parameters of usecase and response message carries its results as `data`, parameter or result which is not representable in protobuf is left out and passed as zero value" + `
 `)
 
-	err := ioutil.WriteFile("./"+dirName+"/README.md", readme, 0644)
+	err := gen.fs.WriteFile(dirName+"/README.md", readme)
 	if err != nil {
 		return err
 	}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/wicaker/cacli/domain"
 	"github.com/wicaker/cacli/fs"
 	"github.com/wicaker/cacli/generator"
 
@@ -24,8 +25,8 @@ func TestGenerateReadmeFile(t *testing.T) {
 		}
 
 		// generate README.md file
-		gen := generator.NewGeneratorService()
-		err = gen.GenReadme(serviceName)
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenReadme(serviceName, domain.Sqlx)
 		resReadme, err := newFs.FindFile(serviceName + "/README.md")
 
 		assert.NoError(t, err)
@@ -39,10 +40,25 @@ func TestGenerateReadmeFile(t *testing.T) {
 		}
 	})
 
+	t.Run("success, should document the stand-in of database which is used by test of go-pg repository", func(t *testing.T) {
+		err := newFs.CreateDir(serviceName)
+		assert.NoError(t, err)
+		defer newFs.RemoveDir(serviceName)
+
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenReadme(serviceName, domain.GoPg)
+		assert.NoError(t, err)
+
+		data, err := newFs.ReadFile(serviceName + "/README.md")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "tests of repository run without a database, against `gopgDB`")
+		assert.NotContains(t, string(data), "sqlmock")
+	})
+
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate README.md file
-		gen := generator.NewGeneratorService()
-		err := gen.GenReadme(serviceName)
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenReadme(serviceName, domain.Sqlx)
 
 		assert.Error(t, err)
 	})
@@ -18,18 +18,22 @@ func (gen *caGen) GenGopgRepository(dirName string, domainFile string, gomodName
 		newR       = fmt.Sprintf("NewGopg%s", repository)
 		comment    = fmt.Sprintf("NewGopg%s will create new an gopg%s object representation of domain.%s interface", repository, repository, repository)
 		f          = jen.NewFile("repository")
+		entity     = newSQLEntity(parser.Entity, gopgColumnName)
+		recv       = string(domainName[0]) + "r"
 	)
 
 	f.ImportAlias("github.com/go-pg/pg/v9", "pg")
+	f.ImportName("github.com/go-pg/pg/v9/orm", "orm")
 	f.ImportName(gomodName+"/domain", "domain")
 
+	// orm.DB is satisfied by *pg.DB and *pg.Tx, so the repository is able to be tested on top of a stand-in
 	f.Type().Id("gopg" + repository).Struct(
-		jen.Id("Conn").Op("*").Qual("github.com/go-pg/pg/v9", "DB"),
+		jen.Id("Conn").Qual("github.com/go-pg/pg/v9/orm", "DB"),
 	)
 
 	f.Comment(comment)
 	f.Func().Id(newR).Params(
-		jen.Id("Conn").Op("*").Qual("github.com/go-pg/pg/v9", "DB"),
+		jen.Id("Conn").Qual("github.com/go-pg/pg/v9/orm", "DB"),
 	).Qual(gomodName+"/domain", repository).Block(
 		jen.Return(jen.Op("&").Id("gopg" + repository).Values(jen.Dict{
 			jen.Id("Conn"): jen.Id("Conn"),
@@ -38,25 +42,34 @@ func (gen *caGen) GenGopgRepository(dirName string, domainFile string, gomodName
 
 	for _, i := range parser.Repository.Method {
 		var (
-			param            = genParamList(i)
-			returnT, returnV = genReturnList(i)
+			param            = genParamList(i, parserImports(gomodName, parser))
+			returnT, returnV = genReturnList(i, parserImports(gomodName, parser))
+			body             = []jen.Code{
+				jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
+			}
 		)
+
+		// conventional methods are implemented, the others are left as stub
+		if op, ok := getCrudOperation(i, entity, gomodName); ok {
+			body = append(body, jen.Line())
+			body = append(body, genGopgCrud(op, entity, recv)...)
+		} else {
+			body = append(body, genCrudStub(i, entity, returnV)...)
+		}
+
 		f.Line()
 		f.Func().
-			Params(jen.Id(string(domainName[0])+"r").Op("*").Id("gopg"+repository)).
-			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(
-			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-			jen.Return(returnV[:]...),
-		)
+			Params(jen.Id(recv).Op("*").Id("gopg" + repository)).
+			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(body...)
 	}
 
 	fileDir := fmt.Sprintf("%s/%s_repository.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return gen.genRepositoryTest(dirName, domainName, gomodName, domain.GoPg, "", parser, entity)
 }
 
 func (gen *caGen) GenGormRepository(dirName string, domainFile string, gomodName string, parser *domain.Parser) error {
@@ -71,6 +84,8 @@ func (gen *caGen) GenGormRepository(dirName string, domainFile string, gomodName
 			"github.com/jinzhu/gorm": "gorm",
 			gomodName + "/domain":    "domain",
 		}
+		entity = newSQLEntity(parser.Entity, gormColumnName)
+		recv   = string(domainName[0]) + "r"
 	)
 
 	f.ImportNames(importName)
@@ -90,28 +105,216 @@ func (gen *caGen) GenGormRepository(dirName string, domainFile string, gomodName
 
 	for _, i := range parser.Repository.Method {
 		var (
-			param            = genParamList(i)
-			returnT, returnV = genReturnList(i)
+			param            = genParamList(i, parserImports(gomodName, parser))
+			returnT, returnV = genReturnList(i, parserImports(gomodName, parser))
+			body             = []jen.Code{
+				jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
+			}
 		)
+
+		// conventional methods are implemented, the others are left as stub
+		if op, ok := getCrudOperation(i, entity, gomodName); ok {
+			body = append(body, jen.Line())
+			body = append(body, genGormCrud(op, entity, recv)...)
+		} else {
+			body = append(body, genCrudStub(i, entity, returnV)...)
+		}
+
 		f.Line()
 		f.Func().
-			Params(jen.Id(string(domainName[0])+"r").Op("*").Id("gorm"+repository)).
-			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(
-			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-			jen.Return(returnV[:]...),
-		)
+			Params(jen.Id(recv).Op("*").Id("gorm" + repository)).
+			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(body...)
 	}
 
 	fileDir := fmt.Sprintf("%s/%s_repository.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
 
+	return gen.genRepositoryTest(dirName, domainName, gomodName, domain.Gorm, "", parser, entity)
+}
+
+// genGopgCrud will generate body of conventional method using go-pg,
+// soft delete is done by go-pg itself when the entity has pg:",soft_delete" tag
+func genGopgCrud(op *crudOperation, e *sqlEntity, recv string) []jen.Code {
+	var (
+		conn = jen.Id(recv).Dot("Conn")
+		ctx  = jen.Id(op.method.ParameterList[0].Name)
+		arg  = op.arg
+	)
+
+	switch op.kind {
+	case crudFetch:
+		var (
+			conditions []string
+			args       []jen.Code
+			elem       = jen.Qual(op.domain, e.name)
+		)
+		for _, filter := range op.filters {
+			conditions = append(conditions, filter.column+" = ?")
+			args = append(args, jen.Id(filter.arg))
+		}
+		if op.slicePointer {
+			elem = jen.Op("*").Add(elem)
+		}
+
+		query := jen.Add(conn).Dot("ModelContext").Call(ctx, jen.Op("&").Id("result"))
+		if len(conditions) > 0 {
+			query = query.Dot("Where").Call(append([]jen.Code{jen.Lit(strings.Join(conditions, " AND "))}, args...)...)
+		}
+		return []jen.Code{
+			jen.Var().Id("result").Index().Add(elem),
+			jen.Err().Op(":=").Add(query).Dot("Select").Call(),
+			op.ifErr(),
+			jen.Line(),
+			jen.Return(jen.Id("result"), jen.Nil()),
+		}
+
+	case crudGetByID:
+		return []jen.Code{
+			jen.Id(e.varName).Op(":=").Op("&").Qual(op.domain, e.name).Values(jen.Dict{
+				jen.Id(e.primary.field.Name): jen.Id(arg),
+			}),
+			jen.Err().Op(":=").Add(conn).Dot("ModelContext").Call(ctx, jen.Id(e.varName)).Dot("WherePK").Call().Dot("Select").Call(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.If(jen.Err().Op("==").Qual("github.com/go-pg/pg/v9", "ErrNoRows")).Block(
+					jen.Return(jen.Nil(), jen.Qual(op.domain, "ErrNotFound")),
+				),
+				jen.Return(jen.Nil(), jen.Err()),
+			),
+			jen.Line(),
+			jen.Return(jen.Id(e.varName), jen.Nil()),
+		}
+
+	case crudStore:
+		code := stampTime(arg, e.createdAt, e.updatedAt)
+		return append(code,
+			jen.List(jen.Id("_"), jen.Err()).Op(":=").Add(conn).Dot("ModelContext").Call(ctx, jen.Id(arg)).Dot("Insert").Call(),
+			op.ifErr(),
+			jen.Line(),
+			op.returns(jen.Id(arg), jen.Nil()),
+		)
+
+	case crudUpdate:
+		var columns []jen.Code
+		for _, c := range e.updateColumns() {
+			columns = append(columns, jen.Lit(c.name))
+		}
+
+		code := stampTime(arg, e.updatedAt)
+		return append(code,
+			jen.List(jen.Id("res"), jen.Err()).Op(":=").Add(conn).Dot("ModelContext").Call(ctx, jen.Id(arg)).
+				Dot("Column").Call(columns...).Dot("WherePK").Call().Dot("Update").Call(),
+			op.ifErr(),
+			jen.If(jen.Id("res").Dot("RowsAffected").Call().Op("==").Lit(0)).Block(op.returns(jen.Nil(), jen.Qual(op.domain, "ErrNotFound"))),
+			jen.Line(),
+			op.returns(jen.Id(arg), jen.Nil()),
+		)
+
+	case crudDelete:
+		return []jen.Code{
+			jen.Id(e.varName).Op(":=").Op("&").Qual(op.domain, e.name).Values(jen.Dict{
+				jen.Id(e.primary.field.Name): jen.Id(arg),
+			}),
+			jen.List(jen.Id("res"), jen.Err()).Op(":=").Add(conn).Dot("ModelContext").Call(ctx, jen.Id(e.varName)).Dot("WherePK").Call().Dot("Delete").Call(),
+			op.ifErr(),
+			jen.If(jen.Id("res").Dot("RowsAffected").Call().Op("==").Lit(0)).Block(jen.Return(jen.Qual(op.domain, "ErrNotFound"))),
+			jen.Line(),
+			jen.Return(jen.Nil()),
+		}
+	}
 	return nil
 }
 
-func (gen *caGen) GenSQLRepository(dirName string, domainFile string, gomodName string, parser *domain.Parser) error {
+// genGormCrud will generate body of conventional method using gorm,
+// timestamps and soft delete of DeletedAt field are handled by gorm itself
+func genGormCrud(op *crudOperation, e *sqlEntity, recv string) []jen.Code {
+	var (
+		conn = jen.Id(recv).Dot("Conn")
+		arg  = op.arg
+	)
+
+	switch op.kind {
+	case crudFetch:
+		var (
+			conditions []string
+			args       []jen.Code
+			elem       = jen.Qual(op.domain, e.name)
+			query      = conn
+		)
+		for _, filter := range op.filters {
+			conditions = append(conditions, filter.column+" = ?")
+			args = append(args, jen.Id(filter.arg))
+		}
+		if op.slicePointer {
+			elem = jen.Op("*").Add(elem)
+		}
+		if len(conditions) > 0 {
+			query = jen.Add(conn).Dot("Where").Call(append([]jen.Code{jen.Lit(strings.Join(conditions, " AND "))}, args...)...)
+		}
+		return []jen.Code{
+			jen.Var().Id("result").Index().Add(elem),
+			jen.Err().Op(":=").Add(query).Dot("Find").Call(jen.Op("&").Id("result")).Dot("Error"),
+			op.ifErr(),
+			jen.Line(),
+			jen.Return(jen.Id("result"), jen.Nil()),
+		}
+
+	case crudGetByID:
+		return []jen.Code{
+			jen.Id(e.varName).Op(":=").New(jen.Qual(op.domain, e.name)),
+			jen.Err().Op(":=").Add(conn).Dot("Where").Call(jen.Lit(e.primary.name+" = ?"), jen.Id(arg)).Dot("First").Call(jen.Id(e.varName)).Dot("Error"),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.If(jen.Qual("github.com/jinzhu/gorm", "IsRecordNotFoundError").Call(jen.Err())).Block(
+					jen.Return(jen.Nil(), jen.Qual(op.domain, "ErrNotFound")),
+				),
+				jen.Return(jen.Nil(), jen.Err()),
+			),
+			jen.Line(),
+			jen.Return(jen.Id(e.varName), jen.Nil()),
+		}
+
+	case crudStore:
+		return []jen.Code{
+			jen.Err().Op(":=").Add(conn).Dot("Create").Call(jen.Id(arg)).Dot("Error"),
+			op.ifErr(),
+			jen.Line(),
+			op.returns(jen.Id(arg), jen.Nil()),
+		}
+
+	case crudUpdate:
+		// every column is written even if it has zero value, save is not used because it insert the missing row,
+		// primary key is given explicitly so zero id never update every row
+		values := jen.Dict{}
+		for _, c := range e.updateColumns() {
+			if e.updatedAt != nil && c.name == e.updatedAt.name {
+				continue
+			}
+			values[jen.Lit(c.name)] = jen.Id(arg).Dot(c.field.Name)
+		}
+		return []jen.Code{
+			jen.Id("res").Op(":=").Add(conn).Dot("Model").Call(jen.Id(arg)).
+				Dot("Where").Call(jen.Lit(e.primary.name+" = ?"), jen.Id(arg).Dot(e.primary.field.Name)).Dot("Updates").Call(jen.Map(jen.String()).Interface().Values(values)),
+			jen.If(jen.Id("res").Dot("Error").Op("!=").Nil()).Block(op.returns(jen.Nil(), jen.Id("res").Dot("Error"))),
+			jen.If(jen.Id("res").Dot("RowsAffected").Op("==").Lit(0)).Block(op.returns(jen.Nil(), jen.Qual(op.domain, "ErrNotFound"))),
+			jen.Line(),
+			op.returns(jen.Id(arg), jen.Nil()),
+		}
+
+	case crudDelete:
+		return []jen.Code{
+			jen.Id("res").Op(":=").Add(conn).Dot("Where").Call(jen.Lit(e.primary.name+" = ?"), jen.Id(arg)).Dot("Delete").Call(jen.Op("&").Qual(op.domain, e.name).Values()),
+			jen.If(jen.Id("res").Dot("Error").Op("!=").Nil()).Block(jen.Return(jen.Id("res").Dot("Error"))),
+			jen.If(jen.Id("res").Dot("RowsAffected").Op("==").Lit(0)).Block(jen.Return(jen.Qual(op.domain, "ErrNotFound"))),
+			jen.Line(),
+			jen.Return(jen.Nil()),
+		}
+	}
+	return nil
+}
+
+func (gen *caGen) GenSQLRepository(dirName string, domainFile string, gomodName string, dialect string, parser *domain.Parser) error {
 	var (
 		file       = path.Base(domainFile)
 		domainName = strings.TrimSuffix(file, filepath.Ext(file))
@@ -119,8 +322,16 @@ func (gen *caGen) GenSQLRepository(dirName string, domainFile string, gomodName
 		newR       = fmt.Sprintf("NewSQL%s", repository)
 		comment    = fmt.Sprintf("NewSQL%s will create new an sql%s object representation of domain.%s interface", repository, repository, repository)
 		f          = jen.NewFile("repository")
+		entity     = newSQLEntity(parser.Entity, columnName)
+		recv       = string(domainName[0]) + "r"
+		fetch      bool
 	)
 
+	// every column is scanned one by one, so field of embedded struct is unknown
+	if entity != nil && entity.embedded {
+		entity = nil
+	}
+
 	f.ImportName(gomodName+"/domain", "domain")
 
 	f.Type().Id("sql" + repository).Struct(
@@ -138,28 +349,213 @@ func (gen *caGen) GenSQLRepository(dirName string, domainFile string, gomodName
 
 	for _, i := range parser.Repository.Method {
 		var (
-			param            = genParamList(i)
-			returnT, returnV = genReturnList(i)
+			param            = genParamList(i, parserImports(gomodName, parser))
+			returnT, returnV = genReturnList(i, parserImports(gomodName, parser))
+			body             = []jen.Code{
+				jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
+			}
 		)
+
+		// conventional methods are implemented, the others are left as stub
+		if op, ok := getCrudOperation(i, entity, gomodName); ok {
+			body = append(body, jen.Line())
+			body = append(body, genSQLCrud(op, entity, dialect, recv)...)
+			fetch = fetch || op.kind == crudFetch || op.kind == crudGetByID
+		} else {
+			body = append(body, genCrudStub(i, entity, returnV)...)
+		}
+
 		f.Line()
 		f.Func().
-			Params(jen.Id(string(domainName[0])+"r").Op("*").Id("sql"+repository)).
-			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(
-			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-			jen.Return(returnV[:]...),
-		)
+			Params(jen.Id(recv).Op("*").Id("sql" + repository)).
+			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(body...)
+	}
+
+	// rows of every select query are scanned by a single helper
+	if fetch {
+		f.Line()
+		genSQLFetch(f, entity, gomodName, recv, "sql"+repository)
 	}
 
 	fileDir := fmt.Sprintf("%s/%s_repository.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
 
+	return gen.genRepositoryTest(dirName, domainName, gomodName, domain.SQL, dialect, parser, entity)
+}
+
+// genSQLFetch will generate fetch method which run the select query and scan every column of entity
+func genSQLFetch(f *jen.File, e *sqlEntity, gomodName string, recv string, repository string) {
+	var fields []jen.Code
+	for _, c := range e.columns {
+		fields = append(fields, jen.Op("&").Id(e.varName).Dot(c.field.Name))
+	}
+
+	f.Func().
+		Params(jen.Id(recv).Op("*").Id(repository)).
+		Id("fetch").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("query").String(), jen.Id("args").Op("...").Interface()).
+		Params(jen.Index().Op("*").Qual(gomodName+"/domain", e.name), jen.Error()).Block(
+		jen.List(jen.Id("rows"), jen.Err()).Op(":=").Id(recv).Dot("Conn").Dot("QueryContext").Call(jen.Id("ctx"), jen.Id("query"), jen.Id("args").Op("...")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+		jen.Defer().Id("rows").Dot("Close").Call(),
+		jen.Line(),
+		jen.Id("result").Op(":=").Make(jen.Index().Op("*").Qual(gomodName+"/domain", e.name), jen.Lit(0)),
+		jen.For(jen.Id("rows").Dot("Next").Call()).Block(
+			jen.Id(e.varName).Op(":=").New(jen.Qual(gomodName+"/domain", e.name)),
+			jen.Err().Op("=").Id("rows").Dot("Scan").Custom(jen.Options{Open: "(", Close: ")", Separator: ",", Multi: true}, fields...),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+			jen.Id("result").Op("=").Append(jen.Id("result"), jen.Id(e.varName)),
+		),
+		jen.Line(),
+		jen.Return(jen.Id("result"), jen.Id("rows").Dot("Err").Call()),
+	)
+}
+
+// genSQLCrud will generate body of conventional method using database/sql, writes are done by prepared statement
+func genSQLCrud(op *crudOperation, e *sqlEntity, dialect string, recv string) []jen.Code {
+	var (
+		conn = jen.Id(recv).Dot("Conn")
+		ctx  = jen.Id(op.method.ParameterList[0].Name)
+		arg  = op.arg
+	)
+
+	// prepare will generate prepared statement of the query which closed when the method return
+	prepare := func(query string) []jen.Code {
+		return []jen.Code{
+			jen.Id("query").Op(":=").Lit(query),
+			jen.List(jen.Id("stmt"), jen.Err()).Op(":=").Add(conn).Dot("PrepareContext").Call(ctx, jen.Id("query")),
+			op.ifErr(),
+			jen.Defer().Id("stmt").Dot("Close").Call(),
+			jen.Line(),
+		}
+	}
+
+	switch op.kind {
+	case crudFetch:
+		var (
+			conditions []string
+			args       = []jen.Code{ctx, jen.Id("query")}
+		)
+		for n, filter := range op.filters {
+			conditions = append(conditions, filter.column+" = "+placeholder(dialect, n+1))
+			args = append(args, jen.Id(filter.arg))
+		}
+
+		code := []jen.Code{
+			jen.Id("query").Op(":=").Lit("SELECT " + e.selectColumns() + " FROM " + e.table + e.where(conditions...)),
+			jen.List(jen.Id("result"), jen.Err()).Op(":=").Id(recv).Dot("fetch").Call(args...),
+			op.ifErr(),
+			jen.Line(),
+		}
+		if op.slicePointer {
+			return append(code, jen.Return(jen.Id("result"), jen.Nil()))
+		}
+
+		// fetch always return pointer, so every row is copied into slice of value
+		return append(code,
+			jen.Id("list").Op(":=").Make(jen.Index().Qual(op.domain, e.name), jen.Len(jen.Id("result"))),
+			jen.For(jen.List(jen.Id("i"), jen.Id(e.varName)).Op(":=").Range().Id("result")).Block(
+				jen.Id("list").Index(jen.Id("i")).Op("=").Op("*").Id(e.varName),
+			),
+			jen.Line(),
+			jen.Return(jen.Id("list"), jen.Nil()),
+		)
+
+	case crudGetByID:
+		return []jen.Code{
+			jen.Id("query").Op(":=").Lit("SELECT " + e.selectColumns() + " FROM " + e.table + e.where(e.primary.name+" = "+placeholder(dialect, 1))),
+			jen.List(jen.Id("result"), jen.Err()).Op(":=").Id(recv).Dot("fetch").Call(ctx, jen.Id("query"), jen.Id(arg)),
+			op.ifErr(),
+			jen.If(jen.Len(jen.Id("result")).Op("==").Lit(0)).Block(jen.Return(jen.Nil(), jen.Qual(op.domain, "ErrNotFound"))),
+			jen.Line(),
+			jen.Return(jen.Id("result").Index(jen.Lit(0)), jen.Nil()),
+		}
+
+	case crudStore:
+		var (
+			columns []string
+			values  []string
+			args    = []jen.Code{ctx}
+		)
+		for n, c := range e.insertColumns() {
+			columns = append(columns, c.name)
+			values = append(values, placeholder(dialect, n+1))
+			args = append(args, jen.Id(arg).Dot(c.field.Name))
+		}
+		query := "INSERT INTO " + e.table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(values, ", ") + ")"
+
+		code := stampTime(arg, e.createdAt, e.updatedAt)
+		switch {
+		case e.autoIncrement() && dialect == domain.Postgres:
+			// postgres does not support LastInsertId, the generated id is returned by the query
+			code = append(code, prepare(query+" RETURNING "+e.primary.name)...)
+			code = append(code,
+				jen.Err().Op("=").Id("stmt").Dot("QueryRowContext").Call(args...).Dot("Scan").Call(jen.Op("&").Id(arg).Dot(e.primary.field.Name)),
+				op.ifErr(),
+				jen.Line(),
+			)
+		case e.autoIncrement():
+			code = append(code, prepare(query)...)
+			code = append(code,
+				jen.List(jen.Id("res"), jen.Err()).Op(":=").Id("stmt").Dot("ExecContext").Call(args...),
+				op.ifErr(),
+				jen.Line(),
+				jen.List(jen.Id("id"), jen.Err()).Op(":=").Id("res").Dot("LastInsertId").Call(),
+				op.ifErr(),
+				jen.Id(arg).Dot(e.primary.field.Name).Op("=").Id(e.primary.field.Type).Call(jen.Id("id")),
+				jen.Line(),
+			)
+		default:
+			code = append(code, prepare(query)...)
+			code = append(code,
+				jen.List(jen.Id("_"), jen.Err()).Op("=").Id("stmt").Dot("ExecContext").Call(args...),
+				op.ifErr(),
+				jen.Line(),
+			)
+		}
+		return append(code, op.returns(jen.Id(arg), jen.Nil()))
+
+	case crudUpdate:
+		var (
+			set  []string
+			args = []jen.Code{ctx}
+		)
+		for n, c := range e.updateColumns() {
+			set = append(set, c.name+" = "+placeholder(dialect, n+1))
+			args = append(args, jen.Id(arg).Dot(c.field.Name))
+		}
+		args = append(args, jen.Id(arg).Dot(e.primary.field.Name))
+		query := "UPDATE " + e.table + " SET " + strings.Join(set, ", ") + e.where(e.primary.name+" = "+placeholder(dialect, len(set)+1))
+
+		code := stampTime(arg, e.updatedAt)
+		code = append(code, prepare(query)...)
+		code = append(code,
+			jen.List(jen.Id("res"), jen.Err()).Op(":=").Id("stmt").Dot("ExecContext").Call(args...),
+			op.ifErr(),
+			jen.Line(),
+		)
+		code = append(code, op.checkAffected("res")...)
+		return append(code, op.returns(jen.Id(arg), jen.Nil()))
+
+	case crudDelete:
+		var code []jen.Code
+		if e.softDelete != nil {
+			code = append(code, prepare("UPDATE "+e.table+" SET "+e.softDelete.name+" = "+placeholder(dialect, 1)+e.where(e.primary.name+" = "+placeholder(dialect, 2)))...)
+			code = append(code, jen.List(jen.Id("res"), jen.Err()).Op(":=").Id("stmt").Dot("ExecContext").Call(ctx, jen.Qual("time", "Now").Call(), jen.Id(arg)))
+		} else {
+			code = append(code, prepare("DELETE FROM "+e.table+" WHERE "+e.primary.name+" = "+placeholder(dialect, 1))...)
+			code = append(code, jen.List(jen.Id("res"), jen.Err()).Op(":=").Id("stmt").Dot("ExecContext").Call(ctx, jen.Id(arg)))
+		}
+		code = append(code, op.ifErr(), jen.Line())
+		code = append(code, op.checkAffected("res")...)
+		return append(code, jen.Return(jen.Nil()))
+	}
 	return nil
 }
 
-func (gen *caGen) GenSqlxRepository(dirName string, domainFile string, gomodName string, parser *domain.Parser) error {
+func (gen *caGen) GenSqlxRepository(dirName string, domainFile string, gomodName string, dialect string, parser *domain.Parser) error {
 	var (
 		file       = path.Base(domainFile)
 		domainName = strings.TrimSuffix(file, filepath.Ext(file))
@@ -171,6 +567,8 @@ func (gen *caGen) GenSqlxRepository(dirName string, domainFile string, gomodName
 			"github.com/jmoiron/sqlx": "sqlx",
 			gomodName + "/domain":     "domain",
 		}
+		entity = newSQLEntity(parser.Entity, columnName)
+		recv   = string(domainName[0]) + "r"
 	)
 
 	f.ImportNames(importName)
@@ -190,24 +588,164 @@ func (gen *caGen) GenSqlxRepository(dirName string, domainFile string, gomodName
 
 	for _, i := range parser.Repository.Method {
 		var (
-			param            = genParamList(i)
-			returnT, returnV = genReturnList(i)
+			param            = genParamList(i, parserImports(gomodName, parser))
+			returnT, returnV = genReturnList(i, parserImports(gomodName, parser))
+			body             = []jen.Code{
+				jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
+			}
 		)
+
+		// conventional methods are implemented, the others are left as stub
+		if op, ok := getCrudOperation(i, entity, gomodName); ok {
+			body = append(body, jen.Line())
+			body = append(body, genSqlxCrud(op, entity, dialect, recv)...)
+		} else {
+			body = append(body, genCrudStub(i, entity, returnV)...)
+		}
+
 		f.Line()
 		f.Func().
-			Params(jen.Id(string(domainName[0])+"r").Op("*").Id("sqlx"+repository)).
-			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(
-			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-			jen.Return(returnV[:]...),
-		)
+			Params(jen.Id(recv).Op("*").Id("sqlx" + repository)).
+			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(body...)
 	}
 
 	fileDir := fmt.Sprintf("%s/%s_repository.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
 
+	return gen.genRepositoryTest(dirName, domainName, gomodName, domain.Sqlx, dialect, parser, entity)
+}
+
+// genSqlxCrud will generate body of conventional method using sqlx, column is taken from db tag of entity
+func genSqlxCrud(op *crudOperation, e *sqlEntity, dialect string, recv string) []jen.Code {
+	var (
+		conn   = jen.Id(recv).Dot("Conn")
+		ctx    = jen.Id(op.method.ParameterList[0].Name)
+		arg    = op.arg
+		entity = jen.Qual(op.domain, e.name)
+	)
+
+	switch op.kind {
+	case crudFetch:
+		var (
+			conditions []string
+			args       = []jen.Code{ctx, jen.Op("&").Id("result"), jen.Id("query")}
+			elem       = entity
+		)
+		for n, filter := range op.filters {
+			conditions = append(conditions, filter.column+" = "+placeholder(dialect, n+1))
+			args = append(args, jen.Id(filter.arg))
+		}
+		if op.slicePointer {
+			elem = jen.Op("*").Add(entity)
+		}
+		return []jen.Code{
+			jen.Var().Id("result").Index().Add(elem),
+			jen.Id("query").Op(":=").Lit("SELECT " + e.selectColumns() + " FROM " + e.table + e.where(conditions...)),
+			jen.Err().Op(":=").Add(conn).Dot("SelectContext").Call(args...),
+			op.ifErr(),
+			jen.Line(),
+			jen.Return(jen.Id("result"), jen.Nil()),
+		}
+
+	case crudGetByID:
+		return []jen.Code{
+			jen.Id(e.varName).Op(":=").New(entity),
+			jen.Id("query").Op(":=").Lit("SELECT " + e.selectColumns() + " FROM " + e.table + e.where(e.primary.name+" = "+placeholder(dialect, 1))),
+			jen.Err().Op(":=").Add(conn).Dot("GetContext").Call(ctx, jen.Id(e.varName), jen.Id("query"), jen.Id(arg)),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.If(jen.Err().Op("==").Qual("database/sql", "ErrNoRows")).Block(
+					jen.Return(jen.Nil(), jen.Qual(op.domain, "ErrNotFound")),
+				),
+				jen.Return(jen.Nil(), jen.Err()),
+			),
+			jen.Line(),
+			jen.Return(jen.Id(e.varName), jen.Nil()),
+		}
+
+	case crudStore:
+		var (
+			columns []string
+			values  []string
+		)
+		for _, c := range e.insertColumns() {
+			columns = append(columns, c.name)
+			values = append(values, ":"+c.name)
+		}
+		query := "INSERT INTO " + e.table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(values, ", ") + ")"
+
+		code := stampTime(arg, e.createdAt, e.updatedAt)
+		switch {
+		case e.autoIncrement() && dialect == domain.Postgres:
+			// postgres does not support LastInsertId, the generated id is returned by the query
+			code = append(code,
+				jen.Id("query").Op(":=").Lit(query+" RETURNING "+e.primary.name),
+				jen.List(jen.Id("stmt"), jen.Err()).Op(":=").Add(conn).Dot("PrepareNamedContext").Call(ctx, jen.Id("query")),
+				op.ifErr(),
+				jen.Defer().Id("stmt").Dot("Close").Call(),
+				jen.Line(),
+				jen.Err().Op("=").Id("stmt").Dot("GetContext").Call(ctx, jen.Op("&").Id(arg).Dot(e.primary.field.Name), jen.Id(arg)),
+				op.ifErr(),
+				jen.Line(),
+			)
+		case e.autoIncrement():
+			code = append(code,
+				jen.Id("query").Op(":=").Lit(query),
+				jen.List(jen.Id("res"), jen.Err()).Op(":=").Add(conn).Dot("NamedExecContext").Call(ctx, jen.Id("query"), jen.Id(arg)),
+				op.ifErr(),
+				jen.Line(),
+				jen.List(jen.Id("id"), jen.Err()).Op(":=").Id("res").Dot("LastInsertId").Call(),
+				op.ifErr(),
+				jen.Id(arg).Dot(e.primary.field.Name).Op("=").Id(e.primary.field.Type).Call(jen.Id("id")),
+				jen.Line(),
+			)
+		default:
+			code = append(code,
+				jen.Id("query").Op(":=").Lit(query),
+				jen.List(jen.Id("_"), jen.Err()).Op(":=").Add(conn).Dot("NamedExecContext").Call(ctx, jen.Id("query"), jen.Id(arg)),
+				op.ifErr(),
+				jen.Line(),
+			)
+		}
+		return append(code, op.returns(jen.Id(arg), jen.Nil()))
+
+	case crudUpdate:
+		var set []string
+		for _, c := range e.updateColumns() {
+			set = append(set, c.name+" = :"+c.name)
+		}
+		query := "UPDATE " + e.table + " SET " + strings.Join(set, ", ") + e.where(e.primary.name+" = :"+e.primary.name)
+
+		code := stampTime(arg, e.updatedAt)
+		code = append(code,
+			jen.Id("query").Op(":=").Lit(query),
+			jen.List(jen.Id("res"), jen.Err()).Op(":=").Add(conn).Dot("NamedExecContext").Call(ctx, jen.Id("query"), jen.Id(arg)),
+			op.ifErr(),
+			jen.Line(),
+		)
+		code = append(code, op.checkAffected("res")...)
+		return append(code, op.returns(jen.Id(arg), jen.Nil()))
+
+	case crudDelete:
+		var code []jen.Code
+		if e.softDelete != nil {
+			query := "UPDATE " + e.table + " SET " + e.softDelete.name + " = " + placeholder(dialect, 1) + e.where(e.primary.name+" = "+placeholder(dialect, 2))
+			code = append(code,
+				jen.Id("query").Op(":=").Lit(query),
+				jen.List(jen.Id("res"), jen.Err()).Op(":=").Add(conn).Dot("ExecContext").Call(ctx, jen.Id("query"), jen.Qual("time", "Now").Call(), jen.Id(arg)),
+			)
+		} else {
+			code = append(code,
+				jen.Id("query").Op(":=").Lit("DELETE FROM "+e.table+" WHERE "+e.primary.name+" = "+placeholder(dialect, 1)),
+				jen.List(jen.Id("res"), jen.Err()).Op(":=").Add(conn).Dot("ExecContext").Call(ctx, jen.Id("query"), jen.Id(arg)),
+			)
+		}
+		code = append(code, op.ifErr(), jen.Line())
+		code = append(code, op.checkAffected("res")...)
+		return append(code, jen.Return(jen.Nil()))
+	}
 	return nil
 }
 
@@ -220,45 +758,310 @@ func (gen *caGen) GenMongodRepository(dirName string, domainFile string, gomodNa
 		comment    = fmt.Sprintf("NewMongod%s will create new an mongod%s object representation of domain.%s interface", repository, repository, repository)
 		f          = jen.NewFile("repository")
 		importName = map[string]string{
-			"go.mongodb.org/mongo-driver/mongo": "mongo",
-			gomodName + "/domain":               "domain",
+			"go.mongodb.org/mongo-driver/mongo":          "mongo",
+			"go.mongodb.org/mongo-driver/bson":           "bson",
+			"go.mongodb.org/mongo-driver/bson/primitive": "primitive",
+			"go.mongodb.org/mongo-driver/mongo/options":  "options",
+			gomodName + "/domain":                        "domain",
 		}
+		entity  = newSQLEntity(parser.Entity, bsonColumnName)
+		recv    = string(domainName[0]) + "r"
+		ops     []*crudOperation
+		indexes [][]string
 	)
 
 	f.ImportNames(importName)
 
+	if entity != nil {
+		for _, i := range parser.Repository.Method {
+			if op, ok := getCrudOperation(i, entity, gomodName); ok {
+				ops = append(ops, op)
+			}
+		}
+		indexes = mongodIndexes(entity, ops)
+	}
+
 	f.Type().Id("mongod" + repository).Struct(
 		jen.Id("Conn").Op("*").Qual("go.mongodb.org/mongo-driver/mongo", "Database"),
 	)
 
-	f.Comment(comment)
-	f.Func().Id(newR).Params(
-		jen.Id("Conn").Op("*").Qual("go.mongodb.org/mongo-driver/mongo", "Database"),
-	).Qual(gomodName+"/domain", repository).Block(
+	constructor := []jen.Code{
 		jen.Return(jen.Op("&").Id("mongod" + repository).Values(jen.Dict{
 			jen.Id("Conn"): jen.Id("Conn"),
 		})),
-	)
+	}
+	if len(indexes) > 0 {
+		constructor = []jen.Code{
+			jen.Id(recv).Op(":=").Op("&").Id("mongod" + repository).Values(jen.Dict{
+				jen.Id("Conn"): jen.Id("Conn"),
+			}),
+			jen.Line(),
+			jen.Comment("indexes of the queried fields are created at startup if enabled"),
+			jen.If(jen.Qual("os", "Getenv").Call(jen.Lit("DATABASE_MONGO_CREATE_INDEX")).Op("==").Lit("true")).Block(
+				jen.Err().Op(":=").Id(recv).Dot("createIndexes").Call(jen.Qual("context", "Background").Call()),
+				jen.If(jen.Err().Op("!=").Nil()).Block(
+					jen.Qual("log", "Println").Call(jen.Lit("Couldn't create indexes of "+entity.table+" "), jen.Err()),
+				),
+			),
+			jen.Line(),
+			jen.Return(jen.Id(recv)),
+		}
+	}
+
+	f.Comment(comment)
+	f.Func().Id(newR).Params(
+		jen.Id("Conn").Op("*").Qual("go.mongodb.org/mongo-driver/mongo", "Database"),
+	).Qual(gomodName+"/domain", repository).Block(constructor...)
 
 	for _, i := range parser.Repository.Method {
 		var (
-			param            = genParamList(i)
-			returnT, returnV = genReturnList(i)
+			param            = genParamList(i, parserImports(gomodName, parser))
+			returnT, returnV = genReturnList(i, parserImports(gomodName, parser))
+			body             = []jen.Code{
+				jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
+			}
 		)
+
+		// conventional methods are implemented, the others are left as stub
+		if op, ok := getCrudOperation(i, entity, gomodName); ok {
+			body = append(body, jen.Line())
+			body = append(body, genMongodCrud(op, entity, recv)...)
+		} else {
+			body = append(body, genCrudStub(i, entity, returnV)...)
+		}
+
 		f.Line()
 		f.Func().
-			Params(jen.Id(string(domainName[0])+"r").Op("*").Id("mongod"+repository)).
-			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(
-			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-			jen.Return(returnV[:]...),
-		)
+			Params(jen.Id(recv).Op("*").Id("mongod" + repository)).
+			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(body...)
+	}
+
+	// document has no auto increment id, so integer id is taken from a counter
+	for _, op := range ops {
+		if op.kind == crudStore && entity.autoIncrement() {
+			f.Line()
+			genMongodNextID(f, entity, recv, "mongod"+repository)
+			break
+		}
+	}
+
+	if len(indexes) > 0 {
+		f.Line()
+		genMongodIndexes(f, entity, indexes, recv, "mongod"+repository)
 	}
 
 	fileDir := fmt.Sprintf("%s/%s_repository.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
 
+	return gen.genRepositoryTest(dirName, domainName, gomodName, domain.Mongod, "", parser, entity)
+}
+
+// mongodIndexes will return keys of index which are used by the queries, one index for every filter of Fetch
+func mongodIndexes(e *sqlEntity, ops []*crudOperation) (indexes [][]string) {
+	seen := map[string]bool{}
+	for _, op := range ops {
+		if op.kind != crudFetch {
+			continue
+		}
+		var keys []string
+		for _, filter := range op.filters {
+			keys = append(keys, filter.column)
+		}
+		if e.softDelete != nil {
+			keys = append(keys, e.softDelete.name)
+		}
+		if len(keys) == 0 || seen[strings.Join(keys, ",")] {
+			continue
+		}
+		seen[strings.Join(keys, ",")] = true
+		indexes = append(indexes, keys)
+	}
+	return indexes
+}
+
+// genMongodIndexes will generate createIndexes method which create the index of every queried fields
+func genMongodIndexes(f *jen.File, e *sqlEntity, indexes [][]string, recv string, repository string) {
+	var models []jen.Code
+	for _, keys := range indexes {
+		var d []jen.Code
+		for _, k := range keys {
+			d = append(d, jen.Values(jen.Dict{jen.Id("Key"): jen.Lit(k), jen.Id("Value"): jen.Lit(1)}))
+		}
+		models = append(models, jen.Values(jen.Dict{
+			jen.Id("Keys"): jen.Qual("go.mongodb.org/mongo-driver/bson", "D").Values(d...),
+		}))
+	}
+
+	f.Func().
+		Params(jen.Id(recv).Op("*").Id(repository)).
+		Id("createIndexes").Params(jen.Id("ctx").Qual("context", "Context")).Error().Block(
+		jen.List(jen.Id("_"), jen.Err()).Op(":=").Id(recv).Dot("Conn").Dot("Collection").Call(jen.Lit(e.table)).Dot("Indexes").Call().Dot("CreateMany").Call(
+			jen.Id("ctx"),
+			jen.Index().Qual("go.mongodb.org/mongo-driver/mongo", "IndexModel").Custom(jen.Options{Open: "{", Close: "}", Separator: ",", Multi: true}, models...),
+		),
+		jen.Return(jen.Err()),
+	)
+}
+
+// genMongodNextID will generate nextID method which increment the counter of collection atomically
+func genMongodNextID(f *jen.File, e *sqlEntity, recv string, repository string) {
+	f.Func().
+		Params(jen.Id(recv).Op("*").Id(repository)).
+		Id("nextID").Params(jen.Id("ctx").Qual("context", "Context")).Params(jen.Id(e.primary.field.Type), jen.Error()).Block(
+		jen.Var().Id("counter").Struct(
+			jen.Id("Seq").Id(e.primary.field.Type).Tag(map[string]string{"bson": "seq"}),
+		),
+		jen.Err().Op(":=").Id(recv).Dot("Conn").Dot("Collection").Call(jen.Lit("counters")).Dot("FindOneAndUpdate").Call(
+			jen.Line().Id("ctx"),
+			jen.Line().Qual("go.mongodb.org/mongo-driver/bson", "M").Values(jen.Dict{jen.Lit("_id"): jen.Lit(e.table)}),
+			jen.Line().Qual("go.mongodb.org/mongo-driver/bson", "M").Values(jen.Dict{
+				jen.Lit("$inc"): jen.Qual("go.mongodb.org/mongo-driver/bson", "M").Values(jen.Dict{jen.Lit("seq"): jen.Lit(1)}),
+			}),
+			jen.Line().Qual("go.mongodb.org/mongo-driver/mongo/options", "FindOneAndUpdate").Call().
+				Dot("SetUpsert").Call(jen.True()).
+				Dot("SetReturnDocument").Call(jen.Qual("go.mongodb.org/mongo-driver/mongo/options", "After")).Op(",").Line(),
+		).Dot("Decode").Call(jen.Op("&").Id("counter")),
+		jen.Line(),
+		jen.Return(jen.Id("counter").Dot("Seq"), jen.Err()),
+	)
+}
+
+// genMongodCrud will generate body of conventional method using mongo-driver,
+// document which was soft deleted has deleted_at field and is excluded by every filter
+func genMongodCrud(op *crudOperation, e *sqlEntity, recv string) []jen.Code {
+	var (
+		coll  = jen.Id(recv).Dot("Conn").Dot("Collection").Call(jen.Lit(e.table))
+		ctx   = jen.Id(op.method.ParameterList[0].Name)
+		arg   = op.arg
+		bsonM = func(d jen.Dict) *jen.Statement {
+			return jen.Qual("go.mongodb.org/mongo-driver/bson", "M").Values(d)
+		}
+		filter = func(d jen.Dict) *jen.Statement {
+			if e.softDelete != nil {
+				d[jen.Lit(e.softDelete.name)] = jen.Nil()
+			}
+			return bsonM(d)
+		}
+	)
+
+	switch op.kind {
+	case crudFetch:
+		var (
+			d    = jen.Dict{}
+			elem = jen.Qual(op.domain, e.name)
+		)
+		for _, f := range op.filters {
+			d[jen.Lit(f.column)] = jen.Id(f.arg)
+		}
+		if op.slicePointer {
+			elem = jen.Op("*").Add(elem)
+		}
+		return []jen.Code{
+			jen.List(jen.Id("cursor"), jen.Err()).Op(":=").Add(coll).Dot("Find").Call(ctx, filter(d)),
+			op.ifErr(),
+			jen.Defer().Id("cursor").Dot("Close").Call(ctx),
+			jen.Line(),
+			jen.Id("result").Op(":=").Make(jen.Index().Add(elem), jen.Lit(0)),
+			jen.Err().Op("=").Id("cursor").Dot("All").Call(ctx, jen.Op("&").Id("result")),
+			op.ifErr(),
+			jen.Line(),
+			jen.Return(jen.Id("result"), jen.Nil()),
+		}
+
+	case crudGetByID:
+		return []jen.Code{
+			jen.Id(e.varName).Op(":=").New(jen.Qual(op.domain, e.name)),
+			jen.Err().Op(":=").Add(coll).Dot("FindOne").Call(ctx, filter(jen.Dict{jen.Lit(e.primary.name): jen.Id(arg)})).Dot("Decode").Call(jen.Id(e.varName)),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.If(jen.Err().Op("==").Qual("go.mongodb.org/mongo-driver/mongo", "ErrNoDocuments")).Block(
+					jen.Return(jen.Nil(), jen.Qual(op.domain, "ErrNotFound")),
+				),
+				jen.Return(jen.Nil(), jen.Err()),
+			),
+			jen.Line(),
+			jen.Return(jen.Id(e.varName), jen.Nil()),
+		}
+
+	case crudStore:
+		var (
+			code = stampTime(arg, e.createdAt, e.updatedAt)
+			pk   = jen.Id(arg).Dot(e.primary.field.Name)
+		)
+		switch {
+		case e.autoIncrement():
+			code = append(code,
+				jen.If(jen.Add(pk).Op("==").Lit(0)).Block(
+					jen.List(jen.Id("id"), jen.Err()).Op(":=").Id(recv).Dot("nextID").Call(ctx),
+					op.ifErr(),
+					jen.Add(pk).Op("=").Id("id"),
+				),
+				jen.Line(),
+			)
+		case e.primary.field.Type == "string":
+			code = append(code,
+				jen.If(jen.Add(pk).Op("==").Lit("")).Block(
+					jen.Add(pk).Op("=").Qual("go.mongodb.org/mongo-driver/bson/primitive", "NewObjectID").Call().Dot("Hex").Call(),
+				),
+				jen.Line(),
+			)
+		case e.primary.field.Type == "primitive.ObjectID":
+			code = append(code,
+				jen.If(jen.Add(pk).Dot("IsZero").Call()).Block(
+					jen.Add(pk).Op("=").Qual("go.mongodb.org/mongo-driver/bson/primitive", "NewObjectID").Call(),
+				),
+				jen.Line(),
+			)
+		}
+		return append(code,
+			jen.List(jen.Id("_"), jen.Err()).Op(":=").Add(coll).Dot("InsertOne").Call(ctx, jen.Id(arg)),
+			op.ifErr(),
+			jen.Line(),
+			op.returns(jen.Id(arg), jen.Nil()),
+		)
+
+	case crudUpdate:
+		set := jen.Dict{}
+		for _, c := range e.updateColumns() {
+			set[jen.Lit(c.name)] = jen.Id(arg).Dot(c.field.Name)
+		}
+
+		code := stampTime(arg, e.updatedAt)
+		return append(code,
+			jen.List(jen.Id("res"), jen.Err()).Op(":=").Add(coll).Dot("UpdateOne").Call(
+				ctx,
+				filter(jen.Dict{jen.Lit(e.primary.name): jen.Id(arg).Dot(e.primary.field.Name)}),
+				bsonM(jen.Dict{jen.Lit("$set"): bsonM(set)}),
+			),
+			op.ifErr(),
+			jen.If(jen.Id("res").Dot("MatchedCount").Op("==").Lit(0)).Block(op.returns(jen.Nil(), jen.Qual(op.domain, "ErrNotFound"))),
+			jen.Line(),
+			op.returns(jen.Id(arg), jen.Nil()),
+		)
+
+	case crudDelete:
+		if e.softDelete != nil {
+			return []jen.Code{
+				jen.List(jen.Id("res"), jen.Err()).Op(":=").Add(coll).Dot("UpdateOne").Call(
+					ctx,
+					filter(jen.Dict{jen.Lit(e.primary.name): jen.Id(arg)}),
+					bsonM(jen.Dict{jen.Lit("$set"): bsonM(jen.Dict{jen.Lit(e.softDelete.name): jen.Qual("time", "Now").Call()})}),
+				),
+				op.ifErr(),
+				jen.If(jen.Id("res").Dot("MatchedCount").Op("==").Lit(0)).Block(jen.Return(jen.Qual(op.domain, "ErrNotFound"))),
+				jen.Line(),
+				jen.Return(jen.Nil()),
+			}
+		}
+		return []jen.Code{
+			jen.List(jen.Id("res"), jen.Err()).Op(":=").Add(coll).Dot("DeleteOne").Call(ctx, bsonM(jen.Dict{jen.Lit(e.primary.name): jen.Id(arg)})),
+			op.ifErr(),
+			jen.If(jen.Id("res").Dot("DeletedCount").Op("==").Lit(0)).Block(jen.Return(jen.Qual(op.domain, "ErrNotFound"))),
+			jen.Line(),
+			jen.Return(jen.Nil()),
+		}
+	}
 	return nil
 }
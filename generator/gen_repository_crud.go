@@ -0,0 +1,398 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/wicaker/cacli/domain"
+)
+
+// name of repository methods which follow the convention, so they are able to be implemented from the entity
+const (
+	crudFetch   = "Fetch"
+	crudGetByID = "GetByID"
+	crudStore   = "Store"
+	crudUpdate  = "Update"
+	crudDelete  = "Delete"
+)
+
+// reservedVarNames are package names used inside the generated repository, entity variable must not shadow them
+var reservedVarNames = map[string]bool{
+	"context": true, "domain": true, "errors": true, "sql": true, "sqlx": true, "time": true,
+	"pg": true, "gorm": true, "mongo": true, "bson": true, "options": true, "primitive": true, "log": true, "os": true,
+}
+
+// sqlColumn represent a field of entity which is stored as a column
+type sqlColumn struct {
+	name  string
+	field domain.Field
+}
+
+// sqlEntity represent the table of entity, built from the fields which are known by parser
+type sqlEntity struct {
+	name       string
+	varName    string
+	table      string
+	columns    []sqlColumn
+	primary    *sqlColumn
+	createdAt  *sqlColumn
+	updatedAt  *sqlColumn
+	softDelete *sqlColumn
+	embedded   bool
+}
+
+// crudFilter represent a parameter of Fetch which filter a column
+type crudFilter struct {
+	column string
+	arg    string
+}
+
+// crudOperation represent a repository method which follow the convention
+type crudOperation struct {
+	kind         string
+	domain       string
+	method       domain.Method
+	arg          string
+	filters      []crudFilter
+	slicePointer bool
+	returnEntity bool
+}
+
+// newSQLEntity will build the table of entity, nil if the entity is unknown or has no primary key,
+// column of every field is named by the given function because each library has its own naming
+func newSQLEntity(entity domain.Entity, columnName func(f domain.Field) string) *sqlEntity {
+	if entity.Name == "" || len(entity.Fields) == 0 {
+		return nil
+	}
+
+	e := &sqlEntity{
+		name:    entity.Name,
+		varName: strings.ToLower(entity.Name[:1]) + entity.Name[1:],
+		table:   tableName(entity.Name),
+	}
+	if reservedVarNames[e.varName] {
+		e.varName += "Data"
+	}
+
+	for _, f := range entity.Fields {
+		if f.Embedded {
+			e.embedded = true
+			continue
+		}
+		name := columnName(f)
+		if name == "" {
+			continue
+		}
+
+		e.columns = append(e.columns, sqlColumn{name: name, field: f})
+		c := &e.columns[len(e.columns)-1]
+		switch {
+		case name == "id", name == "_id":
+			e.primary = c
+		case name == "created_at" && isTimeType(f.Type):
+			e.createdAt = c
+		case name == "updated_at" && isTimeType(f.Type):
+			e.updatedAt = c
+		case name == "deleted_at" && f.Type == "*time.Time", strings.Contains(f.Tag("pg"), "soft_delete"):
+			e.softDelete = c
+		}
+	}
+
+	// pointer of columns is taken before the slice stop growing, so look them up again
+	for _, c := range []**sqlColumn{&e.primary, &e.createdAt, &e.updatedAt, &e.softDelete} {
+		if *c != nil {
+			*c = e.column((*c).name)
+		}
+	}
+
+	if e.primary == nil {
+		return nil
+	}
+	return e
+}
+
+// column will return the column which has the given name
+func (e *sqlEntity) column(name string) *sqlColumn {
+	for i := range e.columns {
+		if e.columns[i].name == name {
+			return &e.columns[i]
+		}
+	}
+	return nil
+}
+
+// selectColumns will return column list of select query, every column is selected when some fields are embedded
+func (e *sqlEntity) selectColumns() string {
+	if e.embedded {
+		return "*"
+	}
+	var names []string
+	for _, c := range e.columns {
+		names = append(names, c.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// insertColumns will return the columns which are written by insert query, auto increment primary key is excluded
+func (e *sqlEntity) insertColumns() []sqlColumn {
+	var columns []sqlColumn
+	for _, c := range e.columns {
+		if (c.name == e.primary.name && e.autoIncrement()) || (e.softDelete != nil && c.name == e.softDelete.name) {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+// updateColumns will return the columns which are written by update query
+func (e *sqlEntity) updateColumns() []sqlColumn {
+	var columns []sqlColumn
+	for _, c := range e.columns {
+		if c.name == e.primary.name ||
+			(e.createdAt != nil && c.name == e.createdAt.name) ||
+			(e.softDelete != nil && c.name == e.softDelete.name) {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+// autoIncrement will return true if primary key is generated by database
+func (e *sqlEntity) autoIncrement() bool {
+	switch e.primary.field.Type {
+	case "int", "int32", "int64", "uint", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// where will join the conditions of query, row which was soft deleted is excluded
+func (e *sqlEntity) where(conditions ...string) string {
+	if e.softDelete != nil {
+		conditions = append(conditions, e.softDelete.name+" IS NULL")
+	}
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// genCrudStub will generate body of method which is not able to be implemented from the entity,
+// it is marked the same way as stub of usecase, so it is not mistaken for a working method
+func genCrudStub(method domain.Method, e *sqlEntity, returnV []jen.Code) []jen.Code {
+	reason := fmt.Sprintf("TODO: %s is not a conventional method, implement it by hand", method.Name)
+	if e == nil {
+		reason = fmt.Sprintf("TODO: domain has no entity to implement %s from, implement it by hand", method.Name)
+	}
+	return []jen.Code{jen.Comment(reason), jen.Return(notImplemented(method, returnV)...)}
+}
+
+// getCrudOperation will match the method with the convention of Fetch, GetByID, Store, Update and Delete
+func getCrudOperation(method domain.Method, e *sqlEntity, gomodName string) (*crudOperation, bool) {
+	if e == nil {
+		return nil, false
+	}
+
+	var (
+		params  = method.ParameterList
+		results = method.ResultList
+		entity  = "domain." + e.name
+		op      = &crudOperation{kind: method.Name, domain: gomodName + "/domain", method: method}
+	)
+	if len(params) == 0 || params[0].Type != "context.Context" || params[0].Name == "" {
+		return nil, false
+	}
+	if len(results) == 0 || results[len(results)-1].Type != "error" {
+		return nil, false
+	}
+	for _, p := range params {
+		if p.Name == "" || p.Name == "_" {
+			return nil, false
+		}
+	}
+
+	switch method.Name {
+	case crudFetch:
+		if len(results) != 2 || (results[0].Type != "[]*"+entity && results[0].Type != "[]"+entity) {
+			return nil, false
+		}
+		op.slicePointer = results[0].Type == "[]*"+entity
+		for _, p := range params[1:] {
+			c := e.column(toSnakeCase(p.Name))
+			if c == nil || c.field.Type != p.Type {
+				return nil, false
+			}
+			op.filters = append(op.filters, crudFilter{column: c.name, arg: p.Name})
+		}
+		return op, true
+	case crudGetByID:
+		if len(params) != 2 || params[1].Type != e.primary.field.Type || len(results) != 2 || results[0].Type != "*"+entity {
+			return nil, false
+		}
+		op.arg = params[1].Name
+		return op, true
+	case crudStore, crudUpdate:
+		if len(params) != 2 || params[1].Type != "*"+entity {
+			return nil, false
+		}
+		if len(results) == 2 && results[0].Type != "*"+entity || len(results) > 2 {
+			return nil, false
+		}
+		if method.Name == crudUpdate && len(e.updateColumns()) == 0 {
+			return nil, false
+		}
+		op.arg = params[1].Name
+		op.returnEntity = len(results) == 2
+		return op, true
+	case crudDelete:
+		if len(params) != 2 || params[1].Type != e.primary.field.Type || len(results) != 1 {
+			return nil, false
+		}
+		op.arg = params[1].Name
+		return op, true
+	}
+	return nil, false
+}
+
+// returns will return the result of operation, the entity is omitted if the method only return error
+func (op *crudOperation) returns(entity jen.Code, err jen.Code) jen.Code {
+	if op.kind == crudDelete || (!op.returnEntity && (op.kind == crudStore || op.kind == crudUpdate)) {
+		return jen.Return(err)
+	}
+	return jen.Return(entity, err)
+}
+
+// ifErr will return early if err is not nil
+func (op *crudOperation) ifErr() jen.Code {
+	return jen.If(jen.Err().Op("!=").Nil()).Block(op.returns(jen.Nil(), jen.Err()))
+}
+
+// stampTime will set created_at and updated_at before the entity is written
+func stampTime(arg string, columns ...*sqlColumn) []jen.Code {
+	var code []jen.Code
+	for _, c := range columns {
+		if c == nil {
+			continue
+		}
+		if len(code) == 0 {
+			code = append(code, jen.Id("now").Op(":=").Qual("time", "Now").Call())
+		}
+		value := jen.Id("now")
+		if c.field.Pointer {
+			value = jen.Op("&").Id("now")
+		}
+		code = append(code, jen.Id(arg).Dot(c.field.Name).Op("=").Add(value))
+	}
+	if len(code) > 0 {
+		code = append(code, jen.Line())
+	}
+	return code
+}
+
+// checkAffected will return domain.ErrNotFound if no row was changed by the query
+func (op *crudOperation) checkAffected(res string) []jen.Code {
+	return []jen.Code{
+		jen.List(jen.Id("affected"), jen.Err()).Op(":=").Id(res).Dot("RowsAffected").Call(),
+		op.ifErr(),
+		jen.If(jen.Id("affected").Op("==").Lit(0)).Block(op.returns(jen.Nil(), jen.Qual(op.domain, "ErrNotFound"))),
+		jen.Line(),
+	}
+}
+
+// placeholder will return the n-th bind parameter of query based on sql dialect
+func placeholder(dialect string, n int) string {
+	if dialect == domain.Postgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// tableName will return plural snake case of entity name, e.g. Category become categories
+func tableName(entity string) string {
+	name := toSnakeCase(entity)
+	switch {
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsAny(name[len(name)-2:len(name)-1], "aeiou"):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	}
+	return name + "s"
+}
+
+// columnName will return column of field from db tag, sqlx lowercase the field name if no tag is given,
+// field which database/sql is not able to bind, e.g. map[string]string, is not a column
+func columnName(f domain.Field) string {
+	name := strings.Split(f.Tag("db"), ",")[0]
+	if name == "-" || !isSQLValueType(f.Type) {
+		return ""
+	}
+	if name == "" {
+		name = strings.ToLower(f.Name)
+	}
+	return name
+}
+
+// gopgColumnName will return column of field from pg tag, go-pg use snake case of field name if no tag is given
+func gopgColumnName(f domain.Field) string {
+	name := strings.Split(f.Tag("pg"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		name = toSnakeCase(f.Name)
+	}
+	return name
+}
+
+// bsonColumnName will return field name of document from bson tag, mongo-driver lowercase the field name if no tag is given
+func bsonColumnName(f domain.Field) string {
+	name := strings.Split(f.Tag("bson"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		name = strings.ToLower(f.Name)
+	}
+	return name
+}
+
+// gormColumnName will return column of field from column setting of gorm tag, gorm use snake case of field name if not given
+func gormColumnName(f domain.Field) string {
+	tag := f.Tag("gorm")
+	if tag == "-" {
+		return ""
+	}
+	for _, setting := range strings.Split(tag, ";") {
+		kv := strings.SplitN(setting, ":", 2)
+		if len(kv) == 2 && strings.EqualFold(strings.TrimSpace(kv[0]), "column") {
+			return strings.TrimSpace(kv[1])
+		}
+	}
+	return toSnakeCase(f.Name)
+}
+
+// isSQLValueType will check whether the type is able to be bound as argument of database/sql,
+// named type is assumed to implement driver.Valuer, since its methods are unknown here
+func isSQLValueType(typ string) bool {
+	expr, err := goparser.ParseExpr(strings.TrimPrefix(typ, "*"))
+	if err != nil {
+		return false
+	}
+	switch d := expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+		return true
+	case *ast.ArrayType:
+		elt, ok := d.Elt.(*ast.Ident)
+		return d.Len == nil && ok && (elt.Name == "byte" || elt.Name == "uint8")
+	}
+	return false
+}
+
+func isTimeType(typ string) bool {
+	return typ == "time.Time" || typ == "*time.Time"
+}
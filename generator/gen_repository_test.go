@@ -16,18 +16,79 @@ import (
 const (
 	expected_gopg_example_repository = `package repository
 
+import (
+	"context"
+	"errors"
+	"github.com/example/examplerepository/domain"
+	"github.com/go-pg/pg/v9/orm"
+)
+
+type gopgExampleRepository struct {
+	Conn orm.DB
+}
+
+// NewGopgExampleRepository will create new an gopgExampleRepository object representation of domain.ExampleRepository interface
+func NewGopgExampleRepository(Conn orm.DB) domain.ExampleRepository {
+	return &gopgExampleRepository{Conn: Conn}
+}
+
+func (er *gopgExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// TODO: domain has no entity to implement Fetch from, implement it by hand
+	return nil, errors.New("Fetch is not implemented")
+}
+
+func (er *gopgExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// TODO: domain has no entity to implement GetByID from, implement it by hand
+	return nil, errors.New("GetByID is not implemented")
+}
+
+func (er *gopgExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// TODO: domain has no entity to implement Store from, implement it by hand
+	return nil, errors.New("Store is not implemented")
+}
+
+func (er *gopgExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// TODO: domain has no entity to implement Update from, implement it by hand
+	return nil, errors.New("Update is not implemented")
+}
+
+func (er *gopgExampleRepository) Delete(ctx context.Context, id uint64) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// TODO: domain has no entity to implement Delete from, implement it by hand
+	return errors.New("Delete is not implemented")
+}
+`
+
+	expected_gopg_crud_repository = `package repository
+
 import (
 	"context"
 	"github.com/example/examplerepository/domain"
 	pg "github.com/go-pg/pg/v9"
+	"github.com/go-pg/pg/v9/orm"
+	"time"
 )
 
 type gopgExampleRepository struct {
-	Conn *pg.DB
+	Conn orm.DB
 }
 
 // NewGopgExampleRepository will create new an gopgExampleRepository object representation of domain.ExampleRepository interface
-func NewGopgExampleRepository(Conn *pg.DB) domain.ExampleRepository {
+func NewGopgExampleRepository(Conn orm.DB) domain.ExampleRepository {
 	return &gopgExampleRepository{Conn: Conn}
 }
 
@@ -35,42 +96,91 @@ func (er *gopgExampleRepository) Fetch(ctx context.Context) ([]*domain.Example,
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+
+	var result []*domain.Example
+	err := er.Conn.ModelContext(ctx, &result).Select()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
 func (er *gopgExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+
+	example := &domain.Example{ID: id}
+	err := er.Conn.ModelContext(ctx, example).WherePK().Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return example, nil
 }
 
 func (er *gopgExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+
+	now := time.Now()
+	exp.CreatedAt = now
+	exp.UpdatedAt = now
+
+	_, err := er.Conn.ModelContext(ctx, exp).Insert()
+	if err != nil {
+		return nil, err
+	}
+
+	return exp, nil
 }
 
 func (er *gopgExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+
+	now := time.Now()
+	exp.UpdatedAt = now
+
+	res, err := er.Conn.ModelContext(ctx, exp).Column("name", "updated_at").WherePK().Update()
+	if err != nil {
+		return nil, err
+	}
+	if res.RowsAffected() == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return exp, nil
 }
 
 func (er *gopgExampleRepository) Delete(ctx context.Context, id uint64) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+
+	example := &domain.Example{ID: id}
+	res, err := er.Conn.ModelContext(ctx, example).WherePK().Delete()
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
 	return nil
 }
 `
-
 	expected_gorm_example_repository = `package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/example/examplerepository/domain"
 	"github.com/jinzhu/gorm"
 )
@@ -88,43 +198,142 @@ func (er *gormExampleRepository) Fetch(ctx context.Context) ([]*domain.Example,
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement Fetch from, implement it by hand
+	return nil, errors.New("Fetch is not implemented")
 }
 
 func (er *gormExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement GetByID from, implement it by hand
+	return nil, errors.New("GetByID is not implemented")
 }
 
 func (er *gormExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement Store from, implement it by hand
+	return nil, errors.New("Store is not implemented")
 }
 
 func (er *gormExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement Update from, implement it by hand
+	return nil, errors.New("Update is not implemented")
 }
 
 func (er *gormExampleRepository) Delete(ctx context.Context, id uint64) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil
+	// TODO: domain has no entity to implement Delete from, implement it by hand
+	return errors.New("Delete is not implemented")
 }
 `
 
+	expected_gorm_crud_repository = `package repository
+
+import (
+	"context"
+	"github.com/example/examplerepository/domain"
+	"github.com/jinzhu/gorm"
+)
+
+type gormExampleRepository struct {
+	Conn *gorm.DB
+}
+
+// NewGormExampleRepository will create new an gormExampleRepository object representation of domain.ExampleRepository interface
+func NewGormExampleRepository(Conn *gorm.DB) domain.ExampleRepository {
+	return &gormExampleRepository{Conn: Conn}
+}
+
+func (er *gormExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var result []*domain.Example
+	err := er.Conn.Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (er *gormExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	example := new(domain.Example)
+	err := er.Conn.Where("id = ?", id).First(example).Error
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return example, nil
+}
+
+func (er *gormExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := er.Conn.Create(exp).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return exp, nil
+}
+
+func (er *gormExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res := er.Conn.Model(exp).Where("id = ?", exp.ID).Updates(map[string]interface{}{"name": exp.Name})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return exp, nil
+}
+
+func (er *gormExampleRepository) Delete(ctx context.Context, id uint64) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	res := er.Conn.Where("id = ?", id).Delete(&domain.Example{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+`
 	expected_sql_example_repository = `package repository
 
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"github.com/example/examplerepository/domain"
 )
 
@@ -141,96 +350,997 @@ func (er *sqlExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, e
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement Fetch from, implement it by hand
+	return nil, errors.New("Fetch is not implemented")
 }
 
 func (er *sqlExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement GetByID from, implement it by hand
+	return nil, errors.New("GetByID is not implemented")
 }
 
 func (er *sqlExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement Store from, implement it by hand
+	return nil, errors.New("Store is not implemented")
 }
 
 func (er *sqlExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement Update from, implement it by hand
+	return nil, errors.New("Update is not implemented")
 }
 
 func (er *sqlExampleRepository) Delete(ctx context.Context, id uint64) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil
+	// TODO: domain has no entity to implement Delete from, implement it by hand
+	return errors.New("Delete is not implemented")
 }
 `
 
-	expected_sqlx_example_repository = `package repository
+	expected_sql_crud_mysql_repository = `package repository
 
 import (
 	"context"
+	"database/sql"
 	"github.com/example/examplerepository/domain"
-	"github.com/jmoiron/sqlx"
+	"time"
 )
 
-type sqlxExampleRepository struct {
-	Conn *sqlx.DB
+type sqlExampleRepository struct {
+	Conn *sql.DB
 }
 
-// NewSqlxExampleRepository will create new an sqlxExampleRepository object representation of domain.ExampleRepository interface
-func NewSqlxExampleRepository(Conn *sqlx.DB) domain.ExampleRepository {
-	return &sqlxExampleRepository{Conn: Conn}
+// NewSQLExampleRepository will create new an sqlExampleRepository object representation of domain.ExampleRepository interface
+func NewSQLExampleRepository(Conn *sql.DB) domain.ExampleRepository {
+	return &sqlExampleRepository{Conn: Conn}
 }
 
-func (er *sqlxExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, error) {
+func (er *sqlExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := "SELECT id, name, created_at, updated_at, deleted_at FROM examples WHERE deleted_at IS NULL"
+	result, err := er.fetch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (er *sqlExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := "SELECT id, name, created_at, updated_at, deleted_at FROM examples WHERE id = ? AND deleted_at IS NULL"
+	result, err := er.fetch(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return result[0], nil
+}
+
+func (er *sqlExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	now := time.Now()
+	exp.CreatedAt = now
+	exp.UpdatedAt = now
+
+	query := "INSERT INTO examples (name, created_at, updated_at) VALUES (?, ?, ?)"
+	stmt, err := er.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, exp.Name, exp.CreatedAt, exp.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	exp.ID = uint64(id)
+
+	return exp, nil
+}
+
+func (er *sqlExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	now := time.Now()
+	exp.UpdatedAt = now
+
+	query := "UPDATE examples SET name = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL"
+	stmt, err := er.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, exp.Name, exp.UpdatedAt, exp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return exp, nil
+}
+
+func (er *sqlExampleRepository) Delete(ctx context.Context, id uint64) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := "UPDATE examples SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL"
+	stmt, err := er.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (er *sqlExampleRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*domain.Example, error) {
+	rows, err := er.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*domain.Example, 0)
+	for rows.Next() {
+		example := new(domain.Example)
+		err = rows.Scan(
+			&example.ID,
+			&example.Name,
+			&example.CreatedAt,
+			&example.UpdatedAt,
+			&example.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, example)
+	}
+
+	return result, rows.Err()
+}
+`
+	expected_sql_crud_postgres_repository = `package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/example/examplerepository/domain"
+	"time"
+)
+
+type sqlExampleRepository struct {
+	Conn *sql.DB
+}
+
+// NewSQLExampleRepository will create new an sqlExampleRepository object representation of domain.ExampleRepository interface
+func NewSQLExampleRepository(Conn *sql.DB) domain.ExampleRepository {
+	return &sqlExampleRepository{Conn: Conn}
+}
+
+func (er *sqlExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := "SELECT id, name, created_at, updated_at, deleted_at FROM examples WHERE deleted_at IS NULL"
+	result, err := er.fetch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (er *sqlExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := "SELECT id, name, created_at, updated_at, deleted_at FROM examples WHERE id = $1 AND deleted_at IS NULL"
+	result, err := er.fetch(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return result[0], nil
+}
+
+func (er *sqlExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	now := time.Now()
+	exp.CreatedAt = now
+	exp.UpdatedAt = now
+
+	query := "INSERT INTO examples (name, created_at, updated_at) VALUES ($1, $2, $3) RETURNING id"
+	stmt, err := er.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, exp.Name, exp.CreatedAt, exp.UpdatedAt).Scan(&exp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return exp, nil
+}
+
+func (er *sqlExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	now := time.Now()
+	exp.UpdatedAt = now
+
+	query := "UPDATE examples SET name = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL"
+	stmt, err := er.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, exp.Name, exp.UpdatedAt, exp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return exp, nil
+}
+
+func (er *sqlExampleRepository) Delete(ctx context.Context, id uint64) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := "UPDATE examples SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL"
+	stmt, err := er.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (er *sqlExampleRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*domain.Example, error) {
+	rows, err := er.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*domain.Example, 0)
+	for rows.Next() {
+		example := new(domain.Example)
+		err = rows.Scan(
+			&example.ID,
+			&example.Name,
+			&example.CreatedAt,
+			&example.UpdatedAt,
+			&example.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, example)
+	}
+
+	return result, rows.Err()
+}
+`
+	expected_sql_crud_mysql_repository_test = `package repository_test
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/example/examplerepository/domain"
+	"github.com/example/examplerepository/repository"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// newSQLExampleRepository will create SQLExampleRepository on top of sqlmock
+func newSQLExampleRepository(t *testing.T) (domain.ExampleRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return repository.NewSQLExampleRepository(db), mock
+}
+
+func TestSQLExampleRepository_Fetch(t *testing.T) {
+	errDatabase := errors.New("unexpected error of database")
+	query := "SELECT id, name, created_at, updated_at, deleted_at FROM examples WHERE deleted_at IS NULL"
+	columns := []string{"id", "name", "created_at", "updated_at", "deleted_at"}
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(columns).AddRow(uint64(1), "test", time.Now(), time.Now(), nil))
+			},
+		},
+		{
+			name: "error, should return error of database",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnError(errDatabase)
+			},
+			wantErr: errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newSQLExampleRepository(t)
+			tt.mock(mock)
+
+			got, err := repo.Fetch(context.TODO())
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+		})
+	}
+}
+
+func TestSQLExampleRepository_GetByID(t *testing.T) {
+	errDatabase := errors.New("unexpected error of database")
+	query := "SELECT id, name, created_at, updated_at, deleted_at FROM examples WHERE id = ? AND deleted_at IS NULL"
+	columns := []string{"id", "name", "created_at", "updated_at", "deleted_at"}
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnRows(sqlmock.NewRows(columns).AddRow(uint64(1), "test", time.Now(), time.Now(), nil))
+			},
+		},
+		{
+			name: "error, should return domain.ErrNotFound when no row is found",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "error, should return error of database",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnError(errDatabase)
+			},
+			wantErr: errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newSQLExampleRepository(t)
+			tt.mock(mock)
+
+			got, err := repo.GetByID(context.TODO(), uint64(1))
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+func TestSQLExampleRepository_Store(t *testing.T) {
+	errDatabase := errors.New("unexpected error of database")
+	query := "INSERT INTO examples (name, created_at, updated_at) VALUES (?, ?, ?)"
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectExec().WithArgs("test", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "error, should return error of database",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectExec().WithArgs("test", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(errDatabase)
+			},
+			wantErr: errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newSQLExampleRepository(t)
+			tt.mock(mock)
+
+			got, err := repo.Store(context.TODO(), &domain.Example{Name: "test"})
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+func TestSQLExampleRepository_Update(t *testing.T) {
+	errDatabase := errors.New("unexpected error of database")
+	query := "UPDATE examples SET name = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL"
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectExec().WithArgs("test", sqlmock.AnyArg(), uint64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "error, should return domain.ErrNotFound when no row is affected",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectExec().WithArgs("test", sqlmock.AnyArg(), uint64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "error, should return error of database",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectExec().WithArgs("test", sqlmock.AnyArg(), uint64(1)).WillReturnError(errDatabase)
+			},
+			wantErr: errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newSQLExampleRepository(t)
+			tt.mock(mock)
+
+			got, err := repo.Update(context.TODO(), &domain.Example{ID: uint64(1), Name: "test"})
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+func TestSQLExampleRepository_Delete(t *testing.T) {
+	errDatabase := errors.New("unexpected error of database")
+	query := "UPDATE examples SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL"
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectExec().WithArgs(sqlmock.AnyArg(), uint64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "error, should return domain.ErrNotFound when no row is affected",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectExec().WithArgs(sqlmock.AnyArg(), uint64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "error, should return error of database",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectExec().WithArgs(sqlmock.AnyArg(), uint64(1)).WillReturnError(errDatabase)
+			},
+			wantErr: errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newSQLExampleRepository(t)
+			tt.mock(mock)
+
+			err := repo.Delete(context.TODO(), uint64(1))
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+`
+	expected_sqlx_example_repository = `package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/example/examplerepository/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type sqlxExampleRepository struct {
+	Conn *sqlx.DB
+}
+
+// NewSqlxExampleRepository will create new an sqlxExampleRepository object representation of domain.ExampleRepository interface
+func NewSqlxExampleRepository(Conn *sqlx.DB) domain.ExampleRepository {
+	return &sqlxExampleRepository{Conn: Conn}
+}
+
+func (er *sqlxExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// TODO: domain has no entity to implement Fetch from, implement it by hand
+	return nil, errors.New("Fetch is not implemented")
+}
+
+func (er *sqlxExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// TODO: domain has no entity to implement GetByID from, implement it by hand
+	return nil, errors.New("GetByID is not implemented")
+}
+
+func (er *sqlxExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// TODO: domain has no entity to implement Store from, implement it by hand
+	return nil, errors.New("Store is not implemented")
+}
+
+func (er *sqlxExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// TODO: domain has no entity to implement Update from, implement it by hand
+	return nil, errors.New("Update is not implemented")
+}
+
+func (er *sqlxExampleRepository) Delete(ctx context.Context, id uint64) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// TODO: domain has no entity to implement Delete from, implement it by hand
+	return errors.New("Delete is not implemented")
+}
+`
+	expected_sqlx_crud_mysql_repository = `package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/example/examplerepository/domain"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type sqlxExampleRepository struct {
+	Conn *sqlx.DB
+}
+
+// NewSqlxExampleRepository will create new an sqlxExampleRepository object representation of domain.ExampleRepository interface
+func NewSqlxExampleRepository(Conn *sqlx.DB) domain.ExampleRepository {
+	return &sqlxExampleRepository{Conn: Conn}
+}
+
+func (er *sqlxExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var result []*domain.Example
+	query := "SELECT id, name, created_at, updated_at, deleted_at FROM examples WHERE deleted_at IS NULL"
+	err := er.Conn.SelectContext(ctx, &result, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (er *sqlxExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	example := new(domain.Example)
+	query := "SELECT id, name, created_at, updated_at, deleted_at FROM examples WHERE id = ? AND deleted_at IS NULL"
+	err := er.Conn.GetContext(ctx, example, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return example, nil
+}
+
+func (er *sqlxExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	now := time.Now()
+	exp.CreatedAt = now
+	exp.UpdatedAt = now
+
+	query := "INSERT INTO examples (name, created_at, updated_at) VALUES (:name, :created_at, :updated_at)"
+	res, err := er.Conn.NamedExecContext(ctx, query, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	exp.ID = uint64(id)
+
+	return exp, nil
+}
+
+func (er *sqlxExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	now := time.Now()
+	exp.UpdatedAt = now
+
+	query := "UPDATE examples SET name = :name, updated_at = :updated_at WHERE id = :id AND deleted_at IS NULL"
+	res, err := er.Conn.NamedExecContext(ctx, query, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return exp, nil
+}
+
+func (er *sqlxExampleRepository) Delete(ctx context.Context, id uint64) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := "UPDATE examples SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL"
+	res, err := er.Conn.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+`
+	expected_sqlx_crud_postgres_repository = `package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/example/examplerepository/domain"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type sqlxExampleRepository struct {
+	Conn *sqlx.DB
+}
+
+// NewSqlxExampleRepository will create new an sqlxExampleRepository object representation of domain.ExampleRepository interface
+func NewSqlxExampleRepository(Conn *sqlx.DB) domain.ExampleRepository {
+	return &sqlxExampleRepository{Conn: Conn}
+}
+
+func (er *sqlxExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var result []*domain.Example
+	query := "SELECT id, name, created_at, updated_at, deleted_at FROM examples WHERE deleted_at IS NULL"
+	err := er.Conn.SelectContext(ctx, &result, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (er *sqlxExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	example := new(domain.Example)
+	query := "SELECT id, name, created_at, updated_at, deleted_at FROM examples WHERE id = $1 AND deleted_at IS NULL"
+	err := er.Conn.GetContext(ctx, example, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return example, nil
+}
+
+func (er *sqlxExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	now := time.Now()
+	exp.CreatedAt = now
+	exp.UpdatedAt = now
+
+	query := "INSERT INTO examples (name, created_at, updated_at) VALUES (:name, :created_at, :updated_at) RETURNING id"
+	stmt, err := er.Conn.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	err = stmt.GetContext(ctx, &exp.ID, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	return exp, nil
+}
+
+func (er *sqlxExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	now := time.Now()
+	exp.UpdatedAt = now
+
+	query := "UPDATE examples SET name = :name, updated_at = :updated_at WHERE id = :id AND deleted_at IS NULL"
+	res, err := er.Conn.NamedExecContext(ctx, query, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return exp, nil
+}
+
+func (er *sqlxExampleRepository) Delete(ctx context.Context, id uint64) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := "UPDATE examples SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL"
+	res, err := er.Conn.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+`
+	expected_mongod_example_repository = `package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/example/examplerepository/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongodExampleRepository struct {
+	Conn *mongo.Database
+}
+
+// NewMongodExampleRepository will create new an mongodExampleRepository object representation of domain.ExampleRepository interface
+func NewMongodExampleRepository(Conn *mongo.Database) domain.ExampleRepository {
+	return &mongodExampleRepository{Conn: Conn}
+}
+
+func (er *mongodExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement Fetch from, implement it by hand
+	return nil, errors.New("Fetch is not implemented")
 }
 
-func (er *sqlxExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
+func (er *mongodExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement GetByID from, implement it by hand
+	return nil, errors.New("GetByID is not implemented")
 }
 
-func (er *sqlxExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+func (er *mongodExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement Store from, implement it by hand
+	return nil, errors.New("Store is not implemented")
 }
 
-func (er *sqlxExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
+func (er *mongodExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+	// TODO: domain has no entity to implement Update from, implement it by hand
+	return nil, errors.New("Update is not implemented")
 }
 
-func (er *sqlxExampleRepository) Delete(ctx context.Context, id uint64) error {
+func (er *mongodExampleRepository) Delete(ctx context.Context, id uint64) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil
+	// TODO: domain has no entity to implement Delete from, implement it by hand
+	return errors.New("Delete is not implemented")
 }
 `
-	expected_mongod_example_repository = `package repository
+
+	expected_mongod_crud_repository = `package repository
 
 import (
 	"context"
 	"github.com/example/examplerepository/domain"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"os"
+	"time"
 )
 
 type mongodExampleRepository struct {
@@ -239,43 +1349,151 @@ type mongodExampleRepository struct {
 
 // NewMongodExampleRepository will create new an mongodExampleRepository object representation of domain.ExampleRepository interface
 func NewMongodExampleRepository(Conn *mongo.Database) domain.ExampleRepository {
-	return &mongodExampleRepository{Conn: Conn}
+	er := &mongodExampleRepository{Conn: Conn}
+
+	// indexes of the queried fields are created at startup if enabled
+	if os.Getenv("DATABASE_MONGO_CREATE_INDEX") == "true" {
+		err := er.createIndexes(context.Background())
+		if err != nil {
+			log.Println("Couldn't create indexes of examples ", err)
+		}
+	}
+
+	return er
 }
 
 func (er *mongodExampleRepository) Fetch(ctx context.Context) ([]*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+
+	cursor, err := er.Conn.Collection("examples").Find(ctx, bson.M{"deleted_at": nil})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	result := make([]*domain.Example, 0)
+	err = cursor.All(ctx, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
 func (er *mongodExampleRepository) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+
+	example := new(domain.Example)
+	err := er.Conn.Collection("examples").FindOne(ctx, bson.M{
+		"_id":        id,
+		"deleted_at": nil,
+	}).Decode(example)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return example, nil
 }
 
 func (er *mongodExampleRepository) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+
+	now := time.Now()
+	exp.CreatedAt = now
+	exp.UpdatedAt = now
+
+	if exp.ID == 0 {
+		id, err := er.nextID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		exp.ID = id
+	}
+
+	_, err := er.Conn.Collection("examples").InsertOne(ctx, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	return exp, nil
 }
 
 func (er *mongodExampleRepository) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return nil, nil
+
+	now := time.Now()
+	exp.UpdatedAt = now
+
+	res, err := er.Conn.Collection("examples").UpdateOne(ctx, bson.M{
+		"_id":        exp.ID,
+		"deleted_at": nil,
+	}, bson.M{"$set": bson.M{
+		"name":       exp.Name,
+		"updated_at": exp.UpdatedAt,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return exp, nil
 }
 
 func (er *mongodExampleRepository) Delete(ctx context.Context, id uint64) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+
+	res, err := er.Conn.Collection("examples").UpdateOne(ctx, bson.M{
+		"_id":        id,
+		"deleted_at": nil,
+	}, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
 	return nil
 }
+
+func (er *mongodExampleRepository) nextID(ctx context.Context) (uint64, error) {
+	var counter struct {
+		Seq uint64 ` + "`" + `bson:"seq"` + "`" + `
+	}
+	err := er.Conn.Collection("counters").FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": "examples"},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+
+	return counter.Seq, err
+}
+
+func (er *mongodExampleRepository) createIndexes(ctx context.Context) error {
+	_, err := er.Conn.Collection("examples").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{
+			Key:   "deleted_at",
+			Value: 1,
+		}}},
+	})
+	return err
+}
 `
 )
 
@@ -364,7 +1582,7 @@ func TestGenerateGopgRepository(t *testing.T) {
 		}
 
 		// generate example_repository.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGopgRepository(dirName, domainFile, gomodName, parser)
 		resGopg, err := newFs.FindFile(dirName + "/example_repository.go")
 		assert.NoError(t, err)
@@ -391,9 +1609,70 @@ func TestGenerateGopgRepository(t *testing.T) {
 		}
 	})
 
+	t.Run("success, should generate go-pg queries of conventional methods", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate example_repository.go file from entity and repository of domain
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenGopgRepository(dirName, domainFile, gomodName, domain.MockParser)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_repository.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected_gopg_crud_repository, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, should generate tests of conventional methods on top of gopgDB", func(t *testing.T) {
+		err := newFs.CreateDir(serviceName)
+		assert.NoError(t, err)
+		defer newFs.RemoveDir(serviceName)
+		err = newFs.CreateDir(serviceName + "/" + dirLayer)
+		assert.NoError(t, err)
+
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenGopgRepository(dirName, domainFile, gomodName, domain.MockParser)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_repository_test.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "func newGopgExampleRepository(t *testing.T) (domain.ExampleRepository, *gopgDB) {")
+		assert.Contains(t, string(data), `db.reply(gopgReply{rows: []map[string]string{{
+					"id":   "1",
+					"name": "test",
+				}}})`)
+		assert.Contains(t, string(data), "db.reply(gopgReply{affected: 0})")
+		assert.Contains(t, string(data), "db.reply(gopgReply{err: errDatabase})")
+		assert.NotContains(t, string(data), "DB_HOST_TEST")
+		assert.NotContains(t, string(data), "t.Skip")
+
+		data, err = ioutil.ReadFile(dirName + "/gopg_db_test.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "type gopgDB struct {\n\torm.DB")
+		assert.Contains(t, string(data), "return orm.NewQueryContext(c, db, model...)")
+		assert.Contains(t, string(data), "return nil, pg.ErrNoRows")
+	})
+
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_repository file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGopgRepository(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
@@ -485,7 +1764,7 @@ func TestGenerateGormRepository(t *testing.T) {
 		}
 
 		// generate example_repository.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGormRepository(dirName, domainFile, gomodName, parser)
 		resGorm, err := newFs.FindFile(dirName + "/example_repository.go")
 		assert.NoError(t, err)
@@ -512,9 +1791,40 @@ func TestGenerateGormRepository(t *testing.T) {
 		}
 	})
 
+	t.Run("success, should generate gorm queries of conventional methods", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate example_repository.go file from entity and repository of domain
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenGormRepository(dirName, domainFile, gomodName, domain.MockParser)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_repository.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected_gorm_crud_repository, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_repository file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGopgRepository(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
@@ -606,8 +1916,8 @@ func TestGenerateSQLRepository(t *testing.T) {
 		}
 
 		// generate example_repository.go file
-		gen := generator.NewGeneratorService()
-		err = gen.GenSQLRepository(dirName, domainFile, gomodName, parser)
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenSQLRepository(dirName, domainFile, gomodName, domain.MySQL, parser)
 		resSQL, err := newFs.FindFile(dirName + "/example_repository.go")
 		assert.NoError(t, err)
 		assert.NotEqual(t, nil, resSQL)
@@ -633,9 +1943,76 @@ func TestGenerateSQLRepository(t *testing.T) {
 		}
 	})
 
+	t.Run("success, should generate prepared queries of conventional methods", func(t *testing.T) {
+		for dialect, expected := range map[string]string{
+			domain.MySQL:    expected_sql_crud_mysql_repository,
+			domain.Postgres: expected_sql_crud_postgres_repository,
+		} {
+			// create directory of service
+			err := newFs.CreateDir(serviceName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			err = newFs.CreateDir(serviceName + "/" + dirLayer)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			// generate example_repository.go file from entity and repository of domain
+			gen := generator.NewGeneratorService(newFs)
+			err = gen.GenSQLRepository(dirName, domainFile, gomodName, dialect, domain.MockParser)
+			assert.NoError(t, err)
+
+			data, err := ioutil.ReadFile(dirName + "/example_repository.go")
+			assert.NoError(t, err)
+			assert.Equal(t, expected, string(data), dialect)
+
+			// remove directory of service
+			err = newFs.RemoveDir(serviceName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+		}
+	})
+
+	t.Run("success, should generate sqlmock tests of conventional methods", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate example_repository_test.go file from entity and repository of domain
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenSQLRepository(dirName, domainFile, gomodName, domain.MySQL, domain.MockParser)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_repository_test.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected_sql_crud_mysql_repository_test, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_repository file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGopgRepository(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
@@ -727,8 +2104,8 @@ func TestGenerateSqlxRepository(t *testing.T) {
 		}
 
 		// generate example_repository.go file
-		gen := generator.NewGeneratorService()
-		err = gen.GenSqlxRepository(dirName, domainFile, gomodName, parser)
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenSqlxRepository(dirName, domainFile, gomodName, domain.MySQL, parser)
 		resSqlx, err := newFs.FindFile(dirName + "/example_repository.go")
 		assert.NoError(t, err)
 		assert.NotEqual(t, nil, resSqlx)
@@ -754,9 +2131,45 @@ func TestGenerateSqlxRepository(t *testing.T) {
 		}
 	})
 
+	t.Run("success, should generate queries of conventional methods", func(t *testing.T) {
+		for dialect, expected := range map[string]string{
+			domain.MySQL:    expected_sqlx_crud_mysql_repository,
+			domain.Postgres: expected_sqlx_crud_postgres_repository,
+		} {
+			// create directory of service
+			err := newFs.CreateDir(serviceName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			err = newFs.CreateDir(serviceName + "/" + dirLayer)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			// generate example_repository.go file from entity and repository of domain
+			gen := generator.NewGeneratorService(newFs)
+			err = gen.GenSqlxRepository(dirName, domainFile, gomodName, dialect, domain.MockParser)
+			assert.NoError(t, err)
+
+			data, err := ioutil.ReadFile(dirName + "/example_repository.go")
+			assert.NoError(t, err)
+			assert.Equal(t, expected, string(data), dialect)
+
+			// remove directory of service
+			err = newFs.RemoveDir(serviceName)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+		}
+	})
+
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_repository file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGopgRepository(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
@@ -848,7 +2261,7 @@ func TestGenerateMongodRepository(t *testing.T) {
 		}
 
 		// generate example_repository.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenMongodRepository(dirName, domainFile, gomodName, parser)
 		resSqlx, err := newFs.FindFile(dirName + "/example_repository.go")
 		assert.NoError(t, err)
@@ -875,11 +2288,219 @@ func TestGenerateMongodRepository(t *testing.T) {
 		}
 	})
 
+	t.Run("success, should generate mongo-driver queries of conventional methods", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate example_repository.go file from entity and repository of domain
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenMongodRepository(dirName, domainFile, gomodName, domain.MockParser)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_repository.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected_mongod_crud_repository, string(data))
+
+		// tests of repository run against mock deployment of mtest, so no database is needed
+		data, err = ioutil.ReadFile(dirName + "/example_repository_test.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))")
+		assert.Contains(t, string(data), "repository.NewMongodExampleRepository(mt.DB)")
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_repository file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenMongodRepository(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
 	})
 }
+
+func TestGenerateRepositoryStub(t *testing.T) {
+	var (
+		serviceName = "testrepositorystub"
+		newFs       = fs.NewFsService()
+		dirName     = serviceName + "/repository"
+		domainFile  = "example.go"
+		gomodName   = "github.com/wicaker/" + serviceName
+		parser      = *domain.MockParser
+	)
+
+	// method which does not follow the convention is left as stub
+	parser.Repository.Method = append(append([]domain.Method{}, domain.MockParser.Repository.Method...), domain.Method{
+		Name: "Archive",
+		ParameterList: []domain.MethodValue{
+			domain.MethodValue{Name: "ctx", Type: "context.Context"},
+			domain.MethodValue{Name: "id", Type: "uint64"},
+		},
+		ResultList: []domain.MethodValue{
+			domain.MethodValue{Type: "error"},
+		},
+	})
+
+	gen := generator.NewGeneratorService(newFs)
+	tests := map[string]func() error{
+		domain.GoPg:   func() error { return gen.GenGopgRepository(dirName, domainFile, gomodName, &parser) },
+		domain.Gorm:   func() error { return gen.GenGormRepository(dirName, domainFile, gomodName, &parser) },
+		domain.SQL:    func() error { return gen.GenSQLRepository(dirName, domainFile, gomodName, domain.MySQL, &parser) },
+		domain.Sqlx:   func() error { return gen.GenSqlxRepository(dirName, domainFile, gomodName, domain.Postgres, &parser) },
+		domain.Mongod: func() error { return gen.GenMongodRepository(dirName, domainFile, gomodName, &parser) },
+	}
+
+	for dbHelper, genRepository := range tests {
+		t.Run(fmt.Sprintf("success, should mark the stub of %s repository to be implemented by hand", dbHelper), func(t *testing.T) {
+			err := newFs.CreateDir(serviceName)
+			assert.NoError(t, err)
+			defer newFs.RemoveDir(serviceName)
+			err = newFs.CreateDir(dirName)
+			assert.NoError(t, err)
+
+			err = genRepository()
+			assert.NoError(t, err)
+
+			data, err := ioutil.ReadFile(dirName + "/example_repository.go")
+			assert.NoError(t, err)
+			assert.Contains(t, string(data), `Archive(ctx context.Context, id uint64) error {`)
+			assert.Contains(t, string(data), "\t// TODO: Archive is not a conventional method, implement it by hand\n\treturn errors.New(\"Archive is not implemented\")\n}")
+		})
+	}
+}
+
+func TestGenerateRepositoryImports(t *testing.T) {
+	var (
+		serviceName = "testrepositoryimports"
+		newFs       = fs.NewFsService()
+		dirName     = serviceName + "/repository"
+		domainFile  = "event.go"
+		gomodName   = "github.com/wicaker/" + serviceName
+		fetch       = domain.Method{
+			Name: "Fetch",
+			ParameterList: []domain.MethodValue{
+				domain.MethodValue{Name: "ctx", Type: "context.Context"},
+				domain.MethodValue{Name: "from", Type: "time.Time"},
+				domain.MethodValue{Name: "to", Type: "time.Time"},
+			},
+			ResultList: []domain.MethodValue{
+				domain.MethodValue{Type: "[]uuid.UUID"},
+				domain.MethodValue{Type: "error"},
+			},
+		}
+		parser = &domain.Parser{
+			Repository: domain.Repository{Name: "EventRepository", Method: []domain.Method{fetch}},
+			Usecase:    domain.Usecase{Name: "EventUsecase", Method: []domain.Method{fetch}},
+			Imports:    map[string]string{"context": "context", "time": "time", "uuid": "github.com/google/uuid"},
+		}
+	)
+
+	gen := generator.NewGeneratorService(newFs)
+	tests := map[string]func() error{
+		domain.GoPg:   func() error { return gen.GenGopgRepository(dirName, domainFile, gomodName, parser) },
+		domain.Gorm:   func() error { return gen.GenGormRepository(dirName, domainFile, gomodName, parser) },
+		domain.SQL:    func() error { return gen.GenSQLRepository(dirName, domainFile, gomodName, domain.MySQL, parser) },
+		domain.Sqlx:   func() error { return gen.GenSqlxRepository(dirName, domainFile, gomodName, domain.Postgres, parser) },
+		domain.Mongod: func() error { return gen.GenMongodRepository(dirName, domainFile, gomodName, parser) },
+	}
+
+	for dbHelper, genRepository := range tests {
+		t.Run(fmt.Sprintf("success, should import the packages of method types in %s repository", dbHelper), func(t *testing.T) {
+			err := newFs.CreateDir(serviceName)
+			assert.NoError(t, err)
+			defer newFs.RemoveDir(serviceName)
+			err = newFs.CreateDir(dirName)
+			assert.NoError(t, err)
+
+			err = genRepository()
+			assert.NoError(t, err)
+
+			data, err := ioutil.ReadFile(dirName + "/event_repository.go")
+			assert.NoError(t, err)
+			assert.Contains(t, string(data), "Fetch(ctx context.Context, from time.Time, to time.Time) ([]uuid.UUID, error) {")
+			assert.Contains(t, string(data), "\t\"time\"\n")
+			assert.Contains(t, string(data), "\tuuid \"github.com/google/uuid\"\n")
+		})
+	}
+}
+
+func TestGenerateSQLRepositoryColumns(t *testing.T) {
+	var (
+		serviceName = "testrepositorycolumns"
+		newFs       = fs.NewFsService()
+		dirName     = serviceName + "/repository"
+		domainFile  = "task.go"
+		gomodName   = "github.com/wicaker/" + serviceName
+		parser      = &domain.Parser{
+			Entity: domain.Entity{
+				Name: "Task",
+				Fields: []domain.Field{
+					domain.Field{Name: "ID", Type: "uint64", Tags: map[string]string{"db": "id"}},
+					domain.Field{Name: "Title", Type: "string", Tags: map[string]string{"db": "title"}},
+					domain.Field{Name: "Labels", Type: "map[string]string", Tags: map[string]string{"db": "labels"}},
+					domain.Field{Name: "Tags", Type: "[]string", Tags: map[string]string{"db": "tags"}},
+					domain.Field{Name: "Data", Type: "[]byte", Tags: map[string]string{"db": "data"}},
+					domain.Field{Name: "DoneAt", Type: "*time.Time", Tags: map[string]string{"db": "done_at"}},
+				},
+			},
+			Repository: domain.Repository{
+				Name: "TaskRepository",
+				Method: []domain.Method{
+					domain.Method{
+						Name: "Store",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "ctx", Type: "context.Context"},
+							domain.MethodValue{Name: "task", Type: "*domain.Task"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "*domain.Task"},
+							domain.MethodValue{Type: "error"},
+						},
+					},
+				},
+			},
+		}
+	)
+
+	gen := generator.NewGeneratorService(newFs)
+	tests := map[string]func() error{
+		domain.SQL:  func() error { return gen.GenSQLRepository(dirName, domainFile, gomodName, domain.MySQL, parser) },
+		domain.Sqlx: func() error { return gen.GenSqlxRepository(dirName, domainFile, gomodName, domain.Postgres, parser) },
+	}
+
+	for dbHelper, genRepository := range tests {
+		t.Run(fmt.Sprintf("success, should leave field which is not able to be bound out of columns of %s repository", dbHelper), func(t *testing.T) {
+			err := newFs.CreateDir(serviceName)
+			assert.NoError(t, err)
+			defer newFs.RemoveDir(serviceName)
+			err = newFs.CreateDir(dirName)
+			assert.NoError(t, err)
+
+			err = genRepository()
+			assert.NoError(t, err)
+
+			for _, file := range []string{"/task_repository.go", "/task_repository_test.go"} {
+				data, err := ioutil.ReadFile(dirName + file)
+				assert.NoError(t, err)
+				assert.Contains(t, string(data), "INSERT INTO tasks (title, data, done_at)")
+				assert.NotContains(t, string(data), "labels")
+				assert.NotContains(t, string(data), "tags")
+			}
+		})
+	}
+}
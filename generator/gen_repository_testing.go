@@ -0,0 +1,873 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/wicaker/cacli/domain"
+)
+
+const (
+	sqlmockPath = "github.com/DATA-DOG/go-sqlmock"
+	mtestPath   = "go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	assertPath  = "github.com/stretchr/testify/assert"
+	gopgPath    = "github.com/go-pg/pg/v9"
+)
+
+// repositoryTest hold everything which is needed to generate test of a repository,
+// database of sql, sqlx and gorm is replaced by sqlmock, mongo by mock deployment of mtest
+// and go-pg by gopgDB which is generated next to the tests
+type repositoryTest struct {
+	f          *jen.File
+	dbHelper   string
+	dialect    string
+	domain     string
+	repository string
+	imports    map[string]string
+	entity     *sqlEntity
+	name       string
+	helper     string
+}
+
+// repositoryCase represent a case of table driven test, mock is the body of function which prepare the stand-in
+type repositoryCase struct {
+	name    string
+	mock    []jen.Code
+	wantErr jen.Code
+}
+
+// genRepositoryTest will generate test of every conventional method of the repository,
+// method which is not conventional has a skipped test to be written by hand
+func (gen *caGen) genRepositoryTest(dirName string, domainName string, gomodName string, dbHelper string, dialect string, parser *domain.Parser, entity *sqlEntity) error {
+	var (
+		prefix = map[string]string{domain.GoPg: "Gopg", domain.Gorm: "Gorm", domain.SQL: "SQL", domain.Sqlx: "Sqlx", domain.Mongod: "Mongod"}[dbHelper]
+		rt     = &repositoryTest{
+			f:          jen.NewFile("repository_test"),
+			dbHelper:   dbHelper,
+			dialect:    dialect,
+			domain:     gomodName + "/domain",
+			repository: gomodName + "/repository",
+			imports:    map[string]string{"domain": gomodName + "/domain", "primitive": "go.mongodb.org/mongo-driver/bson/primitive"},
+			entity:     entity,
+			name:       prefix + parser.Repository.Name,
+			helper:     "new" + prefix + parser.Repository.Name,
+		}
+		ops = map[string]*crudOperation{}
+	)
+
+	rt.f.ImportName(rt.domain, "domain")
+	rt.f.ImportName(rt.repository, "repository")
+	rt.f.ImportName(sqlmockPath, "sqlmock")
+	rt.f.ImportName(mtestPath, "mtest")
+	rt.f.ImportName(assertPath, "assert")
+	rt.f.ImportName("github.com/jmoiron/sqlx", "sqlx")
+	rt.f.ImportName("github.com/jinzhu/gorm", "gorm")
+	rt.f.ImportName("go.mongodb.org/mongo-driver/bson", "bson")
+
+	for _, i := range parser.Repository.Method {
+		if op, ok := getCrudOperation(i, entity, gomodName); ok {
+			ops[i.Name] = op
+		}
+	}
+	if len(ops) > 0 && dbHelper != domain.Mongod {
+		rt.genHelper(parser.Repository.Name)
+	}
+	if len(ops) > 0 && dbHelper == domain.GoPg {
+		if err := gen.genGopgDB(dirName); err != nil {
+			return err
+		}
+	}
+
+	for _, i := range parser.Repository.Method {
+		testName := fmt.Sprintf("Test%s_%s", rt.name, i.Name)
+		rt.f.Line()
+
+		op, ok := ops[i.Name]
+		if !ok {
+			rt.f.Func().Id(testName).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(
+				jen.Id("t").Dot("Skip").Call(jen.Lit(fmt.Sprintf("TODO: %s is not a conventional method, write the test by hand", i.Name))),
+			)
+			continue
+		}
+		rt.genTest(testName, op)
+	}
+
+	return gen.save(rt.f, fmt.Sprintf("%s/%s_repository_test.go", dirName, domainName))
+}
+
+// genHelper will generate function which create the repository on top of its stand-in,
+// mongo has no helper because mtest create the stand-in for every sub test
+func (rt *repositoryTest) genHelper(repository string) {
+	var (
+		ifErr   = jen.If(jen.Err().Op("!=").Nil()).Block(jen.Id("t").Dot("Fatal").Call(jen.Err()))
+		standIn = "sqlmock"
+		results = []jen.Code{jen.Qual(rt.domain, repository), jen.Qual(sqlmockPath, "Sqlmock")}
+		returns = []jen.Code{jen.Qual(rt.repository, "New"+rt.name).Call(jen.Id("db")), jen.Id("mock")}
+		newMock = jen.Qual(sqlmockPath, "New").Call(jen.Qual(sqlmockPath, "QueryMatcherOption").Call(jen.Qual(sqlmockPath, "QueryMatcherEqual")))
+		body    []jen.Code
+	)
+
+	switch rt.dbHelper {
+	case domain.GoPg:
+		standIn = "gopgDB"
+		results[1] = jen.Op("*").Id("gopgDB")
+		returns[1] = jen.Id("db")
+		body = []jen.Code{
+			jen.Id("db").Op(":=").Op("&").Id("gopgDB").Values(),
+			jen.Line(),
+		}
+
+	case domain.Gorm:
+		// query of gorm is built by gorm itself, so the expected query is matched as regular expression
+		body = []jen.Code{
+			jen.List(jen.Id("sqlDB"), jen.Id("mock"), jen.Err()).Op(":=").Qual(sqlmockPath, "New").Call(),
+			ifErr,
+			jen.Id("t").Dot("Cleanup").Call(jen.Func().Params().Block(jen.Id("sqlDB").Dot("Close").Call())),
+			jen.Line(),
+			jen.List(jen.Id("db"), jen.Err()).Op(":=").Qual("github.com/jinzhu/gorm", "Open").Call(jen.Lit("postgres"), jen.Id("sqlDB")),
+			ifErr,
+			jen.Id("db").Dot("LogMode").Call(jen.False()),
+			jen.Line(),
+		}
+
+	case domain.Sqlx:
+		body = []jen.Code{
+			jen.List(jen.Id("sqlDB"), jen.Id("mock"), jen.Err()).Op(":=").Add(newMock),
+			ifErr,
+			jen.Id("t").Dot("Cleanup").Call(jen.Func().Params().Block(jen.Id("sqlDB").Dot("Close").Call())),
+			jen.Line(),
+			jen.Comment("name of driver decide the bind parameter of named query"),
+			jen.Id("db").Op(":=").Qual("github.com/jmoiron/sqlx", "NewDb").Call(jen.Id("sqlDB"), jen.Lit(sqlDriver(rt.dialect))),
+			jen.Line(),
+		}
+
+	default:
+		body = []jen.Code{
+			jen.List(jen.Id("db"), jen.Id("mock"), jen.Err()).Op(":=").Add(newMock),
+			ifErr,
+			jen.Id("t").Dot("Cleanup").Call(jen.Func().Params().Block(jen.Id("db").Dot("Close").Call())),
+			jen.Line(),
+		}
+	}
+	body = append(body, jen.Return(returns...))
+
+	rt.f.Line()
+	rt.f.Comment(fmt.Sprintf("%s will create %s on top of %s", rt.helper, rt.name, standIn))
+	rt.f.Func().Id(rt.helper).Params(jen.Id("t").Op("*").Qual("testing", "T")).Params(results...).Block(body...)
+}
+
+// genTest will generate table driven test of a conventional method
+func (rt *repositoryTest) genTest(testName string, op *crudOperation) {
+	var (
+		e        = rt.entity
+		vars     []jen.Code
+		callArgs = []jen.Code{jen.Qual("context", "TODO").Call()}
+		cases    []repositoryCase
+		check    []jen.Code
+		t        = jen.Id("t")
+	)
+
+	// assertion of mongo is done on *mtest.T of the sub test
+	if rt.dbHelper == domain.Mongod {
+		t = jen.Id("mt")
+	}
+
+	switch op.kind {
+	case crudFetch:
+		for _, filter := range op.filters {
+			callArgs = append(callArgs, rt.value(e.column(filter.column).field.Type))
+		}
+		check = append(check, jen.Qual(assertPath, "Len").Call(t, jen.Id("got"), jen.Lit(1)))
+	case crudGetByID, crudDelete:
+		callArgs = append(callArgs, rt.value(e.primary.field.Type))
+		if op.kind == crudGetByID {
+			check = append(check, jen.Qual(assertPath, "NotNil").Call(t, jen.Id("got")))
+		}
+	case crudStore, crudUpdate:
+		callArgs = append(callArgs, rt.fixture(op.kind == crudUpdate || !e.autoIncrement()))
+		if op.returnEntity {
+			check = append(check, jen.Qual(assertPath, "NotNil").Call(t, jen.Id("got")))
+		}
+	}
+
+	switch rt.dbHelper {
+	case domain.GoPg:
+		cases = rt.gopgCases(op)
+	case domain.Gorm:
+		vars, cases = rt.gormCases(op)
+	case domain.Mongod:
+		cases = rt.mongodCases(op)
+	default:
+		vars, cases = rt.sqlCases(op, callArgs)
+	}
+
+	var (
+		mockParam = jen.Id("mock").Qual(sqlmockPath, "Sqlmock")
+		fieldName = "mock"
+		run       []jen.Code
+		values    []jen.Code
+		call      = jen.Id("repo").Dot(op.kind).Call(callArgs...)
+	)
+	if len(check) > 0 {
+		call = jen.List(jen.Id("got"), jen.Err()).Op(":=").Add(call)
+	} else {
+		call = jen.Err().Op(":=").Add(call)
+	}
+
+	switch rt.dbHelper {
+	case domain.GoPg:
+		mockParam = jen.Id("db").Op("*").Id("gopgDB")
+		run = []jen.Code{
+			jen.List(jen.Id("repo"), jen.Id("db")).Op(":=").Id(rt.helper).Call(t),
+			jen.Id("tt").Dot("mock").Call(jen.Id("db")),
+			jen.Line(),
+			call,
+			jen.Qual(assertPath, "Empty").Call(t, jen.Id("db").Dot("replies"), jen.Lit("every reply should be taken by a query")),
+			jen.If(jen.Id("tt").Dot("wantErr").Op("!=").Nil()).Block(
+				jen.Qual(assertPath, "Equal").Call(t, jen.Id("tt").Dot("wantErr"), jen.Err()),
+				jen.Return(),
+			),
+		}
+	case domain.Mongod:
+		mockParam = jen.Id("mt").Op("*").Qual(mtestPath, "T")
+		run = []jen.Code{
+			jen.Id("tt").Dot("mock").Call(jen.Id("mt")),
+			jen.Id("repo").Op(":=").Qual(rt.repository, "New"+rt.name).Call(jen.Id("mt").Dot("DB")),
+			jen.Line(),
+			call,
+			jen.Comment("error of mock deployment is not the same value, so only the message is compared"),
+			jen.If(jen.Id("tt").Dot("wantErr").Op("!=").Nil()).Block(
+				jen.If(jen.Qual(assertPath, "Error").Call(t, jen.Err())).Block(
+					jen.Qual(assertPath, "Contains").Call(t, jen.Err().Dot("Error").Call(), jen.Id("tt").Dot("wantErr").Dot("Error").Call()),
+				),
+				jen.Return(),
+			),
+		}
+	default:
+		run = []jen.Code{
+			jen.List(jen.Id("repo"), jen.Id("mock")).Op(":=").Id(rt.helper).Call(t),
+			jen.Id("tt").Dot("mock").Call(jen.Id("mock")),
+			jen.Line(),
+			call,
+			jen.Qual(assertPath, "NoError").Call(t, jen.Id("mock").Dot("ExpectationsWereMet").Call()),
+			jen.If(jen.Id("tt").Dot("wantErr").Op("!=").Nil()).Block(
+				jen.Qual(assertPath, "Equal").Call(t, jen.Id("tt").Dot("wantErr"), jen.Err()),
+				jen.Return(),
+			),
+		}
+	}
+	run = append(run, jen.Qual(assertPath, "NoError").Call(t, jen.Err()))
+	run = append(run, check...)
+
+	for _, c := range cases {
+		kv := []jen.Code{
+			jen.Line().Add(jen.Id("name").Op(":").Lit(c.name)),
+			jen.Line().Add(jen.Id(fieldName).Op(":").Func().Params(mockParam).Block(c.mock...)),
+		}
+		if c.wantErr != nil {
+			kv = append(kv, jen.Line().Add(jen.Id("wantErr").Op(":").Add(c.wantErr)))
+		}
+		values = append(values, jen.Line().Values(append(kv, jen.Line())...))
+	}
+
+	fields := []jen.Code{
+		jen.Id("name").String(),
+		jen.Id(fieldName).Func().Params(mockParam),
+		jen.Id("wantErr").Error(),
+	}
+
+	body := []jen.Code{jen.Id("errDatabase").Op(":=").Qual("errors", "New").Call(jen.Lit("unexpected error of database"))}
+	body = append(body, vars...)
+	body = append(body, jen.Line())
+	body = append(body,
+		jen.Id("tests").Op(":=").Index().Struct(fields...).Values(append(values, jen.Line())...),
+		jen.Line(),
+	)
+
+	if rt.dbHelper == domain.Mongod {
+		body = append(body,
+			jen.Id("mt").Op(":=").Qual(mtestPath, "New").Call(jen.Id("t"), jen.Qual(mtestPath, "NewOptions").Call().Dot("ClientType").Call(jen.Qual(mtestPath, "Mock"))),
+			jen.For(jen.List(jen.Id("_"), jen.Id("tt")).Op(":=").Range().Id("tests")).Block(
+				jen.Id("tt").Op(":=").Id("tt"),
+				jen.Id("mt").Dot("Run").Call(jen.Id("tt").Dot("name"), jen.Func().Params(jen.Id("mt").Op("*").Qual(mtestPath, "T")).Block(run...)),
+			),
+		)
+	} else {
+		body = append(body,
+			jen.For(jen.List(jen.Id("_"), jen.Id("tt")).Op(":=").Range().Id("tests")).Block(
+				jen.Id("tt").Op(":=").Id("tt"),
+				jen.Id("t").Dot("Run").Call(jen.Id("tt").Dot("name"), jen.Func().Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(run...)),
+			),
+		)
+	}
+
+	rt.f.Func().Id(testName).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(body...)
+}
+
+// sqlCases will generate cases of database/sql and sqlx, the expected query is exactly the query of repository
+func (rt *repositoryTest) sqlCases(op *crudOperation, callArgs []jen.Code) (vars []jen.Code, cases []repositoryCase) {
+	var (
+		e         = rt.entity
+		d         = rt.dialect
+		query     string
+		args      []jen.Code
+		expect    = jen.Id("mock").Dot("ExpectExec").Call(jen.Id("query"))
+		success   = jen.Dot("WillReturnResult").Call(jen.Qual(sqlmockPath, "NewResult").Call(jen.Lit(0), jen.Lit(1)))
+		notFound  = jen.Dot("WillReturnResult").Call(jen.Qual(sqlmockPath, "NewResult").Call(jen.Lit(0), jen.Lit(0)))
+		dbError   = jen.Dot("WillReturnError").Call(jen.Id("errDatabase"))
+		prepared  = rt.dbHelper == domain.SQL
+		autoIncID = false
+	)
+
+	switch op.kind {
+	case crudFetch:
+		var conditions []string
+		for n, filter := range op.filters {
+			conditions = append(conditions, filter.column+" = "+placeholder(d, n+1))
+		}
+		query = "SELECT " + e.selectColumns() + " FROM " + e.table + e.where(conditions...)
+		args = callArgs[1:]
+		prepared = false
+	case crudGetByID:
+		query = "SELECT " + e.selectColumns() + " FROM " + e.table + e.where(e.primary.name+" = "+placeholder(d, 1))
+		args = callArgs[1:]
+		prepared = false
+	case crudStore:
+		var (
+			columns []string
+			values  []string
+		)
+		for n, c := range e.insertColumns() {
+			columns = append(columns, c.name)
+			values = append(values, placeholder(d, n+1))
+		}
+		query = "INSERT INTO " + e.table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(values, ", ") + ")"
+		args = rt.writeArgs(e.insertColumns(), !e.autoIncrement())
+		if e.autoIncrement() && d == domain.Postgres {
+			// the generated id is returned by the query
+			query += " RETURNING " + e.primary.name
+			expect = jen.Id("mock").Dot("ExpectQuery").Call(jen.Id("query"))
+			success = jen.Dot("WillReturnRows").Call(jen.Qual(sqlmockPath, "NewRows").Call(jen.Index().String().Values(jen.Lit(e.primary.name))).Dot("AddRow").Call(rt.value(e.primary.field.Type)))
+			prepared = true
+		} else if e.autoIncrement() {
+			autoIncID = true
+		}
+	case crudUpdate:
+		var set []string
+		for n, c := range e.updateColumns() {
+			set = append(set, c.name+" = "+placeholder(d, n+1))
+		}
+		query = "UPDATE " + e.table + " SET " + strings.Join(set, ", ") + e.where(e.primary.name+" = "+placeholder(d, len(set)+1))
+		args = append(rt.writeArgs(e.updateColumns(), true), rt.value(e.primary.field.Type))
+	case crudDelete:
+		if e.softDelete != nil {
+			query = "UPDATE " + e.table + " SET " + e.softDelete.name + " = " + placeholder(d, 1) + e.where(e.primary.name+" = "+placeholder(d, 2))
+			args = append([]jen.Code{jen.Qual(sqlmockPath, "AnyArg").Call()}, callArgs[1:]...)
+		} else {
+			query = "DELETE FROM " + e.table + " WHERE " + e.primary.name + " = " + placeholder(d, 1)
+			args = callArgs[1:]
+		}
+		prepared = rt.dbHelper == domain.SQL
+	}
+	if autoIncID {
+		success = jen.Dot("WillReturnResult").Call(jen.Qual(sqlmockPath, "NewResult").Call(jen.Lit(1), jen.Lit(1)))
+	}
+
+	// writes of database/sql are done by prepared statement, so is the query of postgres which return the id
+	if prepared {
+		method := "ExpectExec"
+		if op.kind == crudStore && e.autoIncrement() && d == domain.Postgres {
+			method = "ExpectQuery"
+		}
+		expect = jen.Id("mock").Dot("ExpectPrepare").Call(jen.Id("query")).Dot(method).Call()
+	}
+	if len(args) > 0 {
+		expect = expect.Clone().Dot("WithArgs").Call(args...)
+	}
+
+	vars = append(vars, jen.Id("query").Op(":=").Lit(query))
+
+	switch op.kind {
+	case crudFetch, crudGetByID:
+		expect = jen.Id("mock").Dot("ExpectQuery").Call(jen.Id("query"))
+		if len(args) > 0 {
+			expect = expect.Clone().Dot("WithArgs").Call(args...)
+		}
+		vars = append(vars, jen.Id("columns").Op(":=").Index().String().ValuesFunc(func(g *jen.Group) {
+			for _, c := range e.columns {
+				g.Lit(c.name)
+			}
+		}))
+		if rows, ok := rt.rows(); ok {
+			cases = append(cases, repositoryCase{name: "success", mock: []jen.Code{expect.Clone().Dot("WillReturnRows").Call(rows)}})
+		}
+		if op.kind == crudGetByID {
+			cases = append(cases, repositoryCase{
+				name:    "error, should return domain.ErrNotFound when no row is found",
+				mock:    []jen.Code{expect.Clone().Dot("WillReturnRows").Call(jen.Qual(sqlmockPath, "NewRows").Call(jen.Id("columns")))},
+				wantErr: jen.Qual(rt.domain, "ErrNotFound"),
+			})
+		}
+	default:
+		cases = append(cases, repositoryCase{name: "success", mock: []jen.Code{expect.Clone().Add(success)}})
+		if op.kind != crudStore {
+			cases = append(cases, repositoryCase{
+				name:    "error, should return domain.ErrNotFound when no row is affected",
+				mock:    []jen.Code{expect.Clone().Add(notFound)},
+				wantErr: jen.Qual(rt.domain, "ErrNotFound"),
+			})
+		}
+	}
+	cases = append(cases, repositoryCase{
+		name:    "error, should return error of database",
+		mock:    []jen.Code{expect.Clone().Add(dbError)},
+		wantErr: jen.Id("errDatabase"),
+	})
+	return vars, cases
+}
+
+// gormCases will generate cases of gorm, query is built by gorm so only the start of query is matched,
+// every write of gorm runs inside a transaction
+func (rt *repositoryTest) gormCases(op *crudOperation) (vars []jen.Code, cases []repositoryCase) {
+	var (
+		e     = rt.entity
+		table = `"` + e.table + `"`
+		query string
+		args  []jen.Code
+	)
+
+	switch op.kind {
+	case crudFetch:
+		query = "SELECT * FROM " + table
+		for _, filter := range op.filters {
+			args = append(args, rt.value(e.column(filter.column).field.Type))
+		}
+	case crudGetByID:
+		query = "SELECT * FROM " + table
+		args = append(args, rt.value(e.primary.field.Type))
+	case crudStore:
+		query = "INSERT INTO " + table
+	case crudUpdate:
+		query = "UPDATE " + table + " SET"
+	case crudDelete:
+		query = "DELETE FROM " + table
+		if e.column("deleted_at") != nil && e.column("deleted_at").field.Name == "DeletedAt" {
+			query = "UPDATE " + table + " SET " + `"deleted_at"`
+		}
+	}
+	vars = append(vars, jen.Id("query").Op(":=").Qual("regexp", "QuoteMeta").Call(jen.Lit(query)))
+
+	switch op.kind {
+	case crudFetch, crudGetByID:
+		expect := jen.Id("mock").Dot("ExpectQuery").Call(jen.Id("query"))
+		if len(args) > 0 {
+			expect = expect.Clone().Dot("WithArgs").Call(args...)
+		}
+		vars = append(vars, jen.Id("columns").Op(":=").Index().String().ValuesFunc(func(g *jen.Group) {
+			for _, c := range e.columns {
+				g.Lit(c.name)
+			}
+		}))
+		if rows, ok := rt.rows(); ok {
+			cases = append(cases, repositoryCase{name: "success", mock: []jen.Code{expect.Clone().Dot("WillReturnRows").Call(rows)}})
+		}
+		if op.kind == crudGetByID {
+			cases = append(cases, repositoryCase{
+				name:    "error, should return domain.ErrNotFound when no row is found",
+				mock:    []jen.Code{expect.Clone().Dot("WillReturnRows").Call(jen.Qual(sqlmockPath, "NewRows").Call(jen.Id("columns")))},
+				wantErr: jen.Qual(rt.domain, "ErrNotFound"),
+			})
+		}
+		cases = append(cases, repositoryCase{
+			name:    "error, should return error of database",
+			mock:    []jen.Code{expect.Clone().Dot("WillReturnError").Call(jen.Id("errDatabase"))},
+			wantErr: jen.Id("errDatabase"),
+		})
+		return vars, cases
+	}
+
+	var (
+		begin    = jen.Id("mock").Dot("ExpectBegin").Call()
+		commit   = jen.Id("mock").Dot("ExpectCommit").Call()
+		rollback = jen.Id("mock").Dot("ExpectRollback").Call()
+		expect   = jen.Id("mock").Dot("ExpectExec").Call(jen.Id("query"))
+		success  = jen.Dot("WillReturnResult").Call(jen.Qual(sqlmockPath, "NewResult").Call(jen.Lit(0), jen.Lit(1)))
+	)
+	if op.kind == crudStore {
+		// postgres dialect of gorm return the primary key of inserted row
+		expect = jen.Id("mock").Dot("ExpectQuery").Call(jen.Id("query"))
+		success = jen.Dot("WillReturnRows").Call(jen.Qual(sqlmockPath, "NewRows").Call(jen.Index().String().Values(jen.Lit(e.primary.name))).Dot("AddRow").Call(rt.value(e.primary.field.Type)))
+	}
+
+	cases = append(cases, repositoryCase{name: "success", mock: []jen.Code{begin, expect.Clone().Add(success), commit}})
+	if op.kind != crudStore {
+		cases = append(cases, repositoryCase{
+			name:    "error, should return domain.ErrNotFound when no row is affected",
+			mock:    []jen.Code{begin, expect.Clone().Dot("WillReturnResult").Call(jen.Qual(sqlmockPath, "NewResult").Call(jen.Lit(0), jen.Lit(0))), commit},
+			wantErr: jen.Qual(rt.domain, "ErrNotFound"),
+		})
+	}
+	cases = append(cases, repositoryCase{
+		name:    "error, should return error of database",
+		mock:    []jen.Code{begin, expect.Clone().Dot("WillReturnError").Call(jen.Id("errDatabase")), rollback},
+		wantErr: jen.Id("errDatabase"),
+	})
+	return vars, cases
+}
+
+// mongodCases will generate cases of mongo-driver, the replies of server are queued into mock deployment
+func (rt *repositoryTest) mongodCases(op *crudOperation) (cases []repositoryCase) {
+	var (
+		e        = rt.entity
+		ns       = "test." + e.table
+		add      = jen.Id("mt").Dot("AddMockResponses")
+		dbError  = add.Clone().Call(jen.Qual(mtestPath, "CreateCommandErrorResponse").Call(jen.Qual(mtestPath, "CommandError").Values(field("Code", jen.Lit(1)), field("Message", jen.Id("errDatabase").Dot("Error").Call()))))
+		modified = func(n int) jen.Code {
+			return add.Clone().Call(jen.Qual(mtestPath, "CreateSuccessResponse").Call(
+				jen.Qual("go.mongodb.org/mongo-driver/bson", "E").Values(field("Key", jen.Lit("n")), field("Value", jen.Lit(n))),
+				jen.Qual("go.mongodb.org/mongo-driver/bson", "E").Values(field("Key", jen.Lit("nModified")), field("Value", jen.Lit(n))),
+			))
+		}
+		cursor = func(docs ...jen.Code) jen.Code {
+			return add.Clone().Call(jen.Qual(mtestPath, "CreateCursorResponse").Call(append([]jen.Code{jen.Lit(0), jen.Lit(ns), jen.Qual(mtestPath, "FirstBatch")}, docs...)...))
+		}
+		notFound = "error, should return domain.ErrNotFound when no document is affected"
+	)
+
+	switch op.kind {
+	case crudFetch, crudGetByID:
+		cases = append(cases, repositoryCase{name: "success", mock: []jen.Code{cursor(rt.document())}})
+		if op.kind == crudGetByID {
+			cases = append(cases, repositoryCase{name: "error, should return domain.ErrNotFound when no document is found", mock: []jen.Code{cursor()}, wantErr: jen.Qual(rt.domain, "ErrNotFound")})
+		}
+	case crudStore:
+		var mock []jen.Code
+		if e.autoIncrement() {
+			// integer id is taken from the counter before the document is inserted
+			pk := rt.value(e.primary.field.Type)
+			mock = append(mock, add.Clone().Call(jen.Qual(mtestPath, "CreateSuccessResponse").Call(
+				jen.Qual("go.mongodb.org/mongo-driver/bson", "E").Values(field("Key", jen.Lit("value")), field("Value", jen.Qual("go.mongodb.org/mongo-driver/bson", "D").Values(
+					jen.Values(field("Key", jen.Lit("seq")), field("Value", pk)),
+				))),
+			)))
+		}
+		mock = append(mock, add.Clone().Call(jen.Qual(mtestPath, "CreateSuccessResponse").Call()))
+		cases = append(cases, repositoryCase{name: "success", mock: mock})
+	case crudUpdate:
+		cases = append(cases,
+			repositoryCase{name: "success", mock: []jen.Code{modified(1)}},
+			repositoryCase{name: notFound, mock: []jen.Code{modified(0)}, wantErr: jen.Qual(rt.domain, "ErrNotFound")},
+		)
+	case crudDelete:
+		if e.softDelete == nil {
+			deleted := func(n int) jen.Code {
+				return add.Clone().Call(jen.Qual(mtestPath, "CreateSuccessResponse").Call(
+					jen.Qual("go.mongodb.org/mongo-driver/bson", "E").Values(field("Key", jen.Lit("n")), field("Value", jen.Lit(n))),
+				))
+			}
+			cases = append(cases,
+				repositoryCase{name: "success", mock: []jen.Code{deleted(1)}},
+				repositoryCase{name: notFound, mock: []jen.Code{deleted(0)}, wantErr: jen.Qual(rt.domain, "ErrNotFound")},
+			)
+			break
+		}
+		cases = append(cases,
+			repositoryCase{name: "success", mock: []jen.Code{modified(1)}},
+			repositoryCase{name: notFound, mock: []jen.Code{modified(0)}, wantErr: jen.Qual(rt.domain, "ErrNotFound")},
+		)
+	}
+
+	return append(cases, repositoryCase{name: "error, should return error of database", mock: []jen.Code{dbError}, wantErr: jen.Id("errDatabase")})
+}
+
+// genGopgDB will generate gopgDB, the stand-in of go-pg database which is shared by tests of every go-pg repository,
+// query is formatted by go-pg itself and the queued reply is scanned into its model instead of being sent to a server
+func (gen *caGen) genGopgDB(dirName string) error {
+	var (
+		f       = jen.NewFile("repository_test")
+		orm     = gopgPath + "/orm"
+		ctx     = jen.Id("c").Qual("context", "Context")
+		query   = []jen.Code{ctx, jen.Id("query").Interface(), jen.Id("params").Op("...").Interface()}
+		results = []jen.Code{jen.Qual(orm, "Result"), jen.Error()}
+		recv    = jen.Id("db").Op("*").Id("gopgDB")
+		ifErr   = jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err()))
+	)
+
+	f.ImportAlias(gopgPath, "pg")
+	f.ImportName(orm, "orm")
+	f.ImportName(gopgPath+"/types", "types")
+
+	f.Comment("gopgReply is the reply of gopgDB to a single query, value of every column is written in text format of postgres")
+	f.Type().Id("gopgReply").Struct(
+		jen.Id("rows").Index().Map(jen.String()).String(),
+		jen.Id("affected").Int(),
+		jen.Id("err").Error(),
+	)
+
+	f.Line()
+	f.Comment("gopgResult is orm.Result of the reply")
+	f.Type().Id("gopgResult").Struct(
+		jen.Id("model").Qual(orm, "Model"),
+		jen.Id("affected").Int(),
+		jen.Id("returned").Int(),
+	)
+	for _, m := range []struct {
+		name, field string
+		typ         jen.Code
+	}{
+		{"Model", "model", jen.Qual(orm, "Model")},
+		{"RowsAffected", "affected", jen.Int()},
+		{"RowsReturned", "returned", jen.Int()},
+	} {
+		f.Line()
+		f.Func().Params(jen.Id("r").Id("gopgResult")).Id(m.name).Params().Add(m.typ).Block(jen.Return(jen.Id("r").Dot(m.field)))
+	}
+
+	f.Line()
+	f.Comment("gopgDB is the stand-in of go-pg database which reply the queued replies in order and record every query,")
+	f.Comment("methods of orm.DB which are not used by repository are left to the embedded nil interface")
+	f.Type().Id("gopgDB").Struct(
+		jen.Qual(orm, "DB"),
+		jen.Id("queries").Index().String(),
+		jen.Id("replies").Index().Id("gopgReply"),
+	)
+
+	f.Line()
+	f.Comment("reply will queue the reply of the next query")
+	f.Func().Params(recv).Id("reply").Params(jen.Id("r").Id("gopgReply")).Block(
+		jen.Id("db").Dot("replies").Op("=").Append(jen.Id("db").Dot("replies"), jen.Id("r")),
+	)
+
+	f.Line()
+	f.Func().Params(recv).Id("Context").Params().Qual("context", "Context").Block(
+		jen.Return(jen.Qual("context", "Background").Call()),
+	)
+
+	f.Line()
+	f.Func().Params(recv).Id("Formatter").Params().Qual(orm, "QueryFormatter").Block(
+		jen.Return(jen.Qual(orm, "NewFormatter").Call()),
+	)
+
+	f.Line()
+	f.Func().Params(recv).Id("Model").Params(jen.Id("model").Op("...").Interface()).Op("*").Qual(orm, "Query").Block(
+		jen.Return(jen.Qual(orm, "NewQuery").Call(jen.Id("db"), jen.Id("model").Op("..."))),
+	)
+
+	f.Line()
+	f.Func().Params(recv).Id("ModelContext").Params(ctx, jen.Id("model").Op("...").Interface()).Op("*").Qual(orm, "Query").Block(
+		jen.Return(jen.Qual(orm, "NewQueryContext").Call(jen.Id("c"), jen.Id("db"), jen.Id("model").Op("..."))),
+	)
+
+	f.Line()
+	f.Func().Params(recv).Id("ExecContext").Params(query...).Params(results...).Block(
+		jen.Return(jen.Id("db").Dot("QueryContext").Call(jen.Id("c"), jen.Nil(), jen.Id("query"), jen.Id("params").Op("..."))),
+	)
+
+	f.Line()
+	f.Func().Params(recv).Id("ExecOneContext").Params(query...).Params(results...).Block(
+		jen.Return(jen.Id("db").Dot("QueryOneContext").Call(jen.Id("c"), jen.Nil(), jen.Id("query"), jen.Id("params").Op("..."))),
+	)
+
+	f.Line()
+	f.Comment("QueryOneContext will return pg.ErrNoRows as go-pg does when no row is affected")
+	f.Func().Params(recv).Id("QueryOneContext").Params(append([]jen.Code{ctx, jen.Id("model")}, query[1:]...)...).Params(results...).Block(
+		jen.List(jen.Id("res"), jen.Err()).Op(":=").Id("db").Dot("QueryContext").Call(jen.Id("c"), jen.Id("model"), jen.Id("query"), jen.Id("params").Op("...")),
+		ifErr,
+		jen.If(jen.Id("res").Dot("RowsAffected").Call().Op("==").Lit(0)).Block(jen.Return(jen.Nil(), jen.Qual(gopgPath, "ErrNoRows"))),
+		jen.Return(jen.Id("res"), jen.Nil()),
+	)
+
+	f.Line()
+	f.Comment("QueryContext will record the query and scan rows of the next reply into the model")
+	f.Func().Params(recv).Id("QueryContext").Params(append([]jen.Code{ctx, jen.Id("model")}, query[1:]...)...).Params(results...).Block(
+		jen.Var().Defs(
+			jen.Id("fmter").Op("=").Qual(orm, "NewFormatter").Call(),
+			jen.Id("q").Index().Byte(),
+			jen.Err().Error(),
+		),
+		jen.If(jen.List(jen.Id("appender"), jen.Id("ok")).Op(":=").Id("query").Assert(jen.Qual(orm, "QueryAppender")), jen.Id("ok")).Block(
+			jen.List(jen.Id("q"), jen.Err()).Op("=").Id("appender").Dot("AppendQuery").Call(jen.Id("fmter").Dot("WithModel").Call(jen.Id("appender")), jen.Nil()),
+		).Else().Block(
+			jen.Id("q").Op("=").Id("fmter").Dot("FormatQuery").Call(jen.Nil(), jen.Qual("fmt", "Sprint").Call(jen.Id("query")), jen.Id("params").Op("...")),
+		),
+		ifErr,
+		jen.Id("db").Dot("queries").Op("=").Append(jen.Id("db").Dot("queries"), jen.String().Call(jen.Id("q"))),
+		jen.Line(),
+		jen.If(jen.Len(jen.Id("db").Dot("replies")).Op("==").Lit(0)).Block(
+			jen.Return(jen.Nil(), jen.Qual("fmt", "Errorf").Call(jen.Lit("unexpected query: %s"), jen.Id("q"))),
+		),
+		jen.Id("r").Op(":=").Id("db").Dot("replies").Index(jen.Lit(0)),
+		jen.Id("db").Dot("replies").Op("=").Id("db").Dot("replies").Index(jen.Lit(1), jen.Empty()),
+		jen.If(jen.Id("r").Dot("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("r").Dot("err"))),
+		jen.Line(),
+		jen.Id("res").Op(":=").Id("gopgResult").Values(field("affected", jen.Id("r").Dot("affected")), field("returned", jen.Len(jen.Id("r").Dot("rows")))),
+		jen.List(jen.Id("m"), jen.Id("ok")).Op(":=").Id("model").Assert(jen.Qual(orm, "Model")),
+		jen.If(jen.Op("!").Id("ok").Op("||").Len(jen.Id("r").Dot("rows")).Op("==").Lit(0)).Block(jen.Return(jen.Id("res"), jen.Nil())),
+		jen.Line(),
+		jen.If(jen.Err().Op(":=").Id("m").Dot("Init").Call(), jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+		jen.For(jen.List(jen.Id("_"), jen.Id("row")).Op(":=").Range().Id("r").Dot("rows")).Block(
+			jen.List(jen.Id("scanner"), jen.Id("i")).Op(":=").List(jen.Id("m").Dot("NextColumnScanner").Call(), jen.Lit(0)),
+			jen.For(jen.List(jen.Id("column"), jen.Id("value")).Op(":=").Range().Id("row")).Block(
+				jen.Id("rd").Op(":=").Qual(gopgPath+"/types", "NewBytesReader").Call(jen.Index().Byte().Call(jen.Id("value"))),
+				jen.If(jen.Err().Op(":=").Id("scanner").Dot("ScanColumn").Call(jen.Id("i"), jen.Id("column"), jen.Id("rd"), jen.Len(jen.Id("value"))), jen.Err().Op("!=").Nil()).Block(
+					jen.Return(jen.Nil(), jen.Err()),
+				),
+				jen.Id("i").Op("++"),
+			),
+			jen.If(jen.Err().Op(":=").Id("m").Dot("AddColumnScanner").Call(jen.Id("scanner")), jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Nil(), jen.Err()),
+			),
+		),
+		jen.List(jen.Id("res").Dot("model"), jen.Id("res").Dot("affected")).Op("=").List(jen.Id("m"), jen.Len(jen.Id("r").Dot("rows"))),
+		jen.Return(jen.Id("res"), jen.Nil()),
+	)
+
+	return gen.save(f, dirName+"/gopg_db_test.go")
+}
+
+// gopgCases will generate cases of go-pg, the replies of database are queued into gopgDB
+func (rt *repositoryTest) gopgCases(op *crudOperation) (cases []repositoryCase) {
+	var (
+		e     = rt.entity
+		reply = func(values ...jen.Code) jen.Code {
+			return jen.Id("db").Dot("reply").Call(jen.Id("gopgReply").Values(values...))
+		}
+		affected = func(n int) jen.Code {
+			return reply(field("affected", jen.Lit(n)))
+		}
+	)
+
+	switch op.kind {
+	case crudFetch, crudGetByID:
+		cases = append(cases, repositoryCase{name: "success", mock: []jen.Code{reply(field("rows", rt.gopgRows(e.columns)))}})
+		if op.kind == crudGetByID {
+			cases = append(cases, repositoryCase{name: "error, should return domain.ErrNotFound when no row is found", mock: []jen.Code{reply()}, wantErr: jen.Qual(rt.domain, "ErrNotFound")})
+		}
+	case crudStore:
+		mock := affected(1)
+		if e.autoIncrement() {
+			// the generated id is returned by the query
+			mock = reply(field("rows", rt.gopgRows([]sqlColumn{*e.primary})))
+		}
+		cases = append(cases, repositoryCase{name: "success", mock: []jen.Code{mock}})
+	default:
+		cases = append(cases,
+			repositoryCase{name: "success", mock: []jen.Code{affected(1)}},
+			repositoryCase{name: "error, should return domain.ErrNotFound when no row is affected", mock: []jen.Code{affected(0)}, wantErr: jen.Qual(rt.domain, "ErrNotFound")},
+		)
+	}
+
+	return append(cases, repositoryCase{name: "error, should return error of database", mock: []jen.Code{reply(field("err", jen.Id("errDatabase")))}, wantErr: jen.Id("errDatabase")})
+}
+
+// gopgRows will generate a single row of the given columns in text format of postgres, column which has unknown value is omitted
+func (rt *repositoryTest) gopgRows(columns []sqlColumn) jen.Code {
+	row := jen.Dict{}
+	for _, c := range columns {
+		var text string
+		switch {
+		case c.field.Type == "string":
+			text = "test"
+		case c.field.Type == "bool":
+			text = "t"
+		case isBasicType(c.field.Type) && !strings.HasPrefix(c.field.Type, "complex"):
+			text = "1"
+		default:
+			continue
+		}
+		row[jen.Lit(c.name)] = jen.Lit(text)
+	}
+	return jen.Index().Map(jen.String()).String().Values(jen.Values(row))
+}
+
+// fixture will generate the entity which is written by the test, every field which has known test value is filled
+func (rt *repositoryTest) fixture(withPrimary bool) jen.Code {
+	var values []jen.Code
+	for _, c := range rt.entity.columns {
+		if c.name == rt.entity.primary.name && !withPrimary || isTimeType(c.field.Type) {
+			continue
+		}
+		if value, ok := fieldTestValue(c.field.Type); ok {
+			values = append(values, field(c.field.Name, value))
+		}
+	}
+	return jen.Op("&").Qual(rt.domain, rt.entity.name).Values(values...)
+}
+
+// writeArgs will generate the expected arguments of insert or update query based on the fixture,
+// time is stamped by repository and unknown value is not filled, so both of them match any argument
+func (rt *repositoryTest) writeArgs(columns []sqlColumn, withPrimary bool) []jen.Code {
+	var args []jen.Code
+	for _, c := range columns {
+		value, ok := fieldTestValue(c.field.Type)
+		if !ok || isTimeType(c.field.Type) || (c.name == rt.entity.primary.name && !withPrimary) {
+			value = jen.Qual(sqlmockPath, "AnyArg").Call()
+		}
+		args = append(args, value)
+	}
+	return args
+}
+
+// rows will generate a single row of every column, false if value of some column is unknown
+func (rt *repositoryTest) rows() (jen.Code, bool) {
+	var values []jen.Code
+	for _, c := range rt.entity.columns {
+		switch {
+		case c.field.Type == "time.Time":
+			values = append(values, jen.Qual("time", "Now").Call())
+		case strings.HasPrefix(c.field.Type, "*"):
+			values = append(values, jen.Nil())
+		default:
+			value, ok := fieldTestValue(c.field.Type)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, value)
+		}
+	}
+	return jen.Qual(sqlmockPath, "NewRows").Call(jen.Id("columns")).Dot("AddRow").Call(values...), true
+}
+
+// document will generate document of entity which is replied by mock deployment, field which has unknown value is omitted
+func (rt *repositoryTest) document() jen.Code {
+	var elements []jen.Code
+	for _, c := range rt.entity.columns {
+		if isTimeType(c.field.Type) {
+			continue
+		}
+		if value, ok := fieldTestValue(c.field.Type); ok {
+			elements = append(elements, jen.Values(field("Key", jen.Lit(c.name)), field("Value", value)))
+		}
+	}
+	return jen.Qual("go.mongodb.org/mongo-driver/bson", "D").Values(elements...)
+}
+
+// fieldTestValue will return literal of the given type which is used by generated repository test, false if the type is unknown
+func fieldTestValue(typ string) (jen.Code, bool) {
+	switch {
+	case typ == "string":
+		return jen.Lit("test"), true
+	case typ == "bool":
+		return jen.True(), true
+	case typ == "int":
+		return jen.Lit(1), true
+	case isBasicType(typ):
+		return jen.Id(typ).Call(jen.Lit(1)), true
+	case typ == "[]byte":
+		return jen.Index().Byte().Call(jen.Lit("test")), true
+	}
+	return nil, false
+}
+
+// value will return test value of the given type, zero value is used if the type has no known literal
+func (rt *repositoryTest) value(typ string) jen.Code {
+	if value, ok := fieldTestValue(typ); ok {
+		return value
+	}
+	value, err := genTestValue(typ, rt.imports)
+	if err != nil {
+		return jen.Nil()
+	}
+	return value
+}
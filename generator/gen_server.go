@@ -10,15 +10,16 @@ import (
 
 	"github.com/dave/jennifer/jen"
 	"github.com/wicaker/cacli/domain"
-	"github.com/wicaker/cacli/fs"
 	"github.com/wicaker/cacli/parser"
 )
 
-type genServer struct{}
+type genServer struct {
+	fs domain.FsService
+}
 
 func (gen *caGen) GenEchoServer(dirName string, serviceName string, repoLib string, gomodName string, parser *domain.Parser) error {
 	var (
-		genServer  genServer
+		genServer  = genServer{fs: gen.fs}
 		f          = jen.NewFile("server")
 		genCode    []jen.Code
 		importName = map[string]string{
@@ -46,6 +47,9 @@ func (gen *caGen) GenEchoServer(dirName string, serviceName string, repoLib stri
 	genCode = append(genCode, jen.Id("middl").Op(":=").Qual(gomodName+"/middleware", "InitEchoMiddleware").Call())
 	genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Id("middl").Dot("MiddlewareLogging")))
 	genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Id("middl").Dot("CORS")))
+	if gen.gen.ProblemJSON {
+		genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Id("middl").Dot("Recover")))
+	}
 	genCode = append(genCode, jen.Line())
 	genCode = append(genCode, jen.Id("timeoutContext").Op(":=").Qual("time", "Duration").Call(jen.Lit(2)).Op("*").Qual("time", "Second"))
 	genCode = append(genCode, jen.Line())
@@ -69,7 +73,7 @@ func (gen *caGen) GenEchoServer(dirName string, serviceName string, repoLib stri
 	)
 
 	fileDir := fmt.Sprintf("%s/echo_server.go", dirName)
-	err = f.Save(fileDir)
+	err = gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -79,7 +83,7 @@ func (gen *caGen) GenEchoServer(dirName string, serviceName string, repoLib stri
 
 func (gen *caGen) GenGinServer(dirName string, serviceName string, repoLib string, gomodName string, parser *domain.Parser) error {
 	var (
-		genServer  genServer
+		genServer  = genServer{fs: gen.fs}
 		f          = jen.NewFile("server")
 		genCode    []jen.Code
 		importName = map[string]string{
@@ -111,7 +115,11 @@ func (gen *caGen) GenGinServer(dirName string, serviceName string, repoLib strin
 	genCode = append(genCode, jen.Id("middl").Op(":=").Qual(gomodName+"/middleware", "InitGinMiddleware").Call())
 	genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Id("middl").Dot("MiddlewareLogging").Call()))
 	genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Id("middl").Dot("CORS").Call()))
-	genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Qual("github.com/gin-gonic/gin", "Recovery").Call()))
+	if gen.gen.ProblemJSON {
+		genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Id("middl").Dot("Recover").Call()))
+	} else {
+		genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Qual("github.com/gin-gonic/gin", "Recovery").Call()))
+	}
 	genCode = append(genCode, jen.Line())
 	genCode = append(genCode, jen.Id("timeoutContext").Op(":=").Qual("time", "Duration").Call(jen.Lit(2)).Op("*").Qual("time", "Second"))
 	genCode = append(genCode, jen.Line())
@@ -135,7 +143,7 @@ func (gen *caGen) GenGinServer(dirName string, serviceName string, repoLib strin
 	)
 
 	fileDir := fmt.Sprintf("%s/gin_server.go", dirName)
-	err = f.Save(fileDir)
+	err = gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -145,7 +153,7 @@ func (gen *caGen) GenGinServer(dirName string, serviceName string, repoLib strin
 
 func (gen *caGen) GenGorillaMuxServer(dirName string, serviceName string, repoLib string, gomodName string, parser *domain.Parser) error {
 	var (
-		genServer  genServer
+		genServer  = genServer{fs: gen.fs}
 		f          = jen.NewFile("server")
 		genCode    []jen.Code
 		importName = map[string]string{
@@ -173,6 +181,9 @@ func (gen *caGen) GenGorillaMuxServer(dirName string, serviceName string, repoLi
 	genCode = append(genCode, jen.Id("middl").Op(":=").Qual(gomodName+"/middleware", "InitGorillaMuxMiddleware").Call())
 	genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Qual("github.com/gorilla/mux", "CORSMethodMiddleware").Call(jen.Id("r"))))
 	genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Id("middl").Dot("MiddlewareLogging")))
+	if gen.gen.ProblemJSON {
+		genCode = append(genCode, jen.Id("r").Dot("Use").Call(jen.Id("middl").Dot("Recover")))
+	}
 	genCode = append(genCode, jen.Line())
 	genCode = append(genCode, jen.Id("timeoutContext").Op(":=").Qual("time", "Duration").Call(jen.Lit(2)).Op("*").Qual("time", "Second"))
 	genCode = append(genCode, jen.Line())
@@ -196,7 +207,7 @@ func (gen *caGen) GenGorillaMuxServer(dirName string, serviceName string, repoLi
 	)
 
 	fileDir := fmt.Sprintf("%s/gorilla_mux_server.go", dirName)
-	err = f.Save(fileDir)
+	err = gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -206,7 +217,7 @@ func (gen *caGen) GenGorillaMuxServer(dirName string, serviceName string, repoLi
 
 func (gen *caGen) GenNetHTTPMuxServer(dirName string, serviceName string, repoLib string, gomodName string, parser *domain.Parser) error {
 	var (
-		genServer  genServer
+		genServer  = genServer{fs: gen.fs}
 		f          = jen.NewFile("server")
 		genCode    []jen.Code
 		importName = map[string]string{
@@ -232,9 +243,11 @@ func (gen *caGen) GenNetHTTPMuxServer(dirName string, serviceName string, repoLi
 	genCode = append(genCode, jen.Id("r").Op(":=").Qual("net/http", "NewServeMux").Call())
 	genCode = append(genCode, jen.Id("middl").Op(":=").Qual(gomodName+"/middleware", "InitNetHTTPMiddleware").Call())
 	genCode = append(genCode, jen.Var().Id("handler").Qual("net/http", "Handler").Op("=").Id("r"))
+	if gen.gen.ProblemJSON {
+		genCode = append(genCode, jen.Id("handler").Op("=").Id("middl").Dot("Recover").Call(jen.Id("handler")))
+	}
 	genCode = append(genCode, jen.Id("handler").Op("=").Id("middl").Dot("MiddlewareLogging").Call(jen.Id("handler")))
 	genCode = append(genCode, jen.Id("handler").Op("=").Id("middl").Dot("CORS").Call(jen.Id("handler")))
-	genCode = append(genCode, jen.Id("handler").Op("=").Id("parseURL").Call(jen.Id("handler")))
 	genCode = append(genCode, jen.Line())
 	genCode = append(genCode, jen.Id("timeoutContext").Op(":=").Qual("time", "Duration").Call(jen.Lit(2)).Op("*").Qual("time", "Second"))
 	genCode = append(genCode, jen.Line())
@@ -256,29 +269,9 @@ func (gen *caGen) GenNetHTTPMuxServer(dirName string, serviceName string, repoLi
 	f.Func().Id("MuxServer").Params(libRepo).Qual("net/http", "Handler").Block(
 		genCode[:]...,
 	)
-	f.Line()
-	f.Func().Id("parseURL").Params(jen.Id("next").Qual("net/http", "Handler")).Qual("net/http", "Handler").Block(
-		jen.Return(
-			jen.Qual("net/http", "HandlerFunc").Call(jen.Func().Params(
-				jen.Id("w").Qual("net/http", "ResponseWriter"),
-				jen.Id("r").Op("*").Qual("net/http", "Request"),
-			).Block(
-				jen.Id("ctx").Op(":=").Id("r").Dot("Context").Call(),
-				jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-				jen.Id("ctx").Op("=").Qual("context", "WithValue").Call(jen.Id("ctx"), jen.Lit("uri"), jen.Id("r").Dot("RequestURI")),
-				jen.Line(),
-				jen.Id("s").Op(":=").Qual("strings", "Split").Call(jen.Id("r").Dot("RequestURI"), jen.Lit("/")),
-				jen.If(jen.Id("s").Op("[1]==").Lit("example")).Block(
-					jen.Id("r").Dot("URL").Op("=&").Qual("net/url", "URL").Values(jen.Dict{jen.Id("Path"): jen.Lit("/example")}),
-				),
-				jen.Line(),
-				jen.Id("next").Dot("ServeHTTP").Call(jen.Id("w"), jen.Id("r").Dot("WithContext").Call(jen.Id("ctx"))),
-			)),
-		),
-	)
 
 	fileDir := fmt.Sprintf("%s/net_http_mux_server.go", dirName)
-	err = f.Save(fileDir)
+	err = gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -288,7 +281,7 @@ func (gen *caGen) GenNetHTTPMuxServer(dirName string, serviceName string, repoLi
 
 func (gen *caGen) GenGraphqlServer(dirName string, serviceName string, repoLib string, gomodName string, parser *domain.Parser) error {
 	var (
-		genServer  genServer
+		genServer  = genServer{fs: gen.fs}
 		f          = jen.NewFile("server")
 		genCode    []jen.Code
 		importName = map[string]string{
@@ -339,7 +332,7 @@ func (gen *caGen) GenGraphqlServer(dirName string, serviceName string, repoLib s
 	)
 
 	fileDir := fmt.Sprintf("%s/graphql_server.go", dirName)
-	err = f.Save(fileDir)
+	err = gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -349,7 +342,7 @@ func (gen *caGen) GenGraphqlServer(dirName string, serviceName string, repoLib s
 
 func (gen *caGen) GenGrpcServer(dirName string, serviceName string, repoLib string, gomodName string, parser *domain.Parser) error {
 	var (
-		genServer  genServer
+		genServer  = genServer{fs: gen.fs}
 		f          = jen.NewFile("server")
 		genCode    []jen.Code
 		importName = map[string]string{
@@ -399,7 +392,7 @@ func (gen *caGen) GenGrpcServer(dirName string, serviceName string, repoLib stri
 	)
 
 	fileDir := fmt.Sprintf("%s/grpc_server.go", dirName)
-	err = f.Save(fileDir)
+	err = gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
@@ -407,17 +400,16 @@ func (gen *caGen) GenGrpcServer(dirName string, serviceName string, repoLib stri
 	return nil
 }
 
-func (gen *genServer) getRepository(path string, gomodName string) (repo []jen.Code, err error) {
-	newFs := fs.NewFsService()
+func (gen *genServer) getRepository(path string, gomodName string, used map[string]bool) (repo []jen.Code, err error) {
 
-	res, err := newFs.ReadDir("./" + path + "/repository")
+	res, err := gen.fs.ReadDir(path + "/repository")
 	if err != nil {
 		return repo, err
 	}
 
 	for i := range res {
-		if filepath.Ext(res[i].Name()) == ".go" {
-			p := parser.NewParserGeneral()
+		if filepath.Ext(res[i].Name()) == ".go" && !strings.HasSuffix(res[i].Name(), "_test.go") {
+			p := parser.NewParserGeneral(gen.fs)
 			par, err := p.GeneralParser(path + "/repository/" + res[i].Name())
 			if err != nil {
 				return repo, err
@@ -428,6 +420,9 @@ func (gen *genServer) getRepository(path string, gomodName string) (repo []jen.C
 			}
 
 			fileName := reg.ReplaceAllString(res[i].Name(), "")
+			if !used[fileName[:len(fileName)-12]] {
+				continue
+			}
 			repoName := par.Repository.Method[0].Name
 			repo = append(repo, jen.Id(fileName[:len(fileName)-2]).Op(":=").Qual(gomodName+"/repository", repoName).Call(jen.Id("db")))
 		}
@@ -436,17 +431,16 @@ func (gen *genServer) getRepository(path string, gomodName string) (repo []jen.C
 	return repo, err
 }
 
-func (gen *genServer) getUsecase(path string, gomodName string) (usecase []jen.Code, err error) {
-	newFs := fs.NewFsService()
+func (gen *genServer) getUsecase(path string, gomodName string, used map[string]bool) (usecase []jen.Code, err error) {
 
-	res, err := newFs.ReadDir("./" + path + "/usecase")
+	res, err := gen.fs.ReadDir(path + "/usecase")
 	if err != nil {
 		return usecase, err
 	}
 
 	for i := range res {
-		if filepath.Ext(res[i].Name()) == ".go" {
-			p := parser.NewParserGeneral()
+		if filepath.Ext(res[i].Name()) == ".go" && !strings.HasSuffix(res[i].Name(), "_test.go") {
+			p := parser.NewParserGeneral(gen.fs)
 			par, err := p.GeneralParser(path + "/usecase/" + res[i].Name())
 			if err != nil {
 				return usecase, err
@@ -458,8 +452,11 @@ func (gen *genServer) getUsecase(path string, gomodName string) (usecase []jen.C
 			}
 
 			fileName := reg.ReplaceAllString(res[i].Name(), "")
-			usecaseName := par.Usecase.Method[0].Name
 			repoName := fileName[:len(fileName)-9]
+			if !used[repoName] {
+				continue
+			}
+			usecaseName := par.Usecase.Method[0].Name
 			usecase = append(usecase, jen.Id(fileName[:len(fileName)-2]).Op(":=").Qual(gomodName+"/usecase", usecaseName).Call(jen.Id(repoName+"repository"), jen.Id("timeoutContext")))
 		}
 	}
@@ -467,46 +464,62 @@ func (gen *genServer) getUsecase(path string, gomodName string) (usecase []jen.C
 	return usecase, err
 }
 
-func (gen *genServer) getHandler(pathName string, gomodName string, transportType string) (handler []jen.Code, err error) {
+// getHandler will return handler of every domain in the given transport and name of domain which is used by them
+func (gen *genServer) getHandler(pathName string, gomodName string, transportType string) (handler []jen.Code, used map[string]bool, err error) {
 	var usecaseName string
 	pathName = pathName + "/transport/" + transportType
 
-	newFs := fs.NewFsService()
+	used = map[string]bool{}
 
-	res, err := newFs.ReadDir("./" + pathName)
+	res, err := gen.fs.ReadDir(pathName)
 	if err != nil {
-		return handler, err
+		return handler, used, err
 	}
 
 	for i := range res {
-		if filepath.Ext(res[i].Name()) == ".go" {
-			p := parser.NewParserGeneral()
+		if filepath.Ext(res[i].Name()) == ".go" && !strings.HasSuffix(res[i].Name(), "_test.go") {
+			p := parser.NewParserGeneral(gen.fs)
 			par, err := p.GeneralParser(pathName + "/" + res[i].Name())
 			if err != nil {
-				return handler, err
+				return handler, used, err
+			}
+
+			// file which doesn't initialize a handler, e.g. errors.go of grpc, is not served
+			if len(par.Handler.Method) == 0 {
+				continue
 			}
 
 			reg, err := regexp.Compile("[^a-zA-Z0-9]+")
 			if err != nil {
-				return handler, err
+				return handler, used, err
 			}
 
 			fileName := reg.ReplaceAllString(res[i].Name(), "")
 			handlerName := par.Handler.Method[0].Name
 
+			// graphql handler serves every domain which has its types, ordered by name like its parameters
 			if transportType == "graphql" {
-				res, err := newFs.ReadDir("./" + pathName + "/types")
+				res, err := gen.fs.ReadDir(pathName + "/types")
 				if err != nil {
-					return handler, err
+					return handler, used, err
 				}
+				usecases := []jen.Code{jen.Id("r")}
 				for i := range res {
 					file := path.Base(res[i].Name())
+					if filepath.Ext(file) != ".go" {
+						continue
+					}
 					usecaseName = strings.TrimSuffix(file, filepath.Ext(file))
+					used[usecaseName] = true
+					usecases = append(usecases, jen.Id(usecaseName+"usecase"))
 				}
-			} else {
-				usecaseName = fileName[:len(fileName)-9]
+				handler = append(handler, jen.Qual(gomodName+"/transport/"+transportType, handlerName).Call(usecases...))
+				continue
 			}
 
+			usecaseName = fileName[:len(fileName)-9]
+			used[usecaseName] = true
+
 			if transportType == "grpc" {
 				handler = append(handler, jen.Qual(gomodName+"/transport/"+transportType, handlerName).Call(jen.Id("s"), jen.Id(usecaseName+"usecase")))
 			} else {
@@ -515,23 +528,22 @@ func (gen *genServer) getHandler(pathName string, gomodName string, transportTyp
 		}
 	}
 
-	return handler, err
+	return handler, used, err
 }
 
 func (gen *genServer) getAllLayer(serviceName string, gomodName string, transportType string) (usecase []jen.Code, repository []jen.Code, handler []jen.Code, err error) {
-	var genServer genServer
-
-	usecase, err = genServer.getUsecase(serviceName, gomodName)
+	// only domain which has a handler is wired, otherwise the server declare an unused usecase
+	handler, used, err := gen.getHandler(serviceName, gomodName, transportType)
 	if err != nil {
 		return usecase, repository, handler, err
 	}
 
-	repository, err = genServer.getRepository(serviceName, gomodName)
+	usecase, err = gen.getUsecase(serviceName, gomodName, used)
 	if err != nil {
 		return usecase, repository, handler, err
 	}
 
-	handler, err = genServer.getHandler(serviceName, gomodName, transportType)
+	repository, err = gen.getRepository(serviceName, gomodName, used)
 	if err != nil {
 		return usecase, repository, handler, err
 	}
@@ -107,15 +107,12 @@ func GorillaMuxServer(db *sql.DB) *mux.Router {
 	expected_net_http_mux_server = `package server
 
 import (
-	"context"
 	"github.com/example/exampleserver/middleware"
 	"github.com/example/exampleserver/repository"
 	"github.com/example/exampleserver/transport/rest"
 	"github.com/example/exampleserver/usecase"
 	"github.com/jinzhu/gorm"
 	"net/http"
-	"net/url"
-	"strings"
 	"time"
 )
 
@@ -126,7 +123,6 @@ func MuxServer(db *gorm.DB) http.Handler {
 	var handler http.Handler = r
 	handler = middl.MiddlewareLogging(handler)
 	handler = middl.CORS(handler)
-	handler = parseURL(handler)
 
 	timeoutContext := time.Duration(2) * time.Second
 
@@ -136,23 +132,6 @@ func MuxServer(db *gorm.DB) http.Handler {
 
 	return handler
 }
-
-func parseURL(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-		if ctx == nil {
-			ctx = context.Background()
-		}
-		ctx = context.WithValue(ctx, "uri", r.RequestURI)
-
-		s := strings.Split(r.RequestURI, "/")
-		if s[1] == "example" {
-			r.URL = &url.URL{Path: "/example"}
-		}
-
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
-}
 `
 	expected_graphql_server = `package server
 
@@ -262,7 +241,7 @@ func TestGenerateEchoServer(t *testing.T) {
 		}
 
 		// generate echo_server.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenMongodRepository(serviceName+"/repository", domainFile, gomodName, domain.MockParser)
 		err = gen.GenUsecase(serviceName+"/usecase", domainFile, gomodName, domain.MockParser)
 		err = gen.GenEchoTransport(serviceName+"/transport/rest", domainFile, gomodName, domain.MockParser)
@@ -294,7 +273,7 @@ func TestGenerateEchoServer(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate echo_server file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenEchoServer(dirName, serviceName, domain.Sqlx, gomodName, domain.MockParser)
 		assert.Error(t, err)
 	})
@@ -348,7 +327,7 @@ func TestGenerateGinServer(t *testing.T) {
 		}
 
 		// generate gin_server.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGopgRepository(serviceName+"/repository", domainFile, gomodName, domain.MockParser)
 		err = gen.GenUsecase(serviceName+"/usecase", domainFile, gomodName, domain.MockParser)
 		err = gen.GenGinTransport(serviceName+"/transport/rest", domainFile, gomodName, domain.MockParser)
@@ -380,7 +359,7 @@ func TestGenerateGinServer(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate gin_server file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGinServer(dirName, serviceName, domain.Sqlx, gomodName, domain.MockParser)
 		assert.Error(t, err)
 	})
@@ -434,8 +413,8 @@ func TestGenerateGorillaMuxServer(t *testing.T) {
 		}
 
 		// generate gorilla_mux_server.go file
-		gen := generator.NewGeneratorService()
-		err = gen.GenSQLRepository(serviceName+"/repository", domainFile, gomodName, domain.MockParser)
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenSQLRepository(serviceName+"/repository", domainFile, gomodName, domain.MySQL, domain.MockParser)
 		err = gen.GenUsecase(serviceName+"/usecase", domainFile, gomodName, domain.MockParser)
 		err = gen.GenGorillaMuxTransport(serviceName+"/transport/rest", domainFile, gomodName, domain.MockParser)
 		err = gen.GenGorillaMuxServer(dirName, serviceName, domain.SQL, gomodName, domain.MockParser)
@@ -466,7 +445,7 @@ func TestGenerateGorillaMuxServer(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate gorilla_mux_server file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGinServer(dirName, serviceName, domain.Sqlx, gomodName, domain.MockParser)
 		assert.Error(t, err)
 	})
@@ -520,7 +499,7 @@ func TestGenNetHTTPMuxServer(t *testing.T) {
 		}
 
 		// generate net_http_mux_server.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGormRepository(serviceName+"/repository", domainFile, gomodName, domain.MockParser)
 		err = gen.GenUsecase(serviceName+"/usecase", domainFile, gomodName, domain.MockParser)
 		err = gen.GenNetHTTPTransport(serviceName+"/transport/rest", domainFile, gomodName, domain.MockParser)
@@ -552,7 +531,7 @@ func TestGenNetHTTPMuxServer(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate net_http_mux_server file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGinServer(dirName, serviceName, domain.Sqlx, gomodName, domain.MockParser)
 		assert.Error(t, err)
 	})
@@ -606,8 +585,8 @@ func TestGenerateGraphqlServer(t *testing.T) {
 		}
 
 		// generate graphql_server.go file
-		gen := generator.NewGeneratorService()
-		err = gen.GenSQLRepository(serviceName+"/repository", domainFile, gomodName, domain.MockParser)
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenSQLRepository(serviceName+"/repository", domainFile, gomodName, domain.MySQL, domain.MockParser)
 		err = gen.GenUsecase(serviceName+"/usecase", domainFile, gomodName, domain.MockParser)
 		err = gen.GenGraphqlTransport(serviceName+"/transport/graphql", domainFile, gomodName, domain.MockParser)
 		err = gen.GenGraphqlServer(dirName, serviceName, domain.SQL, gomodName, domain.MockParser)
@@ -638,7 +617,7 @@ func TestGenerateGraphqlServer(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate graphql_server file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGinServer(dirName, serviceName, domain.Sqlx, gomodName, domain.MockParser)
 		assert.Error(t, err)
 	})
@@ -692,7 +671,7 @@ func TestGenerateGrpcServer(t *testing.T) {
 		}
 
 		// generate grpc_server.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGopgRepository(serviceName+"/repository", domainFile, gomodName, domain.MockParser)
 		err = gen.GenUsecase(serviceName+"/usecase", domainFile, gomodName, domain.MockParser)
 		err = gen.GenGrpcTransport(serviceName+"/transport/grpc", domainFile, gomodName, domain.MockParser)
@@ -724,7 +703,7 @@ func TestGenerateGrpcServer(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate grpc_server file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGinServer(dirName, serviceName, domain.Sqlx, gomodName, domain.MockParser)
 		assert.Error(t, err)
 	})
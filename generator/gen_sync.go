@@ -0,0 +1,195 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+
+	"github.com/wicaker/cacli/domain"
+)
+
+var versionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// GenStub will copy the methods of receiver from a freshly generated stub file and append them into filePath.
+// Existing methods in filePath are never changed, only the imports which needed by the new methods are added.
+// Imports are looked up in the stub file first, then in importFrom, e.g. the domain file which declares the parameter types.
+func (gen *caGen) GenStub(filePath string, stubPath string, receiver []domain.Receiver, importFrom ...string) error {
+	if len(receiver) == 0 {
+		return nil
+	}
+
+	stubSrc, err := gen.fs.ReadFile(stubPath)
+	if err != nil {
+		return err
+	}
+	targetSrc, err := gen.fs.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	stubFile, err := parser.ParseFile(fset, stubPath, stubSrc, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	targetFile, err := parser.ParseFile(fset, filePath, targetSrc, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	wanted := map[string]bool{}
+	for _, r := range receiver {
+		for _, m := range r.Method {
+			wanted[r.Name+"."+m.Name] = true
+		}
+	}
+
+	// take every wanted method in stub file together with its doc comment
+	var (
+		methods  [][]byte
+		usedPkgs = map[string]bool{}
+	)
+	for _, decl := range stubFile.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || !wanted[getRecvName(fn)+"."+fn.Name.Name] {
+			continue
+		}
+		start := fn.Pos()
+		if fn.Doc != nil {
+			start = fn.Doc.Pos()
+		}
+		methods = append(methods, stubSrc[fset.Position(start).Offset:fset.Position(fn.End()).Offset])
+
+		ast.Inspect(fn, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok {
+					usedPkgs[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	if len(methods) != len(wanted) {
+		return errors.New("method not found in " + stubPath)
+	}
+
+	// imports which used by the new methods but not imported yet
+	candidates := stubFile.Imports
+	for _, p := range importFrom {
+		src, err := gen.fs.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		f, err := parser.ParseFile(fset, p, src, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, f.Imports...)
+	}
+
+	existImport := map[string]bool{}
+	for _, i := range targetFile.Imports {
+		existImport[i.Path.Value] = true
+		delete(usedPkgs, getImportName(i))
+	}
+	var newImport []string
+	for _, i := range candidates {
+		name := getImportName(i)
+		if existImport[i.Path.Value] || !usedPkgs[name] {
+			continue
+		}
+		existImport[i.Path.Value] = true
+		delete(usedPkgs, name)
+		if i.Name != nil {
+			newImport = append(newImport, i.Name.Name+" "+i.Path.Value)
+		} else {
+			newImport = append(newImport, i.Path.Value)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Write(addImports(fset, targetFile, targetSrc, newImport))
+	for _, m := range methods {
+		buf.WriteString("\n")
+		buf.Write(m)
+		buf.WriteString("\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return gen.fs.WriteFile(filePath, src)
+}
+
+// addImports will insert import spec into import declaration of src without touching other declarations
+func addImports(fset *token.FileSet, f *ast.File, src []byte, newImport []string) []byte {
+	if len(newImport) == 0 {
+		return src
+	}
+
+	var specs bytes.Buffer
+	for _, i := range newImport {
+		specs.WriteString("\n\t" + i)
+	}
+
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+		if gd.Lparen.IsValid() {
+			offset := fset.Position(gd.Lparen).Offset + 1
+			return concat(src[:offset], specs.Bytes(), src[offset:])
+		}
+		// single import without parentheses
+		start := fset.Position(gd.Pos()).Offset
+		end := fset.Position(gd.End()).Offset
+		single := src[start+len("import") : end]
+		return concat(src[:start], []byte("import ("), specs.Bytes(), []byte("\n\t"), bytes.TrimSpace(single), []byte("\n)"), src[end:])
+	}
+
+	offset := fset.Position(f.Name.End()).Offset
+	return concat(src[:offset], []byte("\n\nimport ("), specs.Bytes(), []byte("\n)"), src[offset:])
+}
+
+func concat(b ...[]byte) []byte {
+	return bytes.Join(b, nil)
+}
+
+func getRecvName(fn *ast.FuncDecl) string {
+	if len(fn.Recv.List) == 0 {
+		return ""
+	}
+	recvType := fn.Recv.List[0].Type
+	if star, ok := recvType.(*ast.StarExpr); ok {
+		recvType = star.X
+	}
+	if ident, ok := recvType.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// getImportName will return the name which used to refer the imported package
+func getImportName(i *ast.ImportSpec) string {
+	if i.Name != nil {
+		return i.Name.Name
+	}
+	importPath, err := strconv.Unquote(i.Path.Value)
+	if err != nil {
+		return ""
+	}
+	name := path.Base(importPath)
+	if versionSuffix.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	return name
+}
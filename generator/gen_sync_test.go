@@ -0,0 +1,112 @@
+package generator_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/fs"
+	"github.com/wicaker/cacli/generator"
+)
+
+func TestGenerateStub(t *testing.T) {
+	var (
+		serviceName = "test_stub"
+		dirLayer    = "usecase"
+		dirStub     = "stub"
+		dirName     = fmt.Sprintf("%s/%s", serviceName, dirLayer)
+		stubName    = fmt.Sprintf("%s/%s", serviceName, dirStub)
+		domainFile  = "example.go"
+		gomodName   = "github.com/example/examplestub"
+		newFs       = fs.NewFsService()
+		gen         = generator.NewGeneratorService(newFs)
+	)
+
+	setup := func(method []domain.Method) {
+		// create directory of service
+		for _, dir := range []string{serviceName, dirName, stubName} {
+			err := newFs.CreateDir(dir)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+		}
+
+		existing := &domain.Parser{
+			Usecase:    domain.Usecase{Name: domain.MockParser.Usecase.Name, Method: method},
+			Repository: domain.MockParser.Repository,
+		}
+		err := gen.GenUsecase(dirName, domainFile, gomodName, existing)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		err = gen.GenUsecase(stubName, domainFile, gomodName, domain.MockParser)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	}
+
+	teardown := func() {
+		// remove directory of service
+		err := newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	}
+
+	t.Run("success, should append the new method and keep the existing one", func(t *testing.T) {
+		setup(domain.MockParser.Usecase.Method[:4])
+		defer teardown()
+
+		err := gen.GenStub(dirName+"/example_usecase.go", stubName+"/example_usecase.go", []domain.Receiver{
+			domain.Receiver{Name: "exampleUsecase", Method: domain.MockParser.Usecase.Method[4:]},
+		})
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_usecase.go")
+		assert.NoError(t, err)
+		expected, err := ioutil.ReadFile(stubName + "/example_usecase.go")
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(data))
+	})
+
+	t.Run("success, should add the import which needed by the new method", func(t *testing.T) {
+		setup([]domain.Method{})
+		defer teardown()
+
+		err := gen.GenStub(dirName+"/example_usecase.go", stubName+"/example_usecase.go", []domain.Receiver{
+			domain.Receiver{Name: "exampleUsecase", Method: domain.MockParser.Usecase.Method},
+		})
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_usecase.go")
+		assert.NoError(t, err)
+		expected, err := ioutil.ReadFile(stubName + "/example_usecase.go")
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(data))
+	})
+
+	t.Run("failed, because method not found in stub file", func(t *testing.T) {
+		setup(domain.MockParser.Usecase.Method)
+		defer teardown()
+
+		err := gen.GenStub(dirName+"/example_usecase.go", stubName+"/example_usecase.go", []domain.Receiver{
+			domain.Receiver{Name: "exampleUsecase", Method: []domain.Method{domain.Method{Name: "Archive"}}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("failed, because file not found", func(t *testing.T) {
+		err := gen.GenStub(dirName+"/example_usecase.go", stubName+"/example_usecase.go", []domain.Receiver{
+			domain.Receiver{Name: "exampleUsecase", Method: domain.MockParser.Usecase.Method},
+		})
+		assert.Error(t, err)
+	})
+}
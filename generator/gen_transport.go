@@ -8,7 +8,6 @@ import (
 
 	"github.com/dave/jennifer/jen"
 	"github.com/wicaker/cacli/domain"
-	"github.com/wicaker/cacli/fs"
 )
 
 func (gen *caGen) GenEchoTransport(dirName string, domainFile string, gomodName string, parser *domain.Parser) error {
@@ -23,6 +22,7 @@ func (gen *caGen) GenEchoTransport(dirName string, domainFile string, gomodName
 		importName = map[string]string{
 			gomodName + "/domain":      "domain",
 			"github.com/labstack/echo": "echo",
+			gomodName + "/middleware":  "middleware",
 		}
 	)
 
@@ -31,11 +31,12 @@ func (gen *caGen) GenEchoTransport(dirName string, domainFile string, gomodName
 	}))
 
 	for _, i := range parser.Usecase.Method {
-		path := fmt.Sprintf("/%s/%s", domainName, strings.ToLower(i.Name))
-		handler = append(handler, jen.Id("e").Dot("GET").Call(jen.Lit(path), jen.Id("handler").Dot(i.Name+"Handler")))
+		route := newRestRoute(domainName, i)
+		handler = append(handler, jen.Id("e").Dot(route.verb).Call(jen.Lit(route.path), jen.Id("handler").Dot(i.Name+"Handler")))
 	}
 
 	f.ImportNames(importName)
+	f.ImportAlias("github.com/json-iterator/go", "json")
 
 	f.Type().Id(domainName + "Handler").Struct(
 		jen.Id(useCase).Qual(gomodName+"/domain", useCase),
@@ -48,26 +49,30 @@ func (gen *caGen) GenEchoTransport(dirName string, domainFile string, gomodName
 	).Block(handler[:]...)
 
 	for _, i := range parser.Usecase.Method {
+		route := newRestRoute(domainName, i)
+		body := gen.genRestHandle(domain.Echo, domainName, gomodName, useCase, route, i)
 		f.Line()
 		f.Func().
-			Params(jen.Id(string(domainName[0])+"h").Op("*").Id(domainName+"Handler")).
-			Id(i.Name+"Handler").Params(jen.Id("c").Qual("github.com/labstack/echo", "Context")).Call(jen.Error()).Block(
-			jen.Id("ctx").Op(":=").Id("c").Dot("Request").Call().Dot("Context").Call(),
-			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
+			Params(jen.Id(string(domainName[0]) + "h").Op("*").Id(domainName + "Handler")).
+			Id(i.Name + "Handler").Params(jen.Id("c").Qual("github.com/labstack/echo", "Context")).Call(jen.Error()).Block(body...)
 
-			jen.Qual(gomodName+"/domain", "ResponseData").Op("=").Make(jen.Map(jen.String()).Interface()),
-			jen.Qual(gomodName+"/domain", "ResponseData").Op("[").Lit("message").Op("]=").Lit(i.Name+"Handler"),
-			jen.Return(jen.Id("c").Dot("JSON").Call(jen.Qual("net/http", "StatusOK"), jen.Qual(gomodName+"/domain", "ResponseData"))),
-		)
+		bind, err := genRestBind(domain.Echo, domainName, gomodName, route, i, parser.Entity)
+		if err != nil {
+			return err
+		}
+		if bind != nil {
+			f.Line()
+			f.Add(bind)
+		}
 	}
 
 	fileDir := fmt.Sprintf("%s/%s_handler.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return gen.genRestTransportTest(dirName, domainName, gomodName, domain.Echo, parser)
 }
 
 func (gen *caGen) GenGinTransport(dirName string, domainFile string, gomodName string, parser *domain.Parser) error {
@@ -82,6 +87,7 @@ func (gen *caGen) GenGinTransport(dirName string, domainFile string, gomodName s
 		importName = map[string]string{
 			gomodName + "/domain":      "domain",
 			"github.com/gin-gonic/gin": "gin",
+			gomodName + "/middleware":  "middleware",
 		}
 	)
 
@@ -90,11 +96,12 @@ func (gen *caGen) GenGinTransport(dirName string, domainFile string, gomodName s
 	}))
 
 	for _, i := range parser.Usecase.Method {
-		path := fmt.Sprintf("/%s/%s", domainName, strings.ToLower(i.Name))
-		handler = append(handler, jen.Id("r").Dot("GET").Call(jen.Lit(path), jen.Id("handler").Dot(i.Name+"Handler")))
+		route := newRestRoute(domainName, i)
+		handler = append(handler, jen.Id("r").Dot(route.verb).Call(jen.Lit(route.path), jen.Id("handler").Dot(i.Name+"Handler")))
 	}
 
 	f.ImportNames(importName)
+	f.ImportAlias("github.com/json-iterator/go", "json")
 
 	f.Type().Id(domainName + "Handler").Struct(
 		jen.Id(useCase).Qual(gomodName+"/domain", useCase),
@@ -107,27 +114,30 @@ func (gen *caGen) GenGinTransport(dirName string, domainFile string, gomodName s
 	).Block(handler[:]...)
 
 	for _, i := range parser.Usecase.Method {
+		route := newRestRoute(domainName, i)
+		body := gen.genRestHandle(domain.Gin, domainName, gomodName, useCase, route, i)
 		f.Line()
 		f.Func().
-			Params(jen.Id(string(domainName[0])+"h").Op("*").Id(domainName+"Handler")).
-			Id(i.Name+"Handler").Params(jen.Id("c").Op("*").Qual("github.com/gin-gonic/gin", "Context")).Block(
-			jen.Id("ctx").Op(":=").Id("c").Dot("Request").Dot("Context").Call(),
-			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
+			Params(jen.Id(string(domainName[0]) + "h").Op("*").Id(domainName + "Handler")).
+			Id(i.Name + "Handler").Params(jen.Id("c").Op("*").Qual("github.com/gin-gonic/gin", "Context")).Block(body...)
 
-			jen.Qual(gomodName+"/domain", "ResponseData").Op("=").Make(jen.Map(jen.String()).Interface()),
-			jen.Qual(gomodName+"/domain", "ResponseData").Op("[").Lit("message").Op("]=").Lit(i.Name+"Handler"),
-			jen.Id("c").Dot("JSON").Call(jen.Qual("net/http", "StatusOK"), jen.Qual(gomodName+"/domain", "ResponseData")),
-			jen.Return(),
-		)
+		bind, err := genRestBind(domain.Gin, domainName, gomodName, route, i, parser.Entity)
+		if err != nil {
+			return err
+		}
+		if bind != nil {
+			f.Line()
+			f.Add(bind)
+		}
 	}
 
 	fileDir := fmt.Sprintf("%s/%s_handler.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return gen.genRestTransportTest(dirName, domainName, gomodName, domain.Gin, parser)
 }
 
 func (gen *caGen) GenGorillaMuxTransport(dirName string, domainFile string, gomodName string, parser *domain.Parser) error {
@@ -140,8 +150,9 @@ func (gen *caGen) GenGorillaMuxTransport(dirName string, domainFile string, gomo
 		handler    []jen.Code
 		f          = jen.NewFile("rest")
 		importName = map[string]string{
-			gomodName + "/domain":    "domain",
-			"github.com/gorilla/mux": "mux",
+			gomodName + "/domain":     "domain",
+			"github.com/gorilla/mux":  "mux",
+			gomodName + "/middleware": "middleware",
 		}
 	)
 
@@ -150,8 +161,8 @@ func (gen *caGen) GenGorillaMuxTransport(dirName string, domainFile string, gomo
 	}))
 
 	for _, i := range parser.Usecase.Method {
-		path := fmt.Sprintf("/%s/%s", domainName, strings.ToLower(i.Name))
-		handler = append(handler, jen.Id("r").Dot("HandleFunc").Call(jen.Lit(path), jen.Id("handler").Dot(i.Name+"Handler")).Dot("Methods").Call(jen.Lit("GET")))
+		route := newRestRoute(domainName, i)
+		handler = append(handler, jen.Id("r").Dot("HandleFunc").Call(jen.Lit(route.muxPath()), jen.Id("handler").Dot(i.Name+"Handler")).Dot("Methods").Call(jen.Lit(route.verb)))
 	}
 
 	f.ImportNames(importName)
@@ -168,42 +179,47 @@ func (gen *caGen) GenGorillaMuxTransport(dirName string, domainFile string, gomo
 	).Block(handler[:]...)
 
 	for _, i := range parser.Usecase.Method {
+		route := newRestRoute(domainName, i)
+		body := gen.genRestHandle(domain.GorillaMux, domainName, gomodName, useCase, route, i)
 		f.Line()
 		f.Func().
 			Params(jen.Id(string(domainName[0])+"h").Op("*").Id(domainName+"Handler")).
-			Id(i.Name+"Handler").Params(jen.Id("w").Qual("net/http", "ResponseWriter"), jen.Id("r").Op("*").Qual("net/http", "Request")).Block(
-			jen.Id("ctx").Op(":=").Id("r").Dot("Context").Call(),
-			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-
-			jen.Qual(gomodName+"/domain", "ResponseData").Op("=").Make(jen.Map(jen.String()).Interface()),
-			jen.Qual(gomodName+"/domain", "ResponseData").Op("[").Lit("message").Op("]=").Lit(i.Name+"Handler"),
-			jen.Id("w").Dot("WriteHeader").Call(jen.Qual("net/http", "StatusOK")),
-			jen.Qual("github.com/json-iterator/go", "NewEncoder").Call(jen.Id("w")).Dot("Encode").Call(jen.Qual(gomodName+"/domain", "ResponseData")),
-			jen.Return(),
-		)
+			Id(i.Name+"Handler").Params(jen.Id("w").Qual("net/http", "ResponseWriter"), jen.Id("r").Op("*").Qual("net/http", "Request")).Block(body...)
+
+		bind, err := genRestBind(domain.GorillaMux, domainName, gomodName, route, i, parser.Entity)
+		if err != nil {
+			return err
+		}
+		if bind != nil {
+			f.Line()
+			f.Add(bind)
+		}
 	}
 
 	fileDir := fmt.Sprintf("%s/%s_handler.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return gen.genRestTransportTest(dirName, domainName, gomodName, domain.GorillaMux, parser)
 }
 
 func (gen *caGen) GenNetHTTPTransport(dirName string, domainFile string, gomodName string, parser *domain.Parser) error {
 	var (
-		file        = path.Base(domainFile)
-		domainName  = strings.TrimSuffix(file, filepath.Ext(file))
-		useCase     = parser.Usecase.Name
-		newD        = fmt.Sprintf("New%sHandler", strings.ToUpper(string(domainName[0]))+domainName[1:])
-		comment     = fmt.Sprintf("%s will initialize the %s endpoint", newD, domainName)
-		handlerName []string
-		f           = jen.NewFile("rest")
-		path        = fmt.Sprintf("/%s", domainName)
-		importName  = map[string]string{
-			gomodName + "/domain": "domain",
+		file       = path.Base(domainFile)
+		domainName = strings.TrimSuffix(file, filepath.Ext(file))
+		useCase    = parser.Usecase.Name
+		newD       = fmt.Sprintf("New%sHandler", strings.ToUpper(string(domainName[0]))+domainName[1:])
+		comment    = fmt.Sprintf("%s will initialize the %s endpoint", newD, domainName)
+		handler    []jen.Code
+		routes     []jen.Code
+		funcs      []jen.Code
+		prefixes   = map[string]bool{}
+		f          = jen.NewFile("rest")
+		importName = map[string]string{
+			gomodName + "/domain":     "domain",
+			gomodName + "/middleware": "middleware",
 		}
 	)
 
@@ -214,95 +230,114 @@ func (gen *caGen) GenNetHTTPTransport(dirName string, domainFile string, gomodNa
 		jen.Id(useCase).Qual(gomodName+"/domain", useCase),
 	)
 
-	f.Comment(comment)
-	f.Func().Id(newD).Params(
-		jen.Id("r").Op("*").Qual("net/http", "ServeMux"),
-		jen.Id("u").Qual(gomodName+"/domain", useCase),
-	).Block(
-		jen.Id("handler").Op(":=").Op("&").Id(domainName+"Handler").Values(jen.Dict{
-			jen.Id(useCase): jen.Id("u"),
-		}),
-		jen.Id("r").Dot("Handle").Call(jen.Lit(path), jen.Id("handler")),
-	)
+	handler = append(handler, jen.Id("handler").Op(":=").Op("&").Id(domainName+"Handler").Values(jen.Dict{
+		jen.Id(useCase): jen.Id("u"),
+	}))
 
 	for _, i := range parser.Usecase.Method {
-		handlerName = append(handlerName, i.Name+"Handler")
-		f.Line()
-		f.Func().
-			Params(jen.Id(string(domainName[0])+"h").Op("*").Id(domainName+"Handler")).
-			Id(i.Name+"Handler").Params(jen.Id("w").Qual("net/http", "ResponseWriter"), jen.Id("r").Op("*").Qual("net/http", "Request")).Block(
-			jen.Id("ctx").Op(":=").Id("r").Dot("Context").Call(),
-			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-
-			jen.Qual(gomodName+"/domain", "ResponseData").Op("=").Make(jen.Map(jen.String()).Interface()),
-			jen.Qual(gomodName+"/domain", "ResponseData").Op("[").Lit("message").Op("]=").Lit(i.Name+"Handler"),
-			jen.Id("w").Dot("WriteHeader").Call(jen.Qual("net/http", "StatusOK")),
-			jen.Qual("github.com/json-iterator/go", "NewEncoder").Call(jen.Id("w")).Dot("Encode").Call(jen.Qual(gomodName+"/domain", "ResponseData")),
-			jen.Return(),
+		var (
+			route     = newRestRoute(domainName, i)
+			segments  = route.segments()
+			condition = jen.Id("r").Dot("Method").Op("==").Qual("net/http", route.method()).Op("&&").Len(jen.Id("s")).Op("==").Lit(len(segments))
 		)
-	}
 
-	f.Line()
-	f.Func().
-		Params(jen.Id(string(domainName[0])+"h").Op("*").Id(domainName+"Handler")).
-		Id("ServeHTTP").Params(jen.Id("w").Qual("net/http", "ResponseWriter"), jen.Id("r").Op("*").Qual("net/http", "Request")).Block(
-		jen.Id("ctx").Op(":=").Id("r").Dot("Context").Call(),
-		jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-		jen.Line(),
+		// ServeMux only match the path prefix, so the handler is registered once for every prefix
+		prefix := "/" + segments[0]
+		if !prefixes[prefix] {
+			prefixes[prefix] = true
+			handler = append(handler,
+				jen.Id("r").Dot("Handle").Call(jen.Lit(prefix), jen.Id("handler")),
+				jen.Id("r").Dot("Handle").Call(jen.Lit(prefix+"/"), jen.Id("handler")),
+			)
+		}
 
-		jen.Id("uri").Op(":=").Id("ctx").Dot("Value").Call(jen.Lit("uri")),
-		jen.Id("s").Op(":=").Qual("strings", "Split").Call(jen.Id("r").Dot("RequestURI"), jen.Lit("/")),
-		jen.Line(),
+		// parameter of path is matched by any segment, it is parsed by the handler
+		for idx, segment := range segments {
+			if !strings.HasPrefix(segment, ":") {
+				condition = condition.Op("&&").Id("s").Index(jen.Lit(idx)).Op("==").Lit(segment)
+			}
+		}
 
-		jen.If(jen.Id("uri").Op("==").Lit(path)).Block(
-			jen.If(jen.Id("r").Dot("Method").Op("==").Qual("net/http", "MethodGet")).Block(
-				jen.Id(string(domainName[0])+"h").Dot(handlerName[0]).Call(jen.Id("w"), jen.Id("r")),
-				jen.Return(),
-			),
-		),
-		jen.Line(),
+		routes = append(routes, jen.Case(condition).Block(
+			jen.Id(string(domainName[0])+"h").Dot(i.Name+"Handler").Call(jen.Id("w"), jen.Id("r")),
+			jen.Return(),
+		))
 
-		jen.Comment("// if the path contain params"),
-		jen.If(jen.Len(jen.Id("s")).Op("==").Lit(3)).Block(
-			jen.Id("id").Op(",").Err().Op(":=").Id("strconv").Dot("Atoi").Call(jen.Qual("path", "Base").Call(jen.Id("r").Dot("RequestURI"))),
-			jen.If(jen.Err().Op("!=").Nil()).Block(
-				jen.Id("w").Dot("WriteHeader").Call(jen.Qual("net/http", "StatusMethodNotAllowed")),
-				jen.Qual("github.com/json-iterator/go", "NewEncoder").Call(jen.Id("w")).Dot("Encode").Call(jen.Op("&").Qual(gomodName+"/domain", "ResponseError").Values(jen.Dict{
-					jen.Id("Message"): jen.Lit("Method Not Allowed"),
-				})),
-				jen.Return(),
-			),
-			jen.If(jen.Id("r").Dot("Method").Op("==").Qual("net/http", "MethodGet")).Block(
-				jen.Id(string(domainName[0])+"h").Dot(handlerName[0]).Call(jen.Id("w"), jen.Id("r"), jen.Id("id")),
-				jen.Return(),
-			),
-		),
-		jen.Line(),
+		body := gen.genRestHandle(domain.NetHTTP, domainName, gomodName, useCase, route, i)
+		funcs = append(funcs, jen.Func().
+			Params(jen.Id(string(domainName[0])+"h").Op("*").Id(domainName+"Handler")).
+			Id(i.Name+"Handler").Params(jen.Id("w").Qual("net/http", "ResponseWriter"), jen.Id("r").Op("*").Qual("net/http", "Request")).Block(body...))
 
+		bind, err := genRestBind(domain.NetHTTP, domainName, gomodName, route, i, parser.Entity)
+		if err != nil {
+			return err
+		}
+		if bind != nil {
+			funcs = append(funcs, bind)
+		}
+	}
+
+	f.Comment(comment)
+	f.Func().Id(newD).Params(
+		jen.Id("r").Op("*").Qual("net/http", "ServeMux"),
+		jen.Id("u").Qual(gomodName+"/domain", useCase),
+	).Block(handler...)
+
+	for _, i := range funcs {
+		f.Line()
+		f.Add(i)
+	}
+
+	notAllowed := []jen.Code{
 		jen.Id("w").Dot("WriteHeader").Call(jen.Qual("net/http", "StatusMethodNotAllowed")),
 		jen.Qual("github.com/json-iterator/go", "NewEncoder").Call(jen.Id("w")).Dot("Encode").Call(jen.Op("&").Qual(gomodName+"/domain", "ResponseError").Values(jen.Dict{
 			jen.Id("Message"): jen.Lit("Method Not Allowed"),
 		})),
-		jen.Return(),
+	}
+	if gen.gen.ProblemJSON {
+		notAllowed = []jen.Code{
+			jen.Id("w").Dot("Header").Call().Dot("Set").Call(jen.Lit("Content-Type"), jen.Qual(gomodName+"/domain", "ProblemContentType")),
+			jen.Id("w").Dot("WriteHeader").Call(jen.Qual("net/http", "StatusMethodNotAllowed")),
+			jen.Qual("github.com/json-iterator/go", "NewEncoder").Call(jen.Id("w")).Dot("Encode").Call(jen.Op("&").Qual(gomodName+"/domain", "ProblemDetails").Values(jen.Dict{
+				jen.Id("Type"):     jen.Lit("about:blank"),
+				jen.Id("Title"):    jen.Qual("net/http", "StatusText").Call(jen.Qual("net/http", "StatusMethodNotAllowed")),
+				jen.Id("Status"):   jen.Qual("net/http", "StatusMethodNotAllowed"),
+				jen.Id("Instance"): jen.Id("r").Dot("URL").Dot("Path"),
+			})),
+		}
+	}
+
+	f.Line()
+	f.Comment("ServeHTTP will dispatch the request to handler of the matching route")
+	f.Func().
+		Params(jen.Id(string(domainName[0])+"h").Op("*").Id(domainName+"Handler")).
+		Id("ServeHTTP").Params(jen.Id("w").Qual("net/http", "ResponseWriter"), jen.Id("r").Op("*").Qual("net/http", "Request")).Block(
+		append([]jen.Code{
+			jen.Id("s").Op(":=").Qual("strings", "Split").Call(jen.Qual("strings", "Trim").Call(jen.Id("r").Dot("URL").Dot("Path"), jen.Lit("/")), jen.Lit("/")),
+			jen.Line(),
+			jen.Switch().Block(routes...),
+			jen.Line(),
+		}, notAllowed...)...,
 	)
 
 	fileDir := fmt.Sprintf("%s/%s_handler.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return gen.genRestTransportTest(dirName, domainName, gomodName, domain.NetHTTP, parser)
 }
 
 func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodName string, parser *domain.Parser) error {
 	var (
 		file       = path.Base(domainFile)
 		domainName = strings.TrimSuffix(file, filepath.Ext(file))
-		useCase    = parser.Usecase.Name
-		newFs      = fs.NewFsService()
+		typeBase   = strings.ToUpper(string(domainName[0])) + domainName[1:]
+		hasMutate  bool // query is always served, see isGraphqlQuery
 		importName = map[string]string{
 			gomodName + "/domain":                      "domain",
+			gomodName + "/middleware":                  "middleware",
 			"github.com/graphql-go/graphql":            "graphql",
 			gomodName + "/transport/graphql/types":     "types",
 			gomodName + "/transport/graphql/mutations": "mutations",
@@ -313,30 +348,20 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 	// types
 	// =>>example.go
 	typeGen := func(dirName string, domainName string) error {
-		typeName := fmt.Sprintf("%sType", strings.ToUpper(string(domainName[0]))+domainName[1:])
-		f := jen.NewFile("types")
+		// path of the package is given, so types which are declared in it are not qualified by itself
+		f := jen.NewFilePathName(gomodName+"/transport/graphql/types", "types")
 		f.ImportNames(importName)
 
-		f.Comment(fmt.Sprintf("%s is the GraphQL schema for the %s type.", typeName, domainName))
-		f.Var().Id(typeName).Op("=").Qual("github.com/graphql-go/graphql", "NewObject").Call(
-			jen.Qual("github.com/graphql-go/graphql", "ObjectConfig").Values(jen.Dict{
-				jen.Id("Name"): jen.Lit(strings.ToUpper(string(domainName[0])) + domainName[1:]),
-				jen.Id("Fields"): jen.Qual("github.com/graphql-go/graphql", "Fields").Values(jen.Dict{
-					jen.Lit("id"): jen.Op("&").Qual("github.com/graphql-go/graphql", "Field").Values(jen.Dict{
-						jen.Id("Type"): jen.Qual("github.com/graphql-go/graphql", "ID"),
-					}),
-				}),
-			}),
-		)
+		genGraphqlTypes(f, parser, gomodName, typeBase, domainName)
 		// create types directory
-		err := newFs.CreateDir(dirName + "/types")
+		err := gen.ensureDir(dirName + "/types")
 		if err != nil {
 			return err
 		}
 
 		// save file
 		fileDir := fmt.Sprintf("%s/types/%s.go", dirName, domainName)
-		err = f.Save(fileDir)
+		err = gen.save(f, fileDir)
 		if err != nil {
 			return err
 		}
@@ -348,29 +373,35 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 		return err
 	}
 
+	// every domain which already has its types is served by the same schema
+	domains, err := gen.graphqlDomains(dirName, domainName, parser)
+	if err != nil {
+		return err
+	}
+
 	// mutations
 	// =>>mutations.go
 	mutationGen := func(dirName string, domainName string) error {
 		graphFields := jen.Dict{}
 		f := jen.NewFile("mutations")
 		f.ImportNames(importName)
+		f.ImportAlias("github.com/json-iterator/go", "json")
 
 		f.Comment("GraphQLMutation represent the graphQLMutation")
-		f.Type().Id("GraphQLMutation").Struct(
-			jen.Id(useCase).Qual(gomodName+"/domain", useCase),
-		)
+		f.Type().Id("GraphQLMutation").Struct(graphqlUsecaseFields(domains, gomodName)...)
 
 		f.Comment("NewGraphQLMutation will initialize mutations")
-		f.Func().Id("NewGraphQLMutation").Params(
-			jen.Id(string(domainName[0])).Qual(gomodName+"/domain", useCase),
-		).Op("*").Id("GraphQLMutation").Block(
-			jen.Return(jen.Op("&").Id("GraphQLMutation").Values(jen.Dict{
-				jen.Id(useCase): jen.Id(string(domainName[0])),
-			})),
+		f.Func().Id("NewGraphQLMutation").Params(graphqlUsecaseParams(domains, gomodName)...).Op("*").Id("GraphQLMutation").Block(
+			jen.Return(jen.Op("&").Id("GraphQLMutation").Values(graphqlUsecaseValues(domains))),
 		)
 
-		for _, i := range parser.Usecase.Method {
-			graphFields[jen.Lit(domainName+i.Name)] = jen.Id("gm").Dot(i.Name + strings.ToUpper(string(domainName[0])) + domainName[1:] + "Mutation").Call()
+		for _, d := range domains {
+			for _, i := range d.parser.Usecase.Method {
+				if !isGraphqlQuery(d.name, d.parser, i) {
+					hasMutate = true
+					graphFields[jen.Lit(d.name+i.Name)] = jen.Id("gm").Dot(i.Name + d.typeName + "Mutation").Call()
+				}
+			}
 		}
 
 		f.Comment("GetRootMutationFields returns all the available mutations.")
@@ -378,16 +409,18 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 			Params(jen.Id("gm").Op("*").Id("GraphQLMutation")).Id("GetRootMutationFields").Params().Qual("github.com/graphql-go/graphql", "Fields").Block(
 			jen.Return(jen.Qual("github.com/graphql-go/graphql", "Fields").Values(graphFields)),
 		)
+		f.Line()
+		genGraphqlBindArgument(f)
 
 		// create mutations directory
-		err := newFs.CreateDir(dirName + "/mutations")
+		err := gen.ensureDir(dirName + "/mutations")
 		if err != nil {
 			return err
 		}
 
 		// save file
 		fileDir := fmt.Sprintf("%s/mutations/mutations.go", dirName)
-		err = f.Save(fileDir)
+		err = gen.save(f, fileDir)
 		if err != nil {
 			return err
 		}
@@ -397,32 +430,29 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 
 	// =>>example.go
 	mutationFieldGen := func(dirName string, domainName string) error {
-		typeName := fmt.Sprintf("%sType", strings.ToUpper(string(domainName[0]))+domainName[1:])
 		f := jen.NewFile("mutations")
 		f.ImportNames(importName)
 
 		for _, i := range parser.Usecase.Method {
+			if isGraphqlQuery(domainName, parser, i) {
+				continue
+			}
+			field, err := genGraphqlField("gm", parser, gomodName, typeBase, i)
+			if err != nil {
+				return err
+			}
 			f.Line()
-			f.Comment(i.Name + strings.ToUpper(string(domainName[0])) + domainName[1:] + "Mutation /.")
+			f.Comment(i.Name + typeBase + "Mutation will resolve " + i.Name + " of " + domainName)
 			f.Func().
 				Params(jen.Id("gm").Op("*").Id("GraphQLMutation")).
-				Id(i.Name+strings.ToUpper(string(domainName[0]))+domainName[1:]+"Mutation").Params().Op("*").Qual("github.com/graphql-go/graphql", "Field").Block(
-				jen.Return(jen.Op("&").Qual("github.com/graphql-go/graphql", "Field").Values(jen.Dict{
-					jen.Id("Type"):        jen.Qual(gomodName+"/transport/graphql/types", typeName),
-					jen.Id("Description"): jen.Lit(strings.ToUpper(string(domainName[0])) + domainName[1:]),
-					jen.Id("Args"):        jen.Qual("github.com/graphql-go/graphql", "FieldConfigArgument").Values(jen.Dict{}),
-					jen.Id("Resolve"): jen.Func().Params(jen.Id("params").Qual("github.com/graphql-go/graphql", "ResolveParams")).Call(jen.Interface(), jen.Error()).Block(
-						jen.Id("ctx").Op(":=").Id("params").Dot("Context"),
-						jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-						jen.Return(jen.Nil(), jen.Nil()),
-					),
-				})),
+				Id(i.Name+typeBase+"Mutation").Params().Op("*").Qual("github.com/graphql-go/graphql", "Field").Block(
+				jen.Return(field),
 			)
 		}
 
 		// save file
 		fileDir := fmt.Sprintf("%s/mutations/%s.go", dirName, domainName)
-		err = f.Save(fileDir)
+		err = gen.save(f, fileDir)
 		if err != nil {
 			return err
 		}
@@ -446,23 +476,22 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 		graphFields := jen.Dict{}
 		f := jen.NewFile("queries")
 		f.ImportNames(importName)
+		f.ImportAlias("github.com/json-iterator/go", "json")
 
 		f.Comment("GraphQLQuery represent the GraphQLQuery")
-		f.Type().Id("GraphQLQuery").Struct(
-			jen.Id(useCase).Qual(gomodName+"/domain", useCase),
-		)
+		f.Type().Id("GraphQLQuery").Struct(graphqlUsecaseFields(domains, gomodName)...)
 
 		f.Comment("NewGraphQLQuery will initialize queries")
-		f.Func().Id("NewGraphQLQuery").Params(
-			jen.Id(string(domainName[0])).Qual(gomodName+"/domain", useCase),
-		).Op("*").Id("GraphQLQuery").Block(
-			jen.Return(jen.Op("&").Id("GraphQLQuery").Values(jen.Dict{
-				jen.Id(useCase): jen.Id(string(domainName[0])),
-			})),
+		f.Func().Id("NewGraphQLQuery").Params(graphqlUsecaseParams(domains, gomodName)...).Op("*").Id("GraphQLQuery").Block(
+			jen.Return(jen.Op("&").Id("GraphQLQuery").Values(graphqlUsecaseValues(domains))),
 		)
 
-		for _, i := range parser.Usecase.Method {
-			graphFields[jen.Lit(domainName+i.Name)] = jen.Id("gq").Dot(i.Name + strings.ToUpper(string(domainName[0])) + domainName[1:] + "Query").Call()
+		for _, d := range domains {
+			for _, i := range d.parser.Usecase.Method {
+				if isGraphqlQuery(d.name, d.parser, i) {
+					graphFields[jen.Lit(d.name+i.Name)] = jen.Id("gq").Dot(i.Name + d.typeName + "Query").Call()
+				}
+			}
 		}
 
 		f.Comment("GetRootQueryFields returns all the available queries.")
@@ -470,16 +499,18 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 			Params(jen.Id("gq").Op("*").Id("GraphQLQuery")).Id("GetRootQueryFields").Params().Qual("github.com/graphql-go/graphql", "Fields").Block(
 			jen.Return(jen.Qual("github.com/graphql-go/graphql", "Fields").Values(graphFields)),
 		)
+		f.Line()
+		genGraphqlBindArgument(f)
 
 		// create queries directory
-		err := newFs.CreateDir(dirName + "/queries")
+		err := gen.ensureDir(dirName + "/queries")
 		if err != nil {
 			return err
 		}
 
 		// save file
 		fileDir := fmt.Sprintf("%s/queries/queries.go", dirName)
-		err = f.Save(fileDir)
+		err = gen.save(f, fileDir)
 		if err != nil {
 			return err
 		}
@@ -489,36 +520,29 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 
 	// =>>example.go
 	queryFieldGen := func(dirName string, domainName string) error {
-		typeName := fmt.Sprintf("%sType", strings.ToUpper(string(domainName[0]))+domainName[1:])
 		f := jen.NewFile("queries")
 		f.ImportNames(importName)
 
 		for _, i := range parser.Usecase.Method {
+			if !isGraphqlQuery(domainName, parser, i) {
+				continue
+			}
+			field, err := genGraphqlField("gq", parser, gomodName, typeBase, i)
+			if err != nil {
+				return err
+			}
 			f.Line()
-			f.Comment(i.Name + strings.ToUpper(string(domainName[0])) + domainName[1:] + "Query /.")
+			f.Comment(i.Name + typeBase + "Query will resolve " + i.Name + " of " + domainName)
 			f.Func().
 				Params(jen.Id("gq").Op("*").Id("GraphQLQuery")).
-				Id(i.Name+strings.ToUpper(string(domainName[0]))+domainName[1:]+"Query").Params().Op("*").Qual("github.com/graphql-go/graphql", "Field").Block(
-				jen.Return(jen.Op("&").Qual("github.com/graphql-go/graphql", "Field").Values(jen.Dict{
-					jen.Id("Type"):        jen.Qual(gomodName+"/transport/graphql/types", typeName),
-					jen.Id("Description"): jen.Lit(strings.ToUpper(string(domainName[0])) + domainName[1:]),
-					jen.Id("Args"): jen.Qual("github.com/graphql-go/graphql", "FieldConfigArgument").Values(jen.Dict{
-						jen.Lit("id"): jen.Op("&").Qual("github.com/graphql-go/graphql", "ArgumentConfig").Values(jen.Dict{
-							jen.Id("Type"): jen.Qual("github.com/graphql-go/graphql", "ID"),
-						}),
-					}),
-					jen.Id("Resolve"): jen.Func().Params(jen.Id("params").Qual("github.com/graphql-go/graphql", "ResolveParams")).Call(jen.Interface(), jen.Error()).Block(
-						jen.Id("ctx").Op(":=").Id("params").Dot("Context"),
-						jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-						jen.Return(jen.Nil(), jen.Nil()),
-					),
-				})),
+				Id(i.Name+typeBase+"Query").Params().Op("*").Qual("github.com/graphql-go/graphql", "Field").Block(
+				jen.Return(field),
 			)
 		}
 
 		// save file
 		fileDir := fmt.Sprintf("%s/queries/%s.go", dirName, domainName)
-		err = f.Save(fileDir)
+		err = gen.save(f, fileDir)
 		if err != nil {
 			return err
 		}
@@ -543,18 +567,18 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 		f.ImportAlias("github.com/sirupsen/logrus", "log")
 
 		f.Comment("graphQLHandler represent the graphQLHandler")
-		f.Type().Id("graphQLHandler").Struct(
-			jen.Id(useCase).Qual(gomodName+"/domain", useCase),
-		)
+		f.Type().Id("graphQLHandler").Struct(graphqlUsecaseFields(domains, gomodName)...)
+
+		var usecases []jen.Code
+		for _, d := range domains {
+			usecases = append(usecases, jen.Id("gh").Dot(d.parser.Usecase.Name))
+		}
 
 		f.Comment("NewGraphQLHandler will initialize the graphql endpoint")
 		f.Func().Id("NewGraphQLHandler").Params(
-			jen.Id("r").Op("*").Qual("net/http", "ServeMux"),
-			jen.Id(string(domainName[0])).Qual(gomodName+"/domain", useCase),
+			append([]jen.Code{jen.Id("r").Op("*").Qual("net/http", "ServeMux")}, graphqlUsecaseParams(domains, gomodName)...)...,
 		).Block(
-			jen.Id("handle").Op(":=&").Id("graphQLHandler").Values(jen.Dict{
-				jen.Id(useCase): jen.Id(string(domainName[0])),
-			}),
+			jen.Id("handle").Op(":=&").Id("graphQLHandler").Values(graphqlUsecaseValues(domains)),
 			jen.Line(),
 			jen.Id("h").Op(":=").Qual("github.com/graphql-go/handler", "New").Call(jen.Op("&").Qual("github.com/graphql-go/handler", "Config").Values(jen.Dict{
 				jen.Id("Schema"):   jen.Id("handle").Dot("schema").Call(),
@@ -565,9 +589,8 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 			jen.Id("r").Dot("Handle").Call(jen.Lit("/graphql"), jen.Id("httpHeaderMiddleware").Call(jen.Id("h"))),
 		)
 		f.Line()
-		f.Func().Params(jen.Id("gh").Op("*").Id("graphQLHandler")).Id("schema").Params().Op("*").Qual("github.com/graphql-go/graphql", "Schema").Block(
-			jen.Id("rootMutation").Op(":=").Qual(gomodName+"/transport/graphql/mutations", "NewGraphQLMutation").Call(jen.Id("gh").Dot(useCase)),
-			jen.Id("rootQuery").Op(":=").Qual(gomodName+"/transport/graphql/queries", "NewGraphQLQuery").Call(jen.Id("gh").Dot(useCase)),
+		schema := []jen.Code{
+			jen.Id("rootQuery").Op(":=").Qual(gomodName+"/transport/graphql/queries", "NewGraphQLQuery").Call(usecases...),
 			jen.Line(),
 			jen.Id("queryType").Op(":=").Qual("github.com/graphql-go/graphql", "NewObject").Call(
 				jen.Qual("github.com/graphql-go/graphql", "ObjectConfig").Values(jen.Dict{
@@ -576,18 +599,28 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 				}),
 			),
 			jen.Line(),
-			jen.Id("mutationType").Op(":=").Qual("github.com/graphql-go/graphql", "NewObject").Call(
-				jen.Qual("github.com/graphql-go/graphql", "ObjectConfig").Values(jen.Dict{
-					jen.Id("Name"):   jen.Lit("Mutation"),
-					jen.Id("Fields"): jen.Id("rootMutation").Dot("GetRootMutationFields").Call(),
-				}),
-			),
-			jen.Line(),
+		}
+		schemaConfig := jen.Dict{jen.Id("Query"): jen.Id("queryType")}
+
+		// graphql does not accept mutation without field
+		if hasMutate {
+			schema = append(schema,
+				jen.Id("rootMutation").Op(":=").Qual(gomodName+"/transport/graphql/mutations", "NewGraphQLMutation").Call(usecases...),
+				jen.Line(),
+				jen.Id("mutationType").Op(":=").Qual("github.com/graphql-go/graphql", "NewObject").Call(
+					jen.Qual("github.com/graphql-go/graphql", "ObjectConfig").Values(jen.Dict{
+						jen.Id("Name"):   jen.Lit("Mutation"),
+						jen.Id("Fields"): jen.Id("rootMutation").Dot("GetRootMutationFields").Call(),
+					}),
+				),
+				jen.Line(),
+			)
+			schemaConfig[jen.Id("Mutation")] = jen.Id("mutationType")
+		}
+
+		schema = append(schema,
 			jen.Id("schema").Op(",").Err().Op(":=").Qual("github.com/graphql-go/graphql", "NewSchema").Call(
-				jen.Qual("github.com/graphql-go/graphql", "SchemaConfig").Values(jen.Dict{
-					jen.Id("Query"):    jen.Id("queryType"),
-					jen.Id("Mutation"): jen.Id("mutationType"),
-				}),
+				jen.Qual("github.com/graphql-go/graphql", "SchemaConfig").Values(schemaConfig),
 			),
 			jen.If(jen.Err().Op("!=").Nil().Block(
 				jen.Qual("github.com/sirupsen/logrus", "Printf").Call(jen.Lit("errors: %v"), jen.Err().Dot("Error").Call()),
@@ -595,6 +628,7 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 			jen.Line(),
 			jen.Return(jen.Op("&").Id("schema")),
 		)
+		f.Func().Params(jen.Id("gh").Op("*").Id("graphQLHandler")).Id("schema").Params().Op("*").Qual("github.com/graphql-go/graphql", "Schema").Block(schema...)
 		f.Line()
 		f.Func().Id("httpHeaderMiddleware").Params(jen.Id("next").Op("*").Qual("github.com/graphql-go/handler", "Handler")).Qual("net/http", "Handler").Block(
 			jen.Return(
@@ -610,7 +644,7 @@ func (gen *caGen) GenGraphqlTransport(dirName string, domainFile string, gomodNa
 
 		// save file
 		fileDir := fmt.Sprintf("%s/index.go", dirName)
-		err = f.Save(fileDir)
+		err = gen.save(f, fileDir)
 		if err != nil {
 			return err
 		}
@@ -633,8 +667,10 @@ func (gen *caGen) GenGrpcTransport(dirName string, domainFile string, gomodName
 		handler    = fmt.Sprintf("%sHandler", strings.ToUpper(string(domainName[0]))+domainName[1:])
 		f          = jen.NewFile("grpchandler")
 		importName = map[string]string{
-			gomodName + "/domain":    "domain",
-			"google.golang.org/grpc": "grpc",
+			gomodName + "/domain":     "domain",
+			gomodName + "/middleware": "middleware",
+			"google.golang.org/grpc":  "grpc",
+			timestampPath:             "timestamp",
 		}
 	)
 
@@ -658,8 +694,13 @@ func (gen *caGen) GenGrpcTransport(dirName string, domainFile string, gomodName
 		jen.Qual(gomodName+"/proto", "Register"+strings.ToUpper(string(domainName[0]))+domainName[1:]+"ServiceServer").Call(jen.Id("gs"), jen.Id("srv")),
 	)
 	f.Line()
+	converter := newGrpcConverter(parser, gomodName, strings.ToUpper(string(domainName[0]))+domainName[1:])
 	for _, i := range parser.Usecase.Method {
 		funcName := i.Name + strings.ToUpper(string(domainName[0])) + domainName[1:]
+		body, err := converter.genGrpcHandle("gh", useCase, i)
+		if err != nil {
+			return err
+		}
 		f.Line()
 		f.Comment(funcName + " will handle " + funcName + " request")
 		f.Func().
@@ -670,15 +711,89 @@ func (gen *caGen) GenGrpcTransport(dirName string, domainFile string, gomodName
 		).Call(
 			jen.Op("*").Qual(gomodName+"/proto", funcName+"Resp"),
 			jen.Error(),
-		).Block(
-			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
-			jen.Return(jen.Op("&").Qual(gomodName+"/proto", funcName+"Resp").Values(jen.Dict{}), jen.Nil()),
-		)
+		).Block(body...)
 	}
+	converter.genHelpers(f)
+
 	fileDir := fmt.Sprintf("%s/%s_handler.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
-	return nil
+	return gen.genGrpcErrors(dirName, gomodName)
+}
+
+// restRoute represent the endpoint which serve a method of usecase, parameter of path is written as :name
+type restRoute struct {
+	verb string
+	path string
+}
+
+// newRestRoute will return the endpoint of usecase method, conventional methods are served restfully,
+// e.g. GetByID become GET /examples/:id, and the others become POST /examples/<method>.
+// The endpoint is overridden if the method is annotated with `@route VERB /path` in its doc
+func newRestRoute(domainName string, m domain.Method) restRoute {
+	for _, line := range strings.Split(m.Doc, "\n") {
+		if route := strings.Fields(line); len(route) == 3 && route[0] == "@route" {
+			return restRoute{verb: strings.ToUpper(route[1]), path: route[2]}
+		}
+	}
+
+	base := "/" + strings.ReplaceAll(tableName(domainName), "_", "-")
+	switch m.Name {
+	case crudFetch:
+		return restRoute{verb: "GET", path: base}
+	case crudGetByID:
+		return restRoute{verb: "GET", path: base + "/:id"}
+	case crudStore:
+		return restRoute{verb: "POST", path: base}
+	case crudUpdate:
+		return restRoute{verb: "PUT", path: base + "/:id"}
+	case crudDelete:
+		return restRoute{verb: "DELETE", path: base + "/:id"}
+	}
+	return restRoute{verb: "POST", path: base + "/" + strings.ToLower(m.Name)}
+}
+
+// method will return name of the net/http constant of verb, e.g. MethodGet
+func (r restRoute) method() string {
+	return "Method" + r.verb[:1] + strings.ToLower(r.verb[1:])
+}
+
+// segments will split path of route, e.g. /examples/:id become [examples :id]
+func (r restRoute) segments() []string {
+	return strings.Split(strings.Trim(r.path, "/"), "/")
+}
+
+// params will return name of every parameter of path
+func (r restRoute) params() []string {
+	var params []string
+	for _, s := range r.segments() {
+		if strings.HasPrefix(s, ":") {
+			params = append(params, s[1:])
+		}
+	}
+	return params
+}
+
+// muxPath will return path of route in gorilla mux syntax, e.g. /examples/{id}
+func (r restRoute) muxPath() string {
+	segments := r.segments()
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// target will return path of route which every parameter is filled by the given value, e.g. /examples/1
+func (r restRoute) target(value string) string {
+	segments := r.segments()
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			segments[i] = value
+		}
+	}
+	return "/" + strings.Join(segments, "/")
 }
@@ -0,0 +1,478 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/wicaker/cacli/domain"
+)
+
+const (
+	fromPath  = "path"
+	fromQuery = "query"
+	fromBody  = "body"
+)
+
+// restParam represent a parameter of usecase method which is bound from the request of rest transport
+type restParam struct {
+	name   string
+	typ    string
+	source string
+}
+
+// newRestParams will decide where every parameter of usecase method is bound from,
+// basic type is bound from parameter of path which has the same name or else from query,
+// the other types are decoded from json body. Context is never bound from the request
+func newRestParams(route restRoute, m domain.Method) []restParam {
+	var (
+		params []restParam
+		path   = map[string]bool{}
+	)
+	for _, p := range route.params() {
+		path[p] = true
+	}
+
+	for idx, p := range m.ParameterList {
+		if p.Type == "context.Context" {
+			continue
+		}
+		param := restParam{name: p.Name, typ: p.Type, source: fromBody}
+		if param.name == "" {
+			param.name = fmt.Sprintf("param%d", idx)
+		}
+		if strings.HasPrefix(param.typ, "...") {
+			param.typ = "[]" + param.typ[3:]
+		}
+		if isBasicType(param.typ) {
+			param.source = fromQuery
+			if path[param.name] {
+				param.source = fromPath
+			}
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// restEntityField represent a field of entity in json body which is bound from parameter of path
+type restEntityField struct {
+	path  string
+	field domain.Field
+}
+
+// newRestEntityFields will return the fields of entity which are bound from parameter of path, e.g. ID from :id of PUT /examples/:id,
+// parameter of path which is not a parameter of usecase method is set on the entity of body, so the path always wins over the body
+func newRestEntityFields(route restRoute, params []restParam, entity domain.Entity) (restParam, []restEntityField) {
+	var (
+		body   restParam
+		fields []restEntityField
+		bound  = map[string]bool{}
+	)
+	for _, p := range params {
+		if p.source == fromPath {
+			bound[p.name] = true
+		}
+		if p.source == fromBody && body.name == "" && strings.TrimPrefix(p.typ, "*") == "domain."+entity.Name {
+			body = p
+		}
+	}
+	if body.name == "" {
+		return body, nil
+	}
+
+	for _, name := range route.params() {
+		if bound[name] {
+			continue
+		}
+		for _, f := range entity.Fields {
+			if strings.EqualFold(f.Name, name) && isBasicType(f.Type) && f.Type != "bool" {
+				fields = append(fields, restEntityField{path: name, field: f})
+				break
+			}
+		}
+	}
+	return body, fields
+}
+
+// genRestBind will generate bind<Method> which bind the request into parameters of usecase method and validate them,
+// it returns nil if the method has no parameter to be bound
+func genRestBind(restServer string, domainName string, gomodName string, route restRoute, m domain.Method, entity domain.Entity) (jen.Code, error) {
+	var (
+		imports   = map[string]string{"domain": gomodName + "/domain"}
+		params    = newRestParams(route, m)
+		results   []jen.Code
+		names     []jen.Code
+		bodies    []restParam
+		body      []jen.Code
+		bindParam []jen.Code
+	)
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	for _, p := range params {
+		typ, err := genTypeCode(p.typ, imports)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, jen.Id(p.name).Add(typ))
+		names = append(names, jen.Id(p.name))
+		if p.source == fromBody {
+			bodies = append(bodies, p)
+		}
+	}
+	results = append(results, jen.Id("details").Map(jen.String()).String())
+	entityBody, entityFields := newRestEntityFields(route, params, entity)
+	invalid := func(details jen.Code) jen.Code {
+		return jen.Return(append(append([]jen.Code{}, names...), details)...)
+	}
+
+	switch restServer {
+	case domain.Echo:
+		bindParam = []jen.Code{jen.Id("c").Qual("github.com/labstack/echo", "Context")}
+	case domain.Gin:
+		bindParam = []jen.Code{jen.Id("c").Op("*").Qual("github.com/gin-gonic/gin", "Context")}
+	case domain.GorillaMux, domain.NetHTTP:
+		bindParam = []jen.Code{jen.Id("r").Op("*").Qual("net/http", "Request")}
+	default:
+		return nil, fmt.Errorf("rest server %s is not supported", restServer)
+	}
+
+	// net/http has no router, so parameter of path is read from the segment of path
+	fromPathParam := len(entityFields) > 0
+	for _, p := range params {
+		if p.source == fromPath {
+			fromPathParam = true
+		}
+	}
+	if fromPathParam && restServer == domain.NetHTTP {
+		body = append(body, jen.Id("s").Op(":=").Qual("strings", "Split").Call(jen.Qual("strings", "Trim").Call(jen.Id("r").Dot("URL").Dot("Path"), jen.Lit("/")), jen.Lit("/")))
+	}
+
+	for _, p := range params {
+		var value jen.Code
+		switch p.source {
+		case fromPath:
+			value = restPathValue(restServer, route, p.name)
+		case fromQuery:
+			value = restQueryValue(restServer, p.name)
+		default:
+			continue
+		}
+
+		if p.typ == "string" {
+			body = append(body, jen.Id(p.name).Op("=").Add(value))
+			continue
+		}
+
+		// parameter of query is optional, the zero value is used if it is not given
+		input := value
+		if p.source == fromQuery {
+			input = jen.Id(p.name + "Value")
+		}
+		parse, parsed := genParseValue(p.typ, input)
+		var assign jen.Code = jen.Id(p.name).Op("=").Id(p.name + "Parsed")
+		if parsed != p.typ {
+			assign = jen.Id(p.name).Op("=").Id(p.typ).Call(jen.Id(p.name + "Parsed"))
+		}
+		parseValue := []jen.Code{
+			jen.List(jen.Id(p.name+"Parsed"), jen.Err()).Op(":=").Add(parse),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				invalid(jen.Map(jen.String()).String().Values(jen.Dict{jen.Lit(p.name): jen.Lit("must be " + p.typ)})),
+			),
+			assign,
+		}
+
+		if p.source == fromQuery {
+			body = append(body, jen.If(jen.Id(p.name+"Value").Op(":=").Add(value), jen.Id(p.name+"Value").Op("!=").Lit("")).Block(parseValue...))
+			continue
+		}
+		body = append(body, parseValue...)
+	}
+
+	if len(bodies) > 0 {
+		var (
+			target jen.Code
+			assign []jen.Code
+		)
+
+		if len(bodies) == 1 {
+			p := bodies[0]
+			target = jen.Op("&").Id(p.name)
+			if strings.HasPrefix(p.typ, "*") {
+				typ, err := genTypeCode(p.typ[1:], imports)
+				if err != nil {
+					return nil, err
+				}
+				body = append(body, jen.Id(p.name).Op("=").New(typ))
+				target = jen.Id(p.name)
+			}
+		} else {
+			// every parameter is written as a field of json body which is named as the parameter
+			var fields, lhs, rhs []jen.Code
+			for _, p := range bodies {
+				typ, err := genTypeCode(p.typ, imports)
+				if err != nil {
+					return nil, err
+				}
+				field := strings.ToUpper(p.name[:1]) + p.name[1:]
+				fields = append(fields, jen.Id(field).Add(typ).Tag(map[string]string{"json": p.name}))
+				lhs = append(lhs, jen.Id(p.name))
+				rhs = append(rhs, jen.Id("request").Dot(field))
+			}
+			body = append(body, jen.Var().Id("request").Struct(fields...))
+			target = jen.Op("&").Id("request")
+			assign = append(assign, jen.List(lhs...).Op("=").List(rhs...))
+		}
+
+		body = append(body, jen.If(
+			jen.Err().Op(":=").Qual("github.com/json-iterator/go", "NewDecoder").Call(restBodyValue(restServer)).Dot("Decode").Call(target),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			invalid(jen.Map(jen.String()).String().Values(jen.Dict{jen.Lit("body"): jen.Lit("must be a valid json")})),
+		))
+		body = append(body, assign...)
+
+		// parameter of path is set after the body is decoded, so it wins over the field which is written in body
+		for _, ef := range entityFields {
+			var (
+				value  = restPathValue(restServer, route, ef.path)
+				target = jen.Id(entityBody.name).Dot(ef.field.Name)
+			)
+			if ef.field.Type == "string" {
+				body = append(body, target.Op("=").Add(value))
+				continue
+			}
+			parse, parsed := genParseValue(ef.field.Type, value)
+			var assign jen.Code = target.Clone().Op("=").Id(ef.path + "Parsed")
+			if parsed != ef.field.Type {
+				assign = target.Clone().Op("=").Id(ef.field.Type).Call(jen.Id(ef.path + "Parsed"))
+			}
+			body = append(body,
+				jen.List(jen.Id(ef.path+"Parsed"), jen.Err()).Op(":=").Add(parse),
+				jen.If(jen.Err().Op("!=").Nil()).Block(
+					invalid(jen.Map(jen.String()).String().Values(jen.Dict{jen.Lit(ef.path): jen.Lit("must be " + ef.field.Type)})),
+				),
+				assign,
+			)
+		}
+
+		// only domain type is validated, since the tags of validator are written on it
+		for _, p := range bodies {
+			if !strings.HasPrefix(strings.TrimPrefix(p.typ, "*"), "domain.") {
+				continue
+			}
+			body = append(body, jen.If(jen.List(jen.Id("valid"), jen.Err()).Op(":=").Qual(gomodName+"/middleware", "IsRequestValid").Call(jen.Id(p.name)), jen.Op("!").Id("valid")).Block(
+				invalid(jen.Qual(gomodName+"/middleware", "ValidationDetails").Call(jen.Err())),
+			))
+		}
+	}
+
+	body = append(body, invalid(jen.Nil()))
+
+	bind := "bind" + m.Name
+	return jen.Comment(fmt.Sprintf("%s will bind the request into parameters of %s, details of every invalid parameter is returned if the request is not valid", bind, m.Name)).Line().
+		Func().Params(jen.Id(string(domainName[0]) + "h").Op("*").Id(domainName + "Handler")).
+		Id(bind).Params(bindParam...).Params(results...).Block(body...), nil
+}
+
+// restPathValue will generate the reading of parameter of path
+func restPathValue(restServer string, route restRoute, name string) jen.Code {
+	switch restServer {
+	case domain.GorillaMux:
+		return jen.Qual("github.com/gorilla/mux", "Vars").Call(jen.Id("r")).Index(jen.Lit(name))
+	case domain.NetHTTP:
+		for idx, s := range route.segments() {
+			if s == ":"+name {
+				return jen.Id("s").Index(jen.Lit(idx))
+			}
+		}
+	}
+	return jen.Id("c").Dot("Param").Call(jen.Lit(name))
+}
+
+// restQueryValue will generate the reading of parameter of query
+func restQueryValue(restServer string, name string) jen.Code {
+	switch restServer {
+	case domain.Echo:
+		return jen.Id("c").Dot("QueryParam").Call(jen.Lit(name))
+	case domain.Gin:
+		return jen.Id("c").Dot("Query").Call(jen.Lit(name))
+	}
+	return jen.Id("r").Dot("URL").Dot("Query").Call().Dot("Get").Call(jen.Lit(name))
+}
+
+// restBodyValue will generate the reader of request body
+func restBodyValue(restServer string) jen.Code {
+	switch restServer {
+	case domain.Echo:
+		return jen.Id("c").Dot("Request").Call().Dot("Body")
+	case domain.Gin:
+		return jen.Id("c").Dot("Request").Dot("Body")
+	}
+	return jen.Id("r").Dot("Body")
+}
+
+// genParseValue will generate the parsing of string into the basic type, type of the parsed value is returned as well
+func genParseValue(typ string, value jen.Code) (jen.Code, string) {
+	bits := map[string]int{
+		"int8": 8, "int16": 16, "int32": 32, "rune": 32, "int64": 64,
+		"uint": 0, "uint8": 8, "byte": 8, "uint16": 16, "uint32": 32, "uint64": 64, "uintptr": 64,
+		"float32": 32, "float64": 64, "complex64": 64, "complex128": 128,
+	}
+	switch typ {
+	case "bool":
+		return jen.Qual("strconv", "ParseBool").Call(value), "bool"
+	case "int":
+		return jen.Qual("strconv", "Atoi").Call(value), "int"
+	case "int8", "int16", "int32", "rune", "int64":
+		return jen.Qual("strconv", "ParseInt").Call(value, jen.Lit(10), jen.Lit(bits[typ])), "int64"
+	case "float32", "float64":
+		return jen.Qual("strconv", "ParseFloat").Call(value, jen.Lit(bits[typ])), "float64"
+	case "complex64", "complex128":
+		return jen.Qual("strconv", "ParseComplex").Call(value, jen.Lit(bits[typ])), "complex128"
+	}
+	return jen.Qual("strconv", "ParseUint").Call(value, jen.Lit(10), jen.Lit(bits[typ])), "uint64"
+}
+
+// genRestHandle will generate body of handler which bind the request, call the usecase and write its results
+// as domain.ResponseSuccess, error of usecase is written with status of domain.GetStatusCode
+func (gen *caGen) genRestHandle(restServer string, domainName string, gomodName string, useCase string, route restRoute, m domain.Method) []jen.Code {
+	var (
+		body   []jen.Code
+		params = newRestParams(route, m)
+		args   []jen.Code
+		values []jen.Code
+		data   []jen.Code
+		hasErr bool
+		arg    = jen.Id("r")
+		ctx    = jen.Id("r").Dot("Context").Call()
+	)
+	switch restServer {
+	case domain.Echo:
+		arg = jen.Id("c")
+		ctx = jen.Id("c").Dot("Request").Call().Dot("Context").Call()
+	case domain.Gin:
+		arg = jen.Id("c")
+		ctx = jen.Id("c").Dot("Request").Dot("Context").Call()
+	}
+
+	if hasContext(m) {
+		body = append(body,
+			jen.Id("ctx").Op(":=").Add(ctx),
+			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
+		)
+	}
+
+	if len(params) > 0 {
+		var names []jen.Code
+		for _, p := range params {
+			names = append(names, jen.Id(p.name))
+		}
+		body = append(body,
+			jen.List(append(names, jen.Id("details"))...).Op(":=").Id(string(domainName[0])+"h").Dot("bind"+m.Name).Call(arg),
+			jen.If(jen.Id("details").Op("!=").Nil()).Block(
+				append([]jen.Code{
+					jen.Err().Op(":=").Qual(gomodName+"/domain", "ErrBadParamInput").Dot("WithDetails").Call(jen.Id("details")),
+				}, gen.restError(restServer, gomodName)...)...,
+			),
+		)
+	}
+
+	for idx, p := range m.ParameterList {
+		name := p.Name
+		if name == "" {
+			name = fmt.Sprintf("param%d", idx)
+		}
+		switch {
+		case p.Type == "context.Context":
+			args = append(args, jen.Id("ctx"))
+		case strings.HasPrefix(p.Type, "..."):
+			args = append(args, jen.Id(name).Op("..."))
+		default:
+			args = append(args, jen.Id(name))
+		}
+	}
+
+	nonErr := len(m.ResultList)
+	if nonErr > 0 && m.ResultList[nonErr-1].Type == "error" {
+		hasErr = true
+		nonErr--
+	}
+	for idx := 0; idx < nonErr; idx++ {
+		name := "res"
+		if nonErr > 1 {
+			name = fmt.Sprintf("res%d", idx)
+		}
+		values = append(values, jen.Id(name))
+		data = append(data, jen.Id(name))
+	}
+	if hasErr {
+		values = append(values, jen.Err())
+	}
+
+	var call jen.Code = jen.Id(string(domainName[0]) + "h").Dot(useCase).Dot(m.Name).Call(args...)
+	if len(values) > 0 {
+		call = jen.List(values...).Op(":=").Add(call)
+	}
+	body = append(body, call)
+	if hasErr {
+		body = append(body, jen.If(jen.Err().Op("!=").Nil()).Block(
+			gen.restError(restServer, gomodName)...,
+		))
+	}
+
+	success := jen.Dict{jen.Id("Message"): jen.Lit(fmt.Sprintf("%s %s successfully", m.Name, domainName))}
+	switch len(data) {
+	case 0:
+	case 1:
+		success[jen.Id("Data")] = data[0]
+	default:
+		success[jen.Id("Data")] = jen.Index().Interface().Values(data...)
+	}
+	return append(body, restJSON(restServer, jen.Qual("net/http", "StatusOK"), jen.Op("&").Qual(gomodName+"/domain", "ResponseSuccess").Values(success))...)
+}
+
+// hasContext will return true if the method receives context, so the context of request is passed to it
+func hasContext(m domain.Method) bool {
+	for _, p := range m.ParameterList {
+		if p.Type == "context.Context" {
+			return true
+		}
+	}
+	return false
+}
+
+// restJSON will generate the writing of json response with the given status, the handler returns after it
+func restJSON(restServer string, status jen.Code, value jen.Code) []jen.Code {
+	switch restServer {
+	case domain.Echo:
+		return []jen.Code{jen.Return(jen.Id("c").Dot("JSON").Call(status, value))}
+	case domain.Gin:
+		return []jen.Code{jen.Id("c").Dot("JSON").Call(status, value), jen.Return()}
+	}
+	return []jen.Code{
+		jen.Id("w").Dot("WriteHeader").Call(status),
+		jen.Qual("github.com/json-iterator/go", "NewEncoder").Call(jen.Id("w")).Dot("Encode").Call(value),
+		jen.Return(),
+	}
+}
+
+// restError will answer err with the status of domain.GetStatusCode, it is written as domain.ResponseError
+// or as problem details if the rest transport is generated with problem+json
+func (gen *caGen) restError(restServer string, gomodName string) []jen.Code {
+	if !gen.gen.ProblemJSON {
+		return restJSON(restServer, jen.Qual(gomodName+"/domain", "GetStatusCode").Call(jen.Err()), jen.Qual(gomodName+"/domain", "NewResponseError").Call(jen.Err()))
+	}
+	switch restServer {
+	case domain.Echo:
+		return []jen.Code{
+			jen.Qual(gomodName+"/domain", "WriteProblem").Call(jen.Id("c").Dot("Response").Call(), jen.Id("c").Dot("Request").Call(), jen.Err()),
+			jen.Return(jen.Nil()),
+		}
+	case domain.Gin:
+		return []jen.Code{jen.Qual(gomodName+"/domain", "WriteProblem").Call(jen.Id("c").Dot("Writer"), jen.Id("c").Dot("Request"), jen.Err()), jen.Return()}
+	}
+	return []jen.Code{jen.Qual(gomodName+"/domain", "WriteProblem").Call(jen.Id("w"), jen.Id("r"), jen.Err()), jen.Return()}
+}
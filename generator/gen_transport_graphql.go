@@ -0,0 +1,324 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/parser"
+)
+
+const graphqlPath = "github.com/graphql-go/graphql"
+
+// graphqlDomain represent a domain which is served by the graphql schema
+type graphqlDomain struct {
+	name     string
+	typeName string
+	parser   *domain.Parser
+}
+
+// graphqlDomains will return every domain which has its types inside the graphql transport, ordered by name.
+// The given domain is served from the given parser, the others are parsed from the domain layer of project
+func (gen *caGen) graphqlDomains(dirName string, domainName string, current *domain.Parser) ([]graphqlDomain, error) {
+	var (
+		domainDir = path.Join(path.Dir(path.Dir(dirName)), "domain")
+		domains   []graphqlDomain
+	)
+
+	res, err := gen.fs.ReadDir(dirName + "/types")
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range res {
+		if r.IsDir() || filepath.Ext(r.Name()) != ".go" {
+			continue
+		}
+		name := strings.TrimSuffix(r.Name(), ".go")
+		par := current
+		if name != domainName {
+			par, err = parser.NewParserDomain(gen.fs, name).DomainParser(domainDir + "/" + r.Name())
+			if err != nil {
+				return nil, fmt.Errorf("parse domain %s which is served by graphql: %s", name, err)
+			}
+		}
+		domains = append(domains, graphqlDomain{
+			name:     name,
+			typeName: strings.ToUpper(name[:1]) + name[1:],
+			parser:   par,
+		})
+	}
+	return domains, nil
+}
+
+// graphqlUsecaseFields will generate a field of every usecase which is served by the graphql schema
+func graphqlUsecaseFields(domains []graphqlDomain, gomodName string) []jen.Code {
+	var fields []jen.Code
+	for _, d := range domains {
+		fields = append(fields, jen.Id(d.parser.Usecase.Name).Qual(gomodName+"/domain", d.parser.Usecase.Name))
+	}
+	return fields
+}
+
+// graphqlUsecaseParams will generate a parameter of every usecase which is served by the graphql schema
+func graphqlUsecaseParams(domains []graphqlDomain, gomodName string) []jen.Code {
+	var params []jen.Code
+	for _, d := range domains {
+		params = append(params, jen.Id(graphqlUsecaseParam(d)).Qual(gomodName+"/domain", d.parser.Usecase.Name))
+	}
+	return params
+}
+
+// graphqlUsecaseValues will generate the fields of struct which are filled by the usecase parameters
+func graphqlUsecaseValues(domains []graphqlDomain) jen.Dict {
+	values := jen.Dict{}
+	for _, d := range domains {
+		values[jen.Id(d.parser.Usecase.Name)] = jen.Id(graphqlUsecaseParam(d))
+	}
+	return values
+}
+
+func graphqlUsecaseParam(d graphqlDomain) string {
+	return strings.ToLower(d.parser.Usecase.Name[:1]) + d.parser.Usecase.Name[1:]
+}
+
+// graphqlType will return the graphql type of go type, entity of domain is served as its object type,
+// or as its input type if it is an argument. Type which is unknown to graphql is served as string
+func graphqlType(typ string, parser *domain.Parser, gomodName string, typeName string, input bool) jen.Code {
+	if strings.HasPrefix(typ, "...") {
+		typ = "[]" + typ[3:]
+	}
+	typ = strings.TrimPrefix(typ, "*")
+	if strings.HasPrefix(typ, "[]") && typ != "[]byte" {
+		return jen.Qual(graphqlPath, "NewList").Call(graphqlType(typ[2:], parser, gomodName, typeName, input))
+	}
+
+	switch typ {
+	case "domain." + parser.Entity.Name:
+		if input {
+			return jen.Qual(gomodName+"/transport/graphql/types", typeName+"Input")
+		}
+		return jen.Qual(gomodName+"/transport/graphql/types", typeName+"Type")
+	case "bool":
+		return jen.Qual(graphqlPath, "Boolean")
+	case "int", "int8", "int16", "int32", "int64", "rune",
+		"uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		return jen.Qual(graphqlPath, "Int")
+	case "float32", "float64":
+		return jen.Qual(graphqlPath, "Float")
+	case "time.Time":
+		return jen.Qual(graphqlPath, "DateTime")
+	}
+	return jen.Qual(graphqlPath, "String")
+}
+
+// graphqlFieldName will return name of entity field in graphql, it is the json name
+// since the default resolver of graphql reads the field of struct by its json tag
+func graphqlFieldName(f domain.Field) string {
+	name := strings.Split(f.Tags["json"], ",")[0]
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// genGraphqlTypes will generate the object type and the input type of entity, every field of entity
+// which is written as json is served. Entity without field is served with id only
+func genGraphqlTypes(f *jen.File, parser *domain.Parser, gomodName string, typeName string, domainName string) {
+	var (
+		fields = jen.Dict{}
+		inputs = jen.Dict{}
+	)
+	for _, i := range parser.Entity.Fields {
+		name := graphqlFieldName(i)
+		if i.Embedded || name == "-" {
+			continue
+		}
+		fields[jen.Lit(name)] = jen.Op("&").Qual(graphqlPath, "Field").Values(jen.Dict{
+			jen.Id("Type"): graphqlType(i.Type, parser, gomodName, typeName, false),
+		})
+		inputs[jen.Lit(name)] = jen.Op("&").Qual(graphqlPath, "InputObjectFieldConfig").Values(jen.Dict{
+			jen.Id("Type"): graphqlType(i.Type, parser, gomodName, typeName, true),
+		})
+	}
+	if len(fields) == 0 {
+		fields[jen.Lit("id")] = jen.Op("&").Qual(graphqlPath, "Field").Values(jen.Dict{jen.Id("Type"): jen.Qual(graphqlPath, "ID")})
+		inputs[jen.Lit("id")] = jen.Op("&").Qual(graphqlPath, "InputObjectFieldConfig").Values(jen.Dict{jen.Id("Type"): jen.Qual(graphqlPath, "ID")})
+	}
+
+	f.Comment(fmt.Sprintf("%sType is the GraphQL schema for the %s type.", typeName, domainName))
+	f.Var().Id(typeName+"Type").Op("=").Qual(graphqlPath, "NewObject").Call(
+		jen.Qual(graphqlPath, "ObjectConfig").Values(jen.Dict{
+			jen.Id("Name"):   jen.Lit(typeName),
+			jen.Id("Fields"): jen.Qual(graphqlPath, "Fields").Values(fields),
+		}),
+	)
+	f.Line()
+	f.Comment(fmt.Sprintf("%sInput is the GraphQL schema for the %s argument.", typeName, domainName))
+	f.Var().Id(typeName+"Input").Op("=").Qual(graphqlPath, "NewInputObject").Call(
+		jen.Qual(graphqlPath, "InputObjectConfig").Values(jen.Dict{
+			jen.Id("Name"):   jen.Lit(typeName + "Input"),
+			jen.Id("Fields"): jen.Qual(graphqlPath, "InputObjectConfigFieldMap").Values(inputs),
+		}),
+	)
+
+	// method which returns several values is served as object of its results
+	for _, m := range parser.Usecase.Method {
+		results := valueResults(m)
+		if len(results) < 2 {
+			continue
+		}
+		resultFields := jen.Dict{}
+		for idx, r := range results {
+			resultFields[jen.Lit(fmt.Sprintf("data%d", idx))] = jen.Op("&").Qual(graphqlPath, "Field").Values(jen.Dict{
+				jen.Id("Type"): graphqlType(r.Type, parser, gomodName, typeName, false),
+			})
+		}
+		resultName := m.Name + typeName + "Result"
+		f.Line()
+		f.Comment(fmt.Sprintf("%sType is the GraphQL schema for the results of %s.", resultName, m.Name))
+		f.Var().Id(resultName+"Type").Op("=").Qual(graphqlPath, "NewObject").Call(
+			jen.Qual(graphqlPath, "ObjectConfig").Values(jen.Dict{
+				jen.Id("Name"):   jen.Lit(resultName),
+				jen.Id("Fields"): jen.Qual(graphqlPath, "Fields").Values(resultFields),
+			}),
+		)
+	}
+}
+
+// isGraphqlQuery will return true if the method is served as query, which is the method served by GET in rest.
+// Every method is served as query if none of them is served by GET, since graphql requires a query
+func isGraphqlQuery(domainName string, parser *domain.Parser, m domain.Method) bool {
+	for _, i := range parser.Usecase.Method {
+		if newRestRoute(domainName, i).verb == "GET" {
+			return newRestRoute(domainName, m).verb == "GET"
+		}
+	}
+	return true
+}
+
+// genGraphqlField will generate the field of query or mutation which bind the arguments into parameters of usecase,
+// call the usecase and resolve its results. Method without result except error is resolved as true
+func genGraphqlField(recv string, parser *domain.Parser, gomodName string, typeName string, m domain.Method) (jen.Code, error) {
+	var (
+		imports  = map[string]string{"domain": gomodName + "/domain"}
+		argTypes = jen.Dict{}
+		fields   []jen.Code
+		validate []jen.Code
+		args     []jen.Code
+		values   []jen.Code
+		body     []jen.Code
+		typ      jen.Code
+		resolved jen.Code
+		results  = valueResults(m)
+		hasErr   = len(m.ResultList) > len(results)
+	)
+
+	if hasContext(m) {
+		body = append(body,
+			jen.Id("ctx").Op(":=").Id("params").Dot("Context"),
+			jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()),
+		)
+	}
+
+	for idx, p := range m.ParameterList {
+		if p.Type == "context.Context" {
+			args = append(args, jen.Id("ctx"))
+			continue
+		}
+		name, paramType := p.Name, p.Type
+		if name == "" {
+			name = fmt.Sprintf("param%d", idx)
+		}
+		if strings.HasPrefix(paramType, "...") {
+			paramType = "[]" + paramType[3:]
+		}
+		goType, err := genTypeCode(paramType, imports)
+		if err != nil {
+			return nil, err
+		}
+
+		field := strings.ToUpper(name[:1]) + name[1:]
+		argTypes[jen.Lit(name)] = jen.Op("&").Qual(graphqlPath, "ArgumentConfig").Values(jen.Dict{
+			jen.Id("Type"): graphqlType(paramType, parser, gomodName, typeName, true),
+		})
+		fields = append(fields, jen.Id(field).Add(goType).Tag(map[string]string{"json": name}))
+
+		arg := jen.Id("args").Dot(field)
+		if strings.HasPrefix(p.Type, "...") {
+			arg = arg.Op("...")
+		}
+		args = append(args, arg)
+
+		// only domain type is validated, since the tags of validator are written on it
+		if strings.HasPrefix(strings.TrimPrefix(paramType, "*"), "domain.") {
+			validate = append(validate, jen.If(jen.List(jen.Id("valid"), jen.Err()).Op(":=").Qual(gomodName+"/middleware", "IsRequestValid").Call(jen.Id("args").Dot(field)), jen.Op("!").Id("valid")).Block(
+				jen.Return(jen.Nil(), jen.Qual(gomodName+"/domain", "GetGraphqlError").Call(
+					jen.Qual(gomodName+"/domain", "ErrBadParamInput").Dot("WithDetails").Call(jen.Qual(gomodName+"/middleware", "ValidationDetails").Call(jen.Err())),
+				)),
+			))
+		}
+	}
+
+	if len(fields) > 0 {
+		body = append(body,
+			jen.Var().Id("args").Struct(fields...),
+			jen.If(jen.Err().Op(":=").Id("bindArgument").Call(jen.Id("params").Dot("Args"), jen.Op("&").Id("args")), jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Nil(), jen.Qual(gomodName+"/domain", "GetGraphqlError").Call(jen.Qual(gomodName+"/domain", "ErrBadParamInput").Dot("Wrap").Call(jen.Err()))),
+			),
+		)
+		body = append(body, validate...)
+	}
+
+	switch len(results) {
+	case 0:
+		typ = jen.Qual(graphqlPath, "Boolean")
+		resolved = jen.True()
+	case 1:
+		typ = graphqlType(results[0].Type, parser, gomodName, typeName, false)
+		values = append(values, jen.Id("res"))
+		resolved = jen.Id("res")
+	default:
+		typ = jen.Qual(gomodName+"/transport/graphql/types", m.Name+typeName+"ResultType")
+		data := jen.Dict{}
+		for idx := range results {
+			name := fmt.Sprintf("res%d", idx)
+			values = append(values, jen.Id(name))
+			data[jen.Lit(fmt.Sprintf("data%d", idx))] = jen.Id(name)
+		}
+		resolved = jen.Map(jen.String()).Interface().Values(data)
+	}
+	if hasErr {
+		values = append(values, jen.Err())
+	}
+
+	var call jen.Code = jen.Id(recv).Dot(parser.Usecase.Name).Dot(m.Name).Call(args...)
+	if len(values) > 0 {
+		call = jen.List(values...).Op(":=").Add(call)
+	}
+	body = append(body, call)
+	if hasErr {
+		body = append(body, jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Qual(gomodName+"/domain", "GetGraphqlError").Call(jen.Err()))))
+	}
+	body = append(body, jen.Return(resolved, jen.Nil()))
+
+	return jen.Op("&").Qual(graphqlPath, "Field").Values(jen.Dict{
+		jen.Id("Type"):        typ,
+		jen.Id("Description"): jen.Lit(typeName),
+		jen.Id("Args"):        jen.Qual(graphqlPath, "FieldConfigArgument").Values(argTypes),
+		jen.Id("Resolve"):     jen.Func().Params(jen.Id("params").Qual(graphqlPath, "ResolveParams")).Call(jen.Interface(), jen.Error()).Block(body...),
+	}), nil
+}
+
+// genGraphqlBindArgument will generate bindArgument which decode the arguments of graphql request
+// into parameters of usecase through their json names
+func genGraphqlBindArgument(f *jen.File) {
+	f.Comment("bindArgument will decode the arguments of request into v through their json names")
+	f.Func().Id("bindArgument").Params(jen.Id("args").Map(jen.String()).Interface(), jen.Id("v").Interface()).Error().Block(
+		jen.List(jen.Id("b"), jen.Err()).Op(":=").Qual("github.com/json-iterator/go", "Marshal").Call(jen.Id("args")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+		jen.Return(jen.Qual("github.com/json-iterator/go", "Unmarshal").Call(jen.Id("b"), jen.Id("v"))),
+	)
+}
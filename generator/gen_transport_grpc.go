@@ -0,0 +1,380 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/wicaker/cacli/domain"
+)
+
+const timestampPath = "github.com/golang/protobuf/ptypes/timestamp"
+
+// grpcConverter will generate the conversion between types of domain and messages of protobuf,
+// helpers of conversion are generated once they are used
+type grpcConverter struct {
+	parser    *domain.Parser
+	gomodName string
+	message   string
+	imports   map[string]string
+	helpers   map[string]bool
+}
+
+func newGrpcConverter(parser *domain.Parser, gomodName string, message string) *grpcConverter {
+	return &grpcConverter{
+		parser:    parser,
+		gomodName: gomodName,
+		message:   message,
+		imports:   map[string]string{"domain": gomodName + "/domain"},
+		helpers:   map[string]bool{},
+	}
+}
+
+// protoGoType will return type of the protobuf scalar in go code
+func protoGoType(typ string) string {
+	switch typ {
+	case "double":
+		return "float64"
+	case "float":
+		return "float32"
+	case "bytes":
+		return "[]byte"
+	}
+	return typ
+}
+
+// toProto will generate the conversion of value of go type into its protobuf type
+func (c *grpcConverter) toProto(typ string, value *jen.Statement) (jen.Code, bool) {
+	return c.convert(typ, value, true)
+}
+
+// fromProto will generate the conversion of value of protobuf type into its go type
+func (c *grpcConverter) fromProto(typ string, value *jen.Statement) (jen.Code, bool) {
+	return c.convert(typ, value, false)
+}
+
+func (c *grpcConverter) convert(typ string, value *jen.Statement, toProto bool) (jen.Code, bool) {
+	protoTyp, ok := protoType(typ, c.parser, c.message)
+	if !ok {
+		return nil, false
+	}
+	entity := "domain." + c.parser.Entity.Name
+
+	helper := func(name string) *jen.Statement {
+		c.helpers[name] = true
+		return jen.Id(name)
+	}
+	switch typ {
+	case "*" + entity:
+		if toProto {
+			return helper("toProto" + c.message).Call(value), true
+		}
+		return helper("fromProto" + c.message).Call(value), true
+	case entity:
+		if toProto {
+			return helper("toProto" + c.message).Call(jen.Op("&").Add(value)), true
+		}
+		return jen.Op("*").Add(helper("fromProto" + c.message).Call(value)), true
+	case "[]*" + entity:
+		if toProto {
+			return helper("toProto" + c.message + "List").Call(value), true
+		}
+		return helper("fromProto" + c.message + "List").Call(value), true
+	case "[]" + entity:
+		if toProto {
+			return helper("toProto" + c.message + "Values").Call(value), true
+		}
+		return helper("fromProto" + c.message + "Values").Call(value), true
+	case "time.Time":
+		if toProto {
+			return helper("toProtoTime").Call(value), true
+		}
+		return helper("fromProtoTime").Call(value), true
+	case "*time.Time":
+		if toProto {
+			return helper("toProtoTimePtr").Call(value), true
+		}
+		return helper("fromProtoTimePtr").Call(value), true
+	}
+
+	// slice of scalar is carried as it is, cast of its element is not supported
+	if strings.HasPrefix(protoTyp, "repeated ") {
+		if "[]"+protoGoType(strings.TrimPrefix(protoTyp, "repeated ")) != typ {
+			return nil, false
+		}
+		return value, true
+	}
+	if protoGoType(protoTyp) == typ {
+		return value, true
+	}
+	if toProto {
+		return jen.Id(protoGoType(protoTyp)).Call(value), true
+	}
+	return jen.Id(typ).Call(value), true
+}
+
+// genHelpers will generate the helpers of conversion which are used, helpers of entity are generated first
+// since they may use the helpers of time
+func (c *grpcConverter) genHelpers(f *jen.File) {
+	var (
+		pb       = c.gomodName + "/proto"
+		entity   = jen.Qual(c.gomodName+"/domain", c.parser.Entity.Name)
+		message  = jen.Qual(pb, c.message)
+		toList   = "toProto" + c.message + "List"
+		fromList = "fromProto" + c.message + "List"
+		toVals   = "toProto" + c.message + "Values"
+		fromVals = "fromProto" + c.message + "Values"
+	)
+	if c.helpers[toList] || c.helpers[toVals] {
+		c.helpers["toProto"+c.message] = true
+	}
+	if c.helpers[fromList] || c.helpers[fromVals] {
+		c.helpers["fromProto"+c.message] = true
+	}
+
+	if c.helpers["toProto"+c.message] {
+		fields := jen.Dict{}
+		for _, i := range c.parser.Entity.Fields {
+			if i.Embedded {
+				continue
+			}
+			value, ok := c.toProto(i.Type, jen.Id("e").Dot(i.Name))
+			if ok {
+				fields[jen.Id(protoGoName(toSnakeCase(i.Name)))] = value
+			}
+		}
+		f.Line()
+		f.Comment(fmt.Sprintf("toProto%s will convert %s of domain into its message", c.message, c.parser.Entity.Name))
+		f.Func().Id("toProto"+c.message).Params(jen.Id("e").Op("*").Add(entity)).Op("*").Add(message).Block(
+			jen.If(jen.Id("e").Op("==").Nil()).Block(jen.Return(jen.Nil())),
+			jen.Return(jen.Op("&").Add(message).Values(fields)),
+		)
+	}
+	if c.helpers["fromProto"+c.message] {
+		fields := jen.Dict{}
+		for _, i := range c.parser.Entity.Fields {
+			if i.Embedded {
+				continue
+			}
+			value, ok := c.fromProto(i.Type, jen.Id("m").Dot("Get"+protoGoName(toSnakeCase(i.Name))).Call())
+			if ok {
+				fields[jen.Id(i.Name)] = value
+			}
+		}
+		f.Line()
+		f.Comment(fmt.Sprintf("fromProto%s will convert message of %s into %s of domain, getters of message are safe for nil message", c.message, c.parser.Entity.Name, c.parser.Entity.Name))
+		f.Func().Id("fromProto" + c.message).Params(jen.Id("m").Op("*").Add(message)).Op("*").Add(entity).Block(
+			jen.Return(jen.Op("&").Add(entity).Values(fields)),
+		)
+	}
+
+	lists := []struct {
+		name   string
+		param  jen.Code
+		result jen.Code
+		value  jen.Code
+		index  bool
+	}{
+		{toList, jen.Index().Op("*").Add(entity), jen.Index().Op("*").Add(message), jen.Id("toProto" + c.message).Call(jen.Id("v")), false},
+		{fromList, jen.Index().Op("*").Add(message), jen.Index().Op("*").Add(entity), jen.Id("fromProto" + c.message).Call(jen.Id("v")), false},
+		{toVals, jen.Index().Add(entity), jen.Index().Op("*").Add(message), jen.Id("toProto" + c.message).Call(jen.Op("&").Id("l").Index(jen.Id("i"))), true},
+		{fromVals, jen.Index().Op("*").Add(message), jen.Index().Add(entity), jen.Op("*").Id("fromProto" + c.message).Call(jen.Id("v")), false},
+	}
+	for _, l := range lists {
+		if !c.helpers[l.name] {
+			continue
+		}
+		loop := jen.List(jen.Id("_"), jen.Id("v")).Op(":=").Range().Id("l")
+		if l.index {
+			loop = jen.Id("i").Op(":=").Range().Id("l")
+		}
+		f.Line()
+		f.Comment(fmt.Sprintf("%s will convert every element of the list", l.name))
+		f.Func().Id(l.name).Params(jen.Id("l").Add(l.param)).Add(l.result).Block(
+			jen.Id("res").Op(":=").Make(l.result, jen.Lit(0), jen.Len(jen.Id("l"))),
+			jen.For(loop).Block(
+				jen.Id("res").Op("=").Append(jen.Id("res"), l.value),
+			),
+			jen.Return(jen.Id("res")),
+		)
+	}
+
+	if c.helpers["toProtoTimePtr"] {
+		c.helpers["toProtoTime"] = true
+	}
+	if c.helpers["fromProtoTimePtr"] {
+		c.helpers["fromProtoTime"] = true
+	}
+	if c.helpers["toProtoTime"] {
+		f.Line()
+		f.Comment("toProtoTime will convert time into timestamp of protobuf")
+		f.Func().Id("toProtoTime").Params(jen.Id("t").Qual("time", "Time")).Op("*").Qual(timestampPath, "Timestamp").Block(
+			jen.Return(jen.Op("&").Qual(timestampPath, "Timestamp").Values(jen.Dict{
+				jen.Id("Seconds"): jen.Id("t").Dot("Unix").Call(),
+				jen.Id("Nanos"):   jen.Int32().Call(jen.Id("t").Dot("Nanosecond").Call()),
+			})),
+		)
+	}
+	if c.helpers["toProtoTimePtr"] {
+		f.Line()
+		f.Comment("toProtoTimePtr will convert time into timestamp of protobuf, nil time is left as nil")
+		f.Func().Id("toProtoTimePtr").Params(jen.Id("t").Op("*").Qual("time", "Time")).Op("*").Qual(timestampPath, "Timestamp").Block(
+			jen.If(jen.Id("t").Op("==").Nil()).Block(jen.Return(jen.Nil())),
+			jen.Return(jen.Id("toProtoTime").Call(jen.Op("*").Id("t"))),
+		)
+	}
+	if c.helpers["fromProtoTime"] {
+		f.Line()
+		f.Comment("fromProtoTime will convert timestamp of protobuf into time, nil timestamp become zero time")
+		f.Func().Id("fromProtoTime").Params(jen.Id("ts").Op("*").Qual(timestampPath, "Timestamp")).Qual("time", "Time").Block(
+			jen.If(jen.Id("ts").Op("==").Nil()).Block(jen.Return(jen.Qual("time", "Time").Values())),
+			jen.Return(jen.Qual("time", "Unix").Call(jen.Id("ts").Dot("GetSeconds").Call(), jen.Int64().Call(jen.Id("ts").Dot("GetNanos").Call())).Dot("UTC").Call()),
+		)
+	}
+	if c.helpers["fromProtoTimePtr"] {
+		f.Line()
+		f.Comment("fromProtoTimePtr will convert timestamp of protobuf into time, nil timestamp is left as nil")
+		f.Func().Id("fromProtoTimePtr").Params(jen.Id("ts").Op("*").Qual(timestampPath, "Timestamp")).Op("*").Qual("time", "Time").Block(
+			jen.If(jen.Id("ts").Op("==").Nil()).Block(jen.Return(jen.Nil())),
+			jen.Id("t").Op(":=").Id("fromProtoTime").Call(jen.Id("ts")),
+			jen.Return(jen.Op("&").Id("t")),
+		)
+	}
+}
+
+// genGrpcHandle will generate body of grpc handler which read the request into parameters of usecase,
+// call the usecase and write its results into the response. Parameter which is not carried by the request
+// is passed as its zero value
+func (c *grpcConverter) genGrpcHandle(recv string, useCase string, m domain.Method) ([]jen.Code, error) {
+	var (
+		pb      = c.gomodName + "/proto"
+		resp    = m.Name + c.message + "Resp"
+		body    []jen.Code
+		args    []jen.Code
+		values  []jen.Code
+		data    = jen.Dict{}
+		results = protoResults(c.parser, c.message, m)
+		hasErr  = len(m.ResultList) > len(results)
+	)
+	body = append(body, jen.If(jen.Id("ctx").Op("==").Nil()).Block(jen.Id("ctx").Op("=").Qual("context", "Background").Call()))
+
+	params := protoParams(c.parser, c.message, m)
+	for _, p := range params {
+		value, ok := c.fromProto(p.goType, jen.Id("req").Dot("Get"+protoGoName(p.name)).Call())
+		if !ok {
+			typ, err := genTypeCode(p.goType, c.imports)
+			if err != nil {
+				return nil, err
+			}
+			body = append(body, jen.Var().Id(p.goName).Add(typ).Comment(p.goType+" is not carried by protobuf message"))
+			continue
+		}
+		body = append(body, jen.Id(p.goName).Op(":=").Add(value))
+	}
+
+	// only domain type is validated, since the tags of validator are written on it
+	for _, p := range params {
+		if !strings.HasPrefix(strings.TrimPrefix(p.goType, "*"), "domain.") {
+			continue
+		}
+		body = append(body, jen.If(jen.List(jen.Id("valid"), jen.Err()).Op(":=").Qual(c.gomodName+"/middleware", "IsRequestValid").Call(jen.Id(p.goName)), jen.Op("!").Id("valid")).Block(
+			jen.Return(jen.Nil(), jen.Id("grpcError").Call(
+				jen.Qual(c.gomodName+"/domain", "ErrBadParamInput").Dot("WithDetails").Call(jen.Qual(c.gomodName+"/middleware", "ValidationDetails").Call(jen.Err())),
+			)),
+		))
+	}
+
+	idx := 0
+	for _, p := range m.ParameterList {
+		if p.Type == "context.Context" {
+			args = append(args, jen.Id("ctx"))
+			continue
+		}
+		arg := jen.Id(params[idx].goName)
+		if params[idx].variadic {
+			arg = arg.Op("...")
+		}
+		args = append(args, arg)
+		idx++
+	}
+
+	for _, r := range results {
+		value, ok := c.toProto(r.goType, jen.Id(r.goName))
+		if !ok {
+			values = append(values, jen.Id("_"))
+			continue
+		}
+		values = append(values, jen.Id(r.goName))
+		data[jen.Id(protoGoName(r.name))] = value
+	}
+	if hasErr {
+		values = append(values, jen.Err())
+	}
+
+	var call jen.Code = jen.Id(recv).Dot(useCase).Dot(m.Name).Call(args...)
+	if len(data) > 0 || hasErr {
+		call = jen.List(values...).Op(":=").Add(call)
+	}
+	body = append(body, call)
+	if hasErr {
+		body = append(body, jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("grpcError").Call(jen.Err()))))
+	}
+	return append(body, jen.Return(jen.Op("&").Qual(pb, resp).Values(data), jen.Nil())), nil
+}
+
+// genGrpcErrors will generate grpcError which map error of domain into status of grpc,
+// details of error are carried as the field violations of bad request
+func (gen *caGen) genGrpcErrors(dirName string, gomodName string) error {
+	var (
+		codesPath  = "google.golang.org/grpc/codes"
+		statusPath = "google.golang.org/grpc/status"
+		detailPath = "google.golang.org/genproto/googleapis/rpc/errdetails"
+		f          = jen.NewFile("grpchandler")
+	)
+	f.ImportNames(map[string]string{
+		gomodName + "/domain": "domain",
+		codesPath:             "codes",
+		statusPath:            "status",
+		detailPath:            "errdetails",
+	})
+	f.ImportAlias("github.com/sirupsen/logrus", "log")
+
+	f.Comment("grpcCode will return code of grpc based on code of error")
+	f.Func().Id("grpcCode").Params(jen.Id("code").Qual(gomodName+"/domain", "ErrorCode")).Qual(codesPath, "Code").Block(
+		jen.Switch(jen.Id("code")).Block(
+			jen.Case(jen.Qual(gomodName+"/domain", "CodeNotFound")).Block(jen.Return(jen.Qual(codesPath, "NotFound"))),
+			jen.Case(jen.Qual(gomodName+"/domain", "CodeConflict")).Block(jen.Return(jen.Qual(codesPath, "AlreadyExists"))),
+			jen.Case(jen.Qual(gomodName+"/domain", "CodeBadParamInput")).Block(jen.Return(jen.Qual(codesPath, "InvalidArgument"))),
+			jen.Case(jen.Qual(gomodName+"/domain", "CodeUnauthorized")).Block(jen.Return(jen.Qual(codesPath, "Unauthenticated"))),
+			jen.Default().Block(jen.Return(jen.Qual(codesPath, "Internal"))),
+		),
+	)
+	f.Line()
+	f.Comment("grpcError will return status error of grpc based on code of error, details are carried as bad request")
+	f.Func().Id("grpcError").Params(jen.Err().Error()).Error().Block(
+		jen.If(jen.Err().Op("==").Nil()).Block(jen.Return(jen.Nil())),
+		jen.Qual("github.com/sirupsen/logrus", "Error").Call(jen.Err()),
+		jen.Id("e").Op(":=").Qual(gomodName+"/domain", "AsError").Call(jen.Err()),
+		jen.Id("st").Op(":=").Qual(statusPath, "New").Call(jen.Id("grpcCode").Call(jen.Id("e").Dot("Code")), jen.Id("e").Dot("Message")),
+		jen.If(jen.Len(jen.Id("e").Dot("Details")).Op("==").Lit(0)).Block(jen.Return(jen.Id("st").Dot("Err").Call())),
+		jen.Line(),
+		jen.Id("fields").Op(":=").Make(jen.Index().Id("string"), jen.Lit(0), jen.Len(jen.Id("e").Dot("Details"))),
+		jen.For(jen.Id("field").Op(":=").Range().Id("e").Dot("Details")).Block(
+			jen.Id("fields").Op("=").Append(jen.Id("fields"), jen.Id("field")),
+		),
+		jen.Qual("sort", "Strings").Call(jen.Id("fields")),
+		jen.Id("badRequest").Op(":=").Op("&").Qual(detailPath, "BadRequest").Values(),
+		jen.For(jen.List(jen.Id("_"), jen.Id("field")).Op(":=").Range().Id("fields")).Block(
+			jen.Id("badRequest").Dot("FieldViolations").Op("=").Append(jen.Id("badRequest").Dot("FieldViolations"), jen.Op("&").Qual(detailPath, "BadRequest_FieldViolation").Values(jen.Dict{
+				jen.Id("Field"):       jen.Id("field"),
+				jen.Id("Description"): jen.Id("e").Dot("Details").Index(jen.Id("field")),
+			})),
+		),
+		jen.If(jen.List(jen.Id("detailed"), jen.Err()).Op(":=").Id("st").Dot("WithDetails").Call(jen.Id("badRequest")), jen.Err().Op("==").Nil()).Block(
+			jen.Return(jen.Id("detailed").Dot("Err").Call()),
+		),
+		jen.Return(jen.Id("st").Dot("Err").Call()),
+	)
+
+	return gen.save(f, dirName+"/errors.go")
+}
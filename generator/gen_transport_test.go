@@ -19,8 +19,11 @@ const (
 import (
 	"context"
 	"github.com/example/exampletranposport/domain"
+	"github.com/example/exampletranposport/middleware"
+	json "github.com/json-iterator/go"
 	"github.com/labstack/echo"
 	"net/http"
+	"strconv"
 )
 
 type exampleHandler struct {
@@ -30,11 +33,11 @@ type exampleHandler struct {
 // NewExampleHandler will initialize the example endpoint
 func NewExampleHandler(e *echo.Echo, u domain.ExampleUsecase) {
 	handler := &exampleHandler{ExampleUsecase: u}
-	e.GET("/example/fetch", handler.FetchHandler)
-	e.GET("/example/getbyid", handler.GetByIDHandler)
-	e.GET("/example/store", handler.StoreHandler)
-	e.GET("/example/update", handler.UpdateHandler)
-	e.GET("/example/delete", handler.DeleteHandler)
+	e.GET("/examples", handler.FetchHandler)
+	e.GET("/examples/:id", handler.GetByIDHandler)
+	e.POST("/examples", handler.StoreHandler)
+	e.PUT("/examples/:id", handler.UpdateHandler)
+	e.DELETE("/examples/:id", handler.DeleteHandler)
 }
 
 func (eh *exampleHandler) FetchHandler(c echo.Context) error {
@@ -42,9 +45,14 @@ func (eh *exampleHandler) FetchHandler(c echo.Context) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "FetchHandler"
-	return c.JSON(http.StatusOK, domain.ResponseData)
+	res, err := eh.ExampleUsecase.Fetch(ctx)
+	if err != nil {
+		return c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+	}
+	return c.JSON(http.StatusOK, &domain.ResponseSuccess{
+		Data:    res,
+		Message: "Fetch example successfully",
+	})
 }
 
 func (eh *exampleHandler) GetByIDHandler(c echo.Context) error {
@@ -52,9 +60,29 @@ func (eh *exampleHandler) GetByIDHandler(c echo.Context) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "GetByIDHandler"
-	return c.JSON(http.StatusOK, domain.ResponseData)
+	id, details := eh.bindGetByID(c)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		return c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+	}
+	res, err := eh.ExampleUsecase.GetByID(ctx, id)
+	if err != nil {
+		return c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+	}
+	return c.JSON(http.StatusOK, &domain.ResponseSuccess{
+		Data:    res,
+		Message: "GetByID example successfully",
+	})
+}
+
+// bindGetByID will bind the request into parameters of GetByID, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindGetByID(c echo.Context) (id uint64, details map[string]string) {
+	idParsed, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return id, map[string]string{"id": "must be uint64"}
+	}
+	id = idParsed
+	return id, nil
 }
 
 func (eh *exampleHandler) StoreHandler(c echo.Context) error {
@@ -62,9 +90,31 @@ func (eh *exampleHandler) StoreHandler(c echo.Context) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "StoreHandler"
-	return c.JSON(http.StatusOK, domain.ResponseData)
+	exp, details := eh.bindStore(c)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		return c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+	}
+	res, err := eh.ExampleUsecase.Store(ctx, exp)
+	if err != nil {
+		return c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+	}
+	return c.JSON(http.StatusOK, &domain.ResponseSuccess{
+		Data:    res,
+		Message: "Store example successfully",
+	})
+}
+
+// bindStore will bind the request into parameters of Store, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindStore(c echo.Context) (exp *domain.Example, details map[string]string) {
+	exp = new(domain.Example)
+	if err := json.NewDecoder(c.Request().Body).Decode(exp); err != nil {
+		return exp, map[string]string{"body": "must be a valid json"}
+	}
+	if valid, err := middleware.IsRequestValid(exp); !valid {
+		return exp, middleware.ValidationDetails(err)
+	}
+	return exp, nil
 }
 
 func (eh *exampleHandler) UpdateHandler(c echo.Context) error {
@@ -72,9 +122,36 @@ func (eh *exampleHandler) UpdateHandler(c echo.Context) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "UpdateHandler"
-	return c.JSON(http.StatusOK, domain.ResponseData)
+	exp, details := eh.bindUpdate(c)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		return c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+	}
+	res, err := eh.ExampleUsecase.Update(ctx, exp)
+	if err != nil {
+		return c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+	}
+	return c.JSON(http.StatusOK, &domain.ResponseSuccess{
+		Data:    res,
+		Message: "Update example successfully",
+	})
+}
+
+// bindUpdate will bind the request into parameters of Update, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindUpdate(c echo.Context) (exp *domain.Example, details map[string]string) {
+	exp = new(domain.Example)
+	if err := json.NewDecoder(c.Request().Body).Decode(exp); err != nil {
+		return exp, map[string]string{"body": "must be a valid json"}
+	}
+	idParsed, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return exp, map[string]string{"id": "must be uint64"}
+	}
+	exp.ID = idParsed
+	if valid, err := middleware.IsRequestValid(exp); !valid {
+		return exp, middleware.ValidationDetails(err)
+	}
+	return exp, nil
 }
 
 func (eh *exampleHandler) DeleteHandler(c echo.Context) error {
@@ -82,9 +159,26 @@ func (eh *exampleHandler) DeleteHandler(c echo.Context) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "DeleteHandler"
-	return c.JSON(http.StatusOK, domain.ResponseData)
+	id, details := eh.bindDelete(c)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		return c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+	}
+	err := eh.ExampleUsecase.Delete(ctx, id)
+	if err != nil {
+		return c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+	}
+	return c.JSON(http.StatusOK, &domain.ResponseSuccess{Message: "Delete example successfully"})
+}
+
+// bindDelete will bind the request into parameters of Delete, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindDelete(c echo.Context) (id uint64, details map[string]string) {
+	idParsed, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return id, map[string]string{"id": "must be uint64"}
+	}
+	id = idParsed
+	return id, nil
 }
 `
 
@@ -93,8 +187,11 @@ func (eh *exampleHandler) DeleteHandler(c echo.Context) error {
 import (
 	"context"
 	"github.com/example/exampletranposport/domain"
+	"github.com/example/exampletranposport/middleware"
 	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
 	"net/http"
+	"strconv"
 )
 
 type exampleHandler struct {
@@ -104,11 +201,11 @@ type exampleHandler struct {
 // NewExampleHandler will initialize the example endpoint
 func NewExampleHandler(r *gin.Engine, u domain.ExampleUsecase) {
 	handler := &exampleHandler{ExampleUsecase: u}
-	r.GET("/example/fetch", handler.FetchHandler)
-	r.GET("/example/getbyid", handler.GetByIDHandler)
-	r.GET("/example/store", handler.StoreHandler)
-	r.GET("/example/update", handler.UpdateHandler)
-	r.GET("/example/delete", handler.DeleteHandler)
+	r.GET("/examples", handler.FetchHandler)
+	r.GET("/examples/:id", handler.GetByIDHandler)
+	r.POST("/examples", handler.StoreHandler)
+	r.PUT("/examples/:id", handler.UpdateHandler)
+	r.DELETE("/examples/:id", handler.DeleteHandler)
 }
 
 func (eh *exampleHandler) FetchHandler(c *gin.Context) {
@@ -116,9 +213,15 @@ func (eh *exampleHandler) FetchHandler(c *gin.Context) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "FetchHandler"
-	c.JSON(http.StatusOK, domain.ResponseData)
+	res, err := eh.ExampleUsecase.Fetch(ctx)
+	if err != nil {
+		c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+		return
+	}
+	c.JSON(http.StatusOK, &domain.ResponseSuccess{
+		Data:    res,
+		Message: "Fetch example successfully",
+	})
 	return
 }
 
@@ -127,44 +230,133 @@ func (eh *exampleHandler) GetByIDHandler(c *gin.Context) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "GetByIDHandler"
-	c.JSON(http.StatusOK, domain.ResponseData)
+	id, details := eh.bindGetByID(c)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+		return
+	}
+	res, err := eh.ExampleUsecase.GetByID(ctx, id)
+	if err != nil {
+		c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+		return
+	}
+	c.JSON(http.StatusOK, &domain.ResponseSuccess{
+		Data:    res,
+		Message: "GetByID example successfully",
+	})
 	return
 }
 
+// bindGetByID will bind the request into parameters of GetByID, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindGetByID(c *gin.Context) (id uint64, details map[string]string) {
+	idParsed, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return id, map[string]string{"id": "must be uint64"}
+	}
+	id = idParsed
+	return id, nil
+}
+
 func (eh *exampleHandler) StoreHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "StoreHandler"
-	c.JSON(http.StatusOK, domain.ResponseData)
+	exp, details := eh.bindStore(c)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+		return
+	}
+	res, err := eh.ExampleUsecase.Store(ctx, exp)
+	if err != nil {
+		c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+		return
+	}
+	c.JSON(http.StatusOK, &domain.ResponseSuccess{
+		Data:    res,
+		Message: "Store example successfully",
+	})
 	return
 }
 
+// bindStore will bind the request into parameters of Store, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindStore(c *gin.Context) (exp *domain.Example, details map[string]string) {
+	exp = new(domain.Example)
+	if err := json.NewDecoder(c.Request.Body).Decode(exp); err != nil {
+		return exp, map[string]string{"body": "must be a valid json"}
+	}
+	if valid, err := middleware.IsRequestValid(exp); !valid {
+		return exp, middleware.ValidationDetails(err)
+	}
+	return exp, nil
+}
+
 func (eh *exampleHandler) UpdateHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "UpdateHandler"
-	c.JSON(http.StatusOK, domain.ResponseData)
+	exp, details := eh.bindUpdate(c)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+		return
+	}
+	res, err := eh.ExampleUsecase.Update(ctx, exp)
+	if err != nil {
+		c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+		return
+	}
+	c.JSON(http.StatusOK, &domain.ResponseSuccess{
+		Data:    res,
+		Message: "Update example successfully",
+	})
 	return
 }
 
+// bindUpdate will bind the request into parameters of Update, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindUpdate(c *gin.Context) (exp *domain.Example, details map[string]string) {
+	exp = new(domain.Example)
+	if err := json.NewDecoder(c.Request.Body).Decode(exp); err != nil {
+		return exp, map[string]string{"body": "must be a valid json"}
+	}
+	if valid, err := middleware.IsRequestValid(exp); !valid {
+		return exp, middleware.ValidationDetails(err)
+	}
+	return exp, nil
+}
+
 func (eh *exampleHandler) DeleteHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "DeleteHandler"
-	c.JSON(http.StatusOK, domain.ResponseData)
+	id, details := eh.bindDelete(c)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+		return
+	}
+	err := eh.ExampleUsecase.Delete(ctx, id)
+	if err != nil {
+		c.JSON(domain.GetStatusCode(err), domain.NewResponseError(err))
+		return
+	}
+	c.JSON(http.StatusOK, &domain.ResponseSuccess{Message: "Delete example successfully"})
 	return
 }
+
+// bindDelete will bind the request into parameters of Delete, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindDelete(c *gin.Context) (id uint64, details map[string]string) {
+	idParsed, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return id, map[string]string{"id": "must be uint64"}
+	}
+	id = idParsed
+	return id, nil
+}
 `
 
 	expected_gorilla_mux_example_transport = `package rest
@@ -172,9 +364,11 @@ func (eh *exampleHandler) DeleteHandler(c *gin.Context) {
 import (
 	"context"
 	"github.com/example/exampletranposport/domain"
+	"github.com/example/exampletranposport/middleware"
 	"github.com/gorilla/mux"
 	json "github.com/json-iterator/go"
 	"net/http"
+	"strconv"
 )
 
 type exampleHandler struct {
@@ -184,11 +378,11 @@ type exampleHandler struct {
 // NewExampleHandler will initialize the example endpoint
 func NewExampleHandler(r *mux.Router, u domain.ExampleUsecase) {
 	handler := &exampleHandler{ExampleUsecase: u}
-	r.HandleFunc("/example/fetch", handler.FetchHandler).Methods("GET")
-	r.HandleFunc("/example/getbyid", handler.GetByIDHandler).Methods("GET")
-	r.HandleFunc("/example/store", handler.StoreHandler).Methods("GET")
-	r.HandleFunc("/example/update", handler.UpdateHandler).Methods("GET")
-	r.HandleFunc("/example/delete", handler.DeleteHandler).Methods("GET")
+	r.HandleFunc("/examples", handler.FetchHandler).Methods("GET")
+	r.HandleFunc("/examples/{id}", handler.GetByIDHandler).Methods("GET")
+	r.HandleFunc("/examples", handler.StoreHandler).Methods("POST")
+	r.HandleFunc("/examples/{id}", handler.UpdateHandler).Methods("PUT")
+	r.HandleFunc("/examples/{id}", handler.DeleteHandler).Methods("DELETE")
 }
 
 func (eh *exampleHandler) FetchHandler(w http.ResponseWriter, r *http.Request) {
@@ -196,10 +390,17 @@ func (eh *exampleHandler) FetchHandler(w http.ResponseWriter, r *http.Request) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "FetchHandler"
+	res, err := eh.ExampleUsecase.Fetch(ctx)
+	if err != nil {
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(domain.ResponseData)
+	json.NewEncoder(w).Encode(&domain.ResponseSuccess{
+		Data:    res,
+		Message: "Fetch example successfully",
+	})
 	return
 }
 
@@ -208,48 +409,145 @@ func (eh *exampleHandler) GetByIDHandler(w http.ResponseWriter, r *http.Request)
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "GetByIDHandler"
+	id, details := eh.bindGetByID(r)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
+	res, err := eh.ExampleUsecase.GetByID(ctx, id)
+	if err != nil {
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(domain.ResponseData)
+	json.NewEncoder(w).Encode(&domain.ResponseSuccess{
+		Data:    res,
+		Message: "GetByID example successfully",
+	})
 	return
 }
 
+// bindGetByID will bind the request into parameters of GetByID, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindGetByID(r *http.Request) (id uint64, details map[string]string) {
+	idParsed, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return id, map[string]string{"id": "must be uint64"}
+	}
+	id = idParsed
+	return id, nil
+}
+
 func (eh *exampleHandler) StoreHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "StoreHandler"
+	exp, details := eh.bindStore(r)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
+	res, err := eh.ExampleUsecase.Store(ctx, exp)
+	if err != nil {
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(domain.ResponseData)
+	json.NewEncoder(w).Encode(&domain.ResponseSuccess{
+		Data:    res,
+		Message: "Store example successfully",
+	})
 	return
 }
 
+// bindStore will bind the request into parameters of Store, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindStore(r *http.Request) (exp *domain.Example, details map[string]string) {
+	exp = new(domain.Example)
+	if err := json.NewDecoder(r.Body).Decode(exp); err != nil {
+		return exp, map[string]string{"body": "must be a valid json"}
+	}
+	if valid, err := middleware.IsRequestValid(exp); !valid {
+		return exp, middleware.ValidationDetails(err)
+	}
+	return exp, nil
+}
+
 func (eh *exampleHandler) UpdateHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "UpdateHandler"
+	exp, details := eh.bindUpdate(r)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
+	res, err := eh.ExampleUsecase.Update(ctx, exp)
+	if err != nil {
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(domain.ResponseData)
+	json.NewEncoder(w).Encode(&domain.ResponseSuccess{
+		Data:    res,
+		Message: "Update example successfully",
+	})
 	return
 }
 
+// bindUpdate will bind the request into parameters of Update, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindUpdate(r *http.Request) (exp *domain.Example, details map[string]string) {
+	exp = new(domain.Example)
+	if err := json.NewDecoder(r.Body).Decode(exp); err != nil {
+		return exp, map[string]string{"body": "must be a valid json"}
+	}
+	if valid, err := middleware.IsRequestValid(exp); !valid {
+		return exp, middleware.ValidationDetails(err)
+	}
+	return exp, nil
+}
+
 func (eh *exampleHandler) DeleteHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "DeleteHandler"
+	id, details := eh.bindDelete(r)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
+	err := eh.ExampleUsecase.Delete(ctx, id)
+	if err != nil {
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(domain.ResponseData)
+	json.NewEncoder(w).Encode(&domain.ResponseSuccess{Message: "Delete example successfully"})
 	return
 }
+
+// bindDelete will bind the request into parameters of Delete, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindDelete(r *http.Request) (id uint64, details map[string]string) {
+	idParsed, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return id, map[string]string{"id": "must be uint64"}
+	}
+	id = idParsed
+	return id, nil
+}
 `
 
 	expected_net_http_mux_example_transport = `package rest
@@ -257,9 +555,10 @@ func (eh *exampleHandler) DeleteHandler(w http.ResponseWriter, r *http.Request)
 import (
 	"context"
 	"github.com/example/exampletranposport/domain"
+	"github.com/example/exampletranposport/middleware"
 	json "github.com/json-iterator/go"
 	"net/http"
-	"path"
+	"strconv"
 	"strings"
 )
 
@@ -270,7 +569,8 @@ type exampleHandler struct {
 // NewExampleHandler will initialize the example endpoint
 func NewExampleHandler(r *http.ServeMux, u domain.ExampleUsecase) {
 	handler := &exampleHandler{ExampleUsecase: u}
-	r.Handle("/example", handler)
+	r.Handle("/examples", handler)
+	r.Handle("/examples/", handler)
 }
 
 func (eh *exampleHandler) FetchHandler(w http.ResponseWriter, r *http.Request) {
@@ -278,10 +578,17 @@ func (eh *exampleHandler) FetchHandler(w http.ResponseWriter, r *http.Request) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "FetchHandler"
+	res, err := eh.ExampleUsecase.Fetch(ctx)
+	if err != nil {
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(domain.ResponseData)
+	json.NewEncoder(w).Encode(&domain.ResponseSuccess{
+		Data:    res,
+		Message: "Fetch example successfully",
+	})
 	return
 }
 
@@ -290,82 +597,540 @@ func (eh *exampleHandler) GetByIDHandler(w http.ResponseWriter, r *http.Request)
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "GetByIDHandler"
+	id, details := eh.bindGetByID(r)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
+	res, err := eh.ExampleUsecase.GetByID(ctx, id)
+	if err != nil {
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(domain.ResponseData)
+	json.NewEncoder(w).Encode(&domain.ResponseSuccess{
+		Data:    res,
+		Message: "GetByID example successfully",
+	})
 	return
 }
 
+// bindGetByID will bind the request into parameters of GetByID, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindGetByID(r *http.Request) (id uint64, details map[string]string) {
+	s := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	idParsed, err := strconv.ParseUint(s[1], 10, 64)
+	if err != nil {
+		return id, map[string]string{"id": "must be uint64"}
+	}
+	id = idParsed
+	return id, nil
+}
+
 func (eh *exampleHandler) StoreHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "StoreHandler"
+	exp, details := eh.bindStore(r)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
+	res, err := eh.ExampleUsecase.Store(ctx, exp)
+	if err != nil {
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(domain.ResponseData)
+	json.NewEncoder(w).Encode(&domain.ResponseSuccess{
+		Data:    res,
+		Message: "Store example successfully",
+	})
 	return
 }
 
+// bindStore will bind the request into parameters of Store, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindStore(r *http.Request) (exp *domain.Example, details map[string]string) {
+	exp = new(domain.Example)
+	if err := json.NewDecoder(r.Body).Decode(exp); err != nil {
+		return exp, map[string]string{"body": "must be a valid json"}
+	}
+	if valid, err := middleware.IsRequestValid(exp); !valid {
+		return exp, middleware.ValidationDetails(err)
+	}
+	return exp, nil
+}
+
 func (eh *exampleHandler) UpdateHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "UpdateHandler"
+	exp, details := eh.bindUpdate(r)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
+	res, err := eh.ExampleUsecase.Update(ctx, exp)
+	if err != nil {
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(domain.ResponseData)
+	json.NewEncoder(w).Encode(&domain.ResponseSuccess{
+		Data:    res,
+		Message: "Update example successfully",
+	})
 	return
 }
 
+// bindUpdate will bind the request into parameters of Update, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindUpdate(r *http.Request) (exp *domain.Example, details map[string]string) {
+	exp = new(domain.Example)
+	if err := json.NewDecoder(r.Body).Decode(exp); err != nil {
+		return exp, map[string]string{"body": "must be a valid json"}
+	}
+	if valid, err := middleware.IsRequestValid(exp); !valid {
+		return exp, middleware.ValidationDetails(err)
+	}
+	return exp, nil
+}
+
 func (eh *exampleHandler) DeleteHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	domain.ResponseData = make(map[string]interface{})
-	domain.ResponseData["message"] = "DeleteHandler"
+	id, details := eh.bindDelete(r)
+	if details != nil {
+		err := domain.ErrBadParamInput.WithDetails(details)
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
+	err := eh.ExampleUsecase.Delete(ctx, id)
+	if err != nil {
+		w.WriteHeader(domain.GetStatusCode(err))
+		json.NewEncoder(w).Encode(domain.NewResponseError(err))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(domain.ResponseData)
+	json.NewEncoder(w).Encode(&domain.ResponseSuccess{Message: "Delete example successfully"})
 	return
 }
 
+// bindDelete will bind the request into parameters of Delete, details of every invalid parameter is returned if the request is not valid
+func (eh *exampleHandler) bindDelete(r *http.Request) (id uint64, details map[string]string) {
+	s := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	idParsed, err := strconv.ParseUint(s[1], 10, 64)
+	if err != nil {
+		return id, map[string]string{"id": "must be uint64"}
+	}
+	id = idParsed
+	return id, nil
+}
+
+// ServeHTTP will dispatch the request to handler of the matching route
 func (eh *exampleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	if ctx == nil {
-		ctx = context.Background()
+	s := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && len(s) == 1 && s[0] == "examples":
+		eh.FetchHandler(w, r)
+		return
+	case r.Method == http.MethodGet && len(s) == 2 && s[0] == "examples":
+		eh.GetByIDHandler(w, r)
+		return
+	case r.Method == http.MethodPost && len(s) == 1 && s[0] == "examples":
+		eh.StoreHandler(w, r)
+		return
+	case r.Method == http.MethodPut && len(s) == 2 && s[0] == "examples":
+		eh.UpdateHandler(w, r)
+		return
+	case r.Method == http.MethodDelete && len(s) == 2 && s[0] == "examples":
+		eh.DeleteHandler(w, r)
+		return
 	}
 
-	uri := ctx.Value("uri")
-	s := strings.Split(r.RequestURI, "/")
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	json.NewEncoder(w).Encode(&domain.ResponseError{Message: "Method Not Allowed"})
+}
+`
+	expected_echo_example_transport_test = `package rest_test
 
-	if uri == "/example" {
-		if r.Method == http.MethodGet {
-			eh.FetchHandler(w, r)
-			return
-		}
+import (
+	"encoding/json"
+	"errors"
+	"github.com/example/exampletranposport/domain"
+	"github.com/example/exampletranposport/domain/mocks"
+	"github.com/example/exampletranposport/transport/rest"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newExampleHandlerServer will register handler of example into echo router on top of the given usecase
+func newExampleHandlerServer(u domain.ExampleUsecase) http.Handler {
+	r := echo.New()
+	rest.NewExampleHandler(r, u)
+	return r
+}
+
+func TestExampleHandler_Fetch(t *testing.T) {
+	errUsecase := errors.New("unexpected error of usecase")
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		invalid    bool
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "success",
+			target:     "/examples",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error, should return status not found when usecase return domain.ErrNotFound",
+			target:     "/examples",
+			err:        domain.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error, should return status bad request when usecase return domain.ErrBadParamInput",
+			target:     "/examples",
+			err:        domain.ErrBadParamInput,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error, should return status internal server error when usecase return unexpected error",
+			target:     "/examples",
+			err:        errUsecase,
+			wantStatus: http.StatusInternalServerError,
+		},
 	}
 
-	// if the path contain params
-	if len(s) == 3 {
-		id, err := strconv.Atoi(path.Base(r.RequestURI))
-		if err != nil {
-			w.WriteHeader(http.StatusMethodNotAllowed)
-			json.NewEncoder(w).Encode(&domain.ResponseError{Message: "Method Not Allowed"})
-			return
-		}
-		if r.Method == http.MethodGet {
-			eh.FetchHandler(w, r, id)
-			return
-		}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockExampleUsecase := new(mocks.ExampleUsecase)
+			mockExampleUsecase.On("Fetch", mock.Anything).Return([]*domain.Example{}, tt.err).Once()
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			newExampleHandlerServer(mockExampleUsecase).ServeHTTP(rec, req)
+
+			mockExampleUsecase.AssertExpectations(t)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
 	}
+}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
-	json.NewEncoder(w).Encode(&domain.ResponseError{Message: "Method Not Allowed"})
-	return
+func TestExampleHandler_GetByID(t *testing.T) {
+	errUsecase := errors.New("unexpected error of usecase")
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		invalid    bool
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "success",
+			target:     "/examples/1",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error, should return status not found when usecase return domain.ErrNotFound",
+			target:     "/examples/1",
+			err:        domain.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error, should return status bad request when usecase return domain.ErrBadParamInput",
+			target:     "/examples/1",
+			err:        domain.ErrBadParamInput,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error, should return status internal server error when usecase return unexpected error",
+			target:     "/examples/1",
+			err:        errUsecase,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "error, should return status bad request when request is not valid",
+			target:     "/examples/invalid",
+			invalid:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockExampleUsecase := new(mocks.ExampleUsecase)
+			if !tt.invalid {
+				mockExampleUsecase.On("GetByID", mock.Anything, mock.Anything).Return(&domain.Example{}, tt.err).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			newExampleHandlerServer(mockExampleUsecase).ServeHTTP(rec, req)
+
+			mockExampleUsecase.AssertExpectations(t)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestExampleHandler_Store(t *testing.T) {
+	body, err := json.Marshal(&domain.Example{
+		ID:        uint64(1),
+		Name:      "test",
+		CreatedAt: *new(time.Time),
+		UpdatedAt: *new(time.Time),
+		DeletedAt: new(time.Time),
+	})
+	assert.NoError(t, err)
+
+	errUsecase := errors.New("unexpected error of usecase")
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		invalid    bool
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "success",
+			target:     "/examples",
+			body:       string(body),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error, should return status not found when usecase return domain.ErrNotFound",
+			target:     "/examples",
+			body:       string(body),
+			err:        domain.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error, should return status bad request when usecase return domain.ErrBadParamInput",
+			target:     "/examples",
+			body:       string(body),
+			err:        domain.ErrBadParamInput,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error, should return status internal server error when usecase return unexpected error",
+			target:     "/examples",
+			body:       string(body),
+			err:        errUsecase,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "error, should return status bad request when request is not valid",
+			target:     "/examples",
+			body:       "invalid",
+			invalid:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockExampleUsecase := new(mocks.ExampleUsecase)
+			if !tt.invalid {
+				mockExampleUsecase.On("Store", mock.Anything, mock.Anything).Return(&domain.Example{}, tt.err).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			newExampleHandlerServer(mockExampleUsecase).ServeHTTP(rec, req)
+
+			mockExampleUsecase.AssertExpectations(t)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestExampleHandler_Update(t *testing.T) {
+	body, err := json.Marshal(&domain.Example{
+		ID:        uint64(1),
+		Name:      "test",
+		CreatedAt: *new(time.Time),
+		UpdatedAt: *new(time.Time),
+		DeletedAt: new(time.Time),
+	})
+	assert.NoError(t, err)
+
+	errUsecase := errors.New("unexpected error of usecase")
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		invalid    bool
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "success",
+			target:     "/examples/1",
+			body:       string(body),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error, should return status not found when usecase return domain.ErrNotFound",
+			target:     "/examples/1",
+			body:       string(body),
+			err:        domain.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error, should return status bad request when usecase return domain.ErrBadParamInput",
+			target:     "/examples/1",
+			body:       string(body),
+			err:        domain.ErrBadParamInput,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error, should return status internal server error when usecase return unexpected error",
+			target:     "/examples/1",
+			body:       string(body),
+			err:        errUsecase,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "error, should return status bad request when request is not valid",
+			target:     "/examples/1",
+			body:       "invalid",
+			invalid:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockExampleUsecase := new(mocks.ExampleUsecase)
+			if !tt.invalid {
+				mockExampleUsecase.On("Update", mock.Anything, mock.Anything).Return(&domain.Example{}, tt.err).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodPut, tt.target, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			newExampleHandlerServer(mockExampleUsecase).ServeHTTP(rec, req)
+
+			mockExampleUsecase.AssertExpectations(t)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestExampleHandler_UpdatePath(t *testing.T) {
+	mockExampleUsecase := new(mocks.ExampleUsecase)
+	mockExampleUsecase.On("Update", mock.Anything, mock.MatchedBy(func(exp *domain.Example) bool {
+		return exp.ID == 2
+	})).Return(&domain.Example{}, nil).Once()
+
+	body, err := json.Marshal(&domain.Example{
+		ID:        uint64(1),
+		Name:      "test",
+		CreatedAt: *new(time.Time),
+		UpdatedAt: *new(time.Time),
+		DeletedAt: new(time.Time),
+	})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/examples/2", strings.NewReader(string(body)))
+	rec := httptest.NewRecorder()
+	newExampleHandlerServer(mockExampleUsecase).ServeHTTP(rec, req)
+
+	mockExampleUsecase.AssertExpectations(t)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestExampleHandler_Delete(t *testing.T) {
+	errUsecase := errors.New("unexpected error of usecase")
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		invalid    bool
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "success",
+			target:     "/examples/1",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error, should return status not found when usecase return domain.ErrNotFound",
+			target:     "/examples/1",
+			err:        domain.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error, should return status bad request when usecase return domain.ErrBadParamInput",
+			target:     "/examples/1",
+			err:        domain.ErrBadParamInput,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error, should return status internal server error when usecase return unexpected error",
+			target:     "/examples/1",
+			err:        errUsecase,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "error, should return status bad request when request is not valid",
+			target:     "/examples/invalid",
+			invalid:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockExampleUsecase := new(mocks.ExampleUsecase)
+			if !tt.invalid {
+				mockExampleUsecase.On("Delete", mock.Anything, mock.Anything).Return(tt.err).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, tt.target, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			newExampleHandlerServer(mockExampleUsecase).ServeHTTP(rec, req)
+
+			mockExampleUsecase.AssertExpectations(t)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
 }
 `
 	expected_graphql_example_types = `package types
@@ -374,8 +1139,26 @@ import "github.com/graphql-go/graphql"
 
 // ExampleType is the GraphQL schema for the example type.
 var ExampleType = graphql.NewObject(graphql.ObjectConfig{
-	Fields: graphql.Fields{"id": &graphql.Field{Type: graphql.ID}},
-	Name:   "Example",
+	Fields: graphql.Fields{
+		"created_at": &graphql.Field{Type: graphql.DateTime},
+		"deleted_at": &graphql.Field{Type: graphql.DateTime},
+		"id":         &graphql.Field{Type: graphql.Int},
+		"name":       &graphql.Field{Type: graphql.String},
+		"updated_at": &graphql.Field{Type: graphql.DateTime},
+	},
+	Name: "Example",
+})
+
+// ExampleInput is the GraphQL schema for the example argument.
+var ExampleInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Fields: graphql.InputObjectConfigFieldMap{
+		"created_at": &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+		"deleted_at": &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+		"id":         &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"name":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"updated_at": &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+	},
+	Name: "ExampleInput",
 })
 `
 	expected_graphql_mutations = `package mutations
@@ -383,6 +1166,7 @@ var ExampleType = graphql.NewObject(graphql.ObjectConfig{
 import (
 	"github.com/example/exampletranposport/domain"
 	"github.com/graphql-go/graphql"
+	json "github.com/json-iterator/go"
 )
 
 // GraphQLMutation represent the graphQLMutation
@@ -391,106 +1175,119 @@ type GraphQLMutation struct {
 }
 
 // NewGraphQLMutation will initialize mutations
-func NewGraphQLMutation(e domain.ExampleUsecase) *GraphQLMutation {
-	return &GraphQLMutation{ExampleUsecase: e}
+func NewGraphQLMutation(exampleUsecase domain.ExampleUsecase) *GraphQLMutation {
+	return &GraphQLMutation{ExampleUsecase: exampleUsecase}
 }
 
 // GetRootMutationFields returns all the available mutations.
 func (gm *GraphQLMutation) GetRootMutationFields() graphql.Fields {
 	return graphql.Fields{
-		"exampleDelete":  gm.DeleteExampleMutation(),
-		"exampleFetch":   gm.FetchExampleMutation(),
-		"exampleGetByID": gm.GetByIDExampleMutation(),
-		"exampleStore":   gm.StoreExampleMutation(),
-		"exampleUpdate":  gm.UpdateExampleMutation(),
+		"exampleDelete": gm.DeleteExampleMutation(),
+		"exampleStore":  gm.StoreExampleMutation(),
+		"exampleUpdate": gm.UpdateExampleMutation(),
+	}
+}
+
+// bindArgument will decode the arguments of request into v through their json names
+func bindArgument(args map[string]interface{}, v interface{}) error {
+	b, err := json.Marshal(args)
+	if err != nil {
+		return err
 	}
+	return json.Unmarshal(b, v)
 }
 `
 	expected_graphql_example_mutations = `package mutations
 
 import (
 	"context"
+	"github.com/example/exampletranposport/domain"
+	"github.com/example/exampletranposport/middleware"
 	"github.com/example/exampletranposport/transport/graphql/types"
 	"github.com/graphql-go/graphql"
 )
 
-// FetchExampleMutation /.
-func (gm *GraphQLMutation) FetchExampleMutation() *graphql.Field {
+// StoreExampleMutation will resolve Store of example
+func (gm *GraphQLMutation) StoreExampleMutation() *graphql.Field {
 	return &graphql.Field{
-		Args:        graphql.FieldConfigArgument{},
+		Args:        graphql.FieldConfigArgument{"exp": &graphql.ArgumentConfig{Type: types.ExampleInput}},
 		Description: "Example",
 		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
 			ctx := params.Context
 			if ctx == nil {
 				ctx = context.Background()
 			}
-			return nil, nil
-		},
-		Type: types.ExampleType,
-	}
-}
-
-// GetByIDExampleMutation /.
-func (gm *GraphQLMutation) GetByIDExampleMutation() *graphql.Field {
-	return &graphql.Field{
-		Args:        graphql.FieldConfigArgument{},
-		Description: "Example",
-		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
-			ctx := params.Context
-			if ctx == nil {
-				ctx = context.Background()
+			var args struct {
+				Exp *domain.Example ` + "`" + `json:"exp"` + "`" + `
 			}
-			return nil, nil
-		},
-		Type: types.ExampleType,
-	}
-}
-
-// StoreExampleMutation /.
-func (gm *GraphQLMutation) StoreExampleMutation() *graphql.Field {
-	return &graphql.Field{
-		Args:        graphql.FieldConfigArgument{},
-		Description: "Example",
-		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
-			ctx := params.Context
-			if ctx == nil {
-				ctx = context.Background()
+			if err := bindArgument(params.Args, &args); err != nil {
+				return nil, domain.GetGraphqlError(domain.ErrBadParamInput.Wrap(err))
+			}
+			if valid, err := middleware.IsRequestValid(args.Exp); !valid {
+				return nil, domain.GetGraphqlError(domain.ErrBadParamInput.WithDetails(middleware.ValidationDetails(err)))
 			}
-			return nil, nil
+			res, err := gm.ExampleUsecase.Store(ctx, args.Exp)
+			if err != nil {
+				return nil, domain.GetGraphqlError(err)
+			}
+			return res, nil
 		},
 		Type: types.ExampleType,
 	}
 }
 
-// UpdateExampleMutation /.
+// UpdateExampleMutation will resolve Update of example
 func (gm *GraphQLMutation) UpdateExampleMutation() *graphql.Field {
 	return &graphql.Field{
-		Args:        graphql.FieldConfigArgument{},
+		Args:        graphql.FieldConfigArgument{"exp": &graphql.ArgumentConfig{Type: types.ExampleInput}},
 		Description: "Example",
 		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
 			ctx := params.Context
 			if ctx == nil {
 				ctx = context.Background()
 			}
-			return nil, nil
+			var args struct {
+				Exp *domain.Example ` + "`" + `json:"exp"` + "`" + `
+			}
+			if err := bindArgument(params.Args, &args); err != nil {
+				return nil, domain.GetGraphqlError(domain.ErrBadParamInput.Wrap(err))
+			}
+			if valid, err := middleware.IsRequestValid(args.Exp); !valid {
+				return nil, domain.GetGraphqlError(domain.ErrBadParamInput.WithDetails(middleware.ValidationDetails(err)))
+			}
+			res, err := gm.ExampleUsecase.Update(ctx, args.Exp)
+			if err != nil {
+				return nil, domain.GetGraphqlError(err)
+			}
+			return res, nil
 		},
 		Type: types.ExampleType,
 	}
 }
 
-// DeleteExampleMutation /.
+// DeleteExampleMutation will resolve Delete of example
 func (gm *GraphQLMutation) DeleteExampleMutation() *graphql.Field {
 	return &graphql.Field{
-		Args:        graphql.FieldConfigArgument{},
+		Args:        graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.Int}},
 		Description: "Example",
 		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
 			ctx := params.Context
 			if ctx == nil {
 				ctx = context.Background()
 			}
-			return nil, nil
+			var args struct {
+				Id uint64 ` + "`" + `json:"id"` + "`" + `
+			}
+			if err := bindArgument(params.Args, &args); err != nil {
+				return nil, domain.GetGraphqlError(domain.ErrBadParamInput.Wrap(err))
+			}
+			err := gm.ExampleUsecase.Delete(ctx, args.Id)
+			if err != nil {
+				return nil, domain.GetGraphqlError(err)
+			}
+			return true, nil
 		},
-		Type: types.ExampleType,
+		Type: graphql.Boolean,
 	}
 }
 `
@@ -499,6 +1296,7 @@ func (gm *GraphQLMutation) DeleteExampleMutation() *graphql.Field {
 import (
 	"github.com/example/exampletranposport/domain"
 	"github.com/graphql-go/graphql"
+	json "github.com/json-iterator/go"
 )
 
 // GraphQLQuery represent the GraphQLQuery
@@ -507,104 +1305,77 @@ type GraphQLQuery struct {
 }
 
 // NewGraphQLQuery will initialize queries
-func NewGraphQLQuery(e domain.ExampleUsecase) *GraphQLQuery {
-	return &GraphQLQuery{ExampleUsecase: e}
+func NewGraphQLQuery(exampleUsecase domain.ExampleUsecase) *GraphQLQuery {
+	return &GraphQLQuery{ExampleUsecase: exampleUsecase}
 }
 
 // GetRootQueryFields returns all the available queries.
 func (gq *GraphQLQuery) GetRootQueryFields() graphql.Fields {
 	return graphql.Fields{
-		"exampleDelete":  gq.DeleteExampleQuery(),
 		"exampleFetch":   gq.FetchExampleQuery(),
 		"exampleGetByID": gq.GetByIDExampleQuery(),
-		"exampleStore":   gq.StoreExampleQuery(),
-		"exampleUpdate":  gq.UpdateExampleQuery(),
 	}
 }
+
+// bindArgument will decode the arguments of request into v through their json names
+func bindArgument(args map[string]interface{}, v interface{}) error {
+	b, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
 `
 	expected_graphql_example_queries = `package queries
 
 import (
 	"context"
+	"github.com/example/exampletranposport/domain"
 	"github.com/example/exampletranposport/transport/graphql/types"
 	"github.com/graphql-go/graphql"
 )
 
-// FetchExampleQuery /.
+// FetchExampleQuery will resolve Fetch of example
 func (gq *GraphQLQuery) FetchExampleQuery() *graphql.Field {
 	return &graphql.Field{
-		Args:        graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.ID}},
-		Description: "Example",
-		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
-			ctx := params.Context
-			if ctx == nil {
-				ctx = context.Background()
-			}
-			return nil, nil
-		},
-		Type: types.ExampleType,
-	}
-}
-
-// GetByIDExampleQuery /.
-func (gq *GraphQLQuery) GetByIDExampleQuery() *graphql.Field {
-	return &graphql.Field{
-		Args:        graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.ID}},
-		Description: "Example",
-		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
-			ctx := params.Context
-			if ctx == nil {
-				ctx = context.Background()
-			}
-			return nil, nil
-		},
-		Type: types.ExampleType,
-	}
-}
-
-// StoreExampleQuery /.
-func (gq *GraphQLQuery) StoreExampleQuery() *graphql.Field {
-	return &graphql.Field{
-		Args:        graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.ID}},
+		Args:        graphql.FieldConfigArgument{},
 		Description: "Example",
 		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
 			ctx := params.Context
 			if ctx == nil {
 				ctx = context.Background()
 			}
-			return nil, nil
-		},
-		Type: types.ExampleType,
-	}
-}
-
-// UpdateExampleQuery /.
-func (gq *GraphQLQuery) UpdateExampleQuery() *graphql.Field {
-	return &graphql.Field{
-		Args:        graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.ID}},
-		Description: "Example",
-		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
-			ctx := params.Context
-			if ctx == nil {
-				ctx = context.Background()
+			res, err := gq.ExampleUsecase.Fetch(ctx)
+			if err != nil {
+				return nil, domain.GetGraphqlError(err)
 			}
-			return nil, nil
+			return res, nil
 		},
-		Type: types.ExampleType,
+		Type: graphql.NewList(types.ExampleType),
 	}
 }
 
-// DeleteExampleQuery /.
-func (gq *GraphQLQuery) DeleteExampleQuery() *graphql.Field {
+// GetByIDExampleQuery will resolve GetByID of example
+func (gq *GraphQLQuery) GetByIDExampleQuery() *graphql.Field {
 	return &graphql.Field{
-		Args:        graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.ID}},
+		Args:        graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.Int}},
 		Description: "Example",
 		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
 			ctx := params.Context
 			if ctx == nil {
 				ctx = context.Background()
 			}
-			return nil, nil
+			var args struct {
+				Id uint64 ` + "`" + `json:"id"` + "`" + `
+			}
+			if err := bindArgument(params.Args, &args); err != nil {
+				return nil, domain.GetGraphqlError(domain.ErrBadParamInput.Wrap(err))
+			}
+			res, err := gq.ExampleUsecase.GetByID(ctx, args.Id)
+			if err != nil {
+				return nil, domain.GetGraphqlError(err)
+			}
+			return res, nil
 		},
 		Type: types.ExampleType,
 	}
@@ -629,8 +1400,8 @@ type graphQLHandler struct {
 }
 
 // NewGraphQLHandler will initialize the graphql endpoint
-func NewGraphQLHandler(r *http.ServeMux, e domain.ExampleUsecase) {
-	handle := &graphQLHandler{ExampleUsecase: e}
+func NewGraphQLHandler(r *http.ServeMux, exampleUsecase domain.ExampleUsecase) {
+	handle := &graphQLHandler{ExampleUsecase: exampleUsecase}
 
 	h := handler.New(&handler.Config{
 		GraphiQL: false,
@@ -642,7 +1413,6 @@ func NewGraphQLHandler(r *http.ServeMux, e domain.ExampleUsecase) {
 }
 
 func (gh *graphQLHandler) schema() *graphql.Schema {
-	rootMutation := mutations.NewGraphQLMutation(gh.ExampleUsecase)
 	rootQuery := queries.NewGraphQLQuery(gh.ExampleUsecase)
 
 	queryType := graphql.NewObject(graphql.ObjectConfig{
@@ -650,6 +1420,8 @@ func (gh *graphQLHandler) schema() *graphql.Schema {
 		Name:   "Query",
 	})
 
+	rootMutation := mutations.NewGraphQLMutation(gh.ExampleUsecase)
+
 	mutationType := graphql.NewObject(graphql.ObjectConfig{
 		Fields: rootMutation.GetRootMutationFields(),
 		Name:   "Mutation",
@@ -679,8 +1451,11 @@ func httpHeaderMiddleware(next *handler.Handler) http.Handler {
 import (
 	"context"
 	"github.com/example/exampletranposport/domain"
+	"github.com/example/exampletranposport/middleware"
 	pb "github.com/example/exampletranposport/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"google.golang.org/grpc"
+	"time"
 )
 
 // GrpcExampleHandler represent the grpc handler for example
@@ -700,7 +1475,11 @@ func (gh *GrpcExampleHandler) FetchExample(ctx context.Context, req *pb.FetchExa
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return &pb.FetchExampleResp{}, nil
+	res, err := gh.ExampleUsecase.Fetch(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.FetchExampleResp{Data: toProtoExampleList(res)}, nil
 }
 
 // GetByIDExample will handle GetByIDExample request
@@ -708,7 +1487,12 @@ func (gh *GrpcExampleHandler) GetByIDExample(ctx context.Context, req *pb.GetByI
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return &pb.GetByIDExampleResp{}, nil
+	id := req.GetId()
+	res, err := gh.ExampleUsecase.GetByID(ctx, id)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.GetByIDExampleResp{Data: toProtoExample(res)}, nil
 }
 
 // StoreExample will handle StoreExample request
@@ -716,7 +1500,15 @@ func (gh *GrpcExampleHandler) StoreExample(ctx context.Context, req *pb.StoreExa
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return &pb.StoreExampleResp{}, nil
+	exp := fromProtoExample(req.GetExp())
+	if valid, err := middleware.IsRequestValid(exp); !valid {
+		return nil, grpcError(domain.ErrBadParamInput.WithDetails(middleware.ValidationDetails(err)))
+	}
+	res, err := gh.ExampleUsecase.Store(ctx, exp)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.StoreExampleResp{Data: toProtoExample(res)}, nil
 }
 
 // UpdateExample will handle UpdateExample request
@@ -724,7 +1516,15 @@ func (gh *GrpcExampleHandler) UpdateExample(ctx context.Context, req *pb.UpdateE
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return &pb.UpdateExampleResp{}, nil
+	exp := fromProtoExample(req.GetExp())
+	if valid, err := middleware.IsRequestValid(exp); !valid {
+		return nil, grpcError(domain.ErrBadParamInput.WithDetails(middleware.ValidationDetails(err)))
+	}
+	res, err := gh.ExampleUsecase.Update(ctx, exp)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.UpdateExampleResp{Data: toProtoExample(res)}, nil
 }
 
 // DeleteExample will handle DeleteExample request
@@ -732,8 +1532,80 @@ func (gh *GrpcExampleHandler) DeleteExample(ctx context.Context, req *pb.DeleteE
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	id := req.GetId()
+	err := gh.ExampleUsecase.Delete(ctx, id)
+	if err != nil {
+		return nil, grpcError(err)
+	}
 	return &pb.DeleteExampleResp{}, nil
 }
+
+// toProtoExample will convert Example of domain into its message
+func toProtoExample(e *domain.Example) *pb.Example {
+	if e == nil {
+		return nil
+	}
+	return &pb.Example{
+		CreatedAt: toProtoTime(e.CreatedAt),
+		DeletedAt: toProtoTimePtr(e.DeletedAt),
+		Id:        e.ID,
+		Name:      e.Name,
+		UpdatedAt: toProtoTime(e.UpdatedAt),
+	}
+}
+
+// fromProtoExample will convert message of Example into Example of domain, getters of message are safe for nil message
+func fromProtoExample(m *pb.Example) *domain.Example {
+	return &domain.Example{
+		CreatedAt: fromProtoTime(m.GetCreatedAt()),
+		DeletedAt: fromProtoTimePtr(m.GetDeletedAt()),
+		ID:        m.GetId(),
+		Name:      m.GetName(),
+		UpdatedAt: fromProtoTime(m.GetUpdatedAt()),
+	}
+}
+
+// toProtoExampleList will convert every element of the list
+func toProtoExampleList(l []*domain.Example) []*pb.Example {
+	res := make([]*pb.Example, 0, len(l))
+	for _, v := range l {
+		res = append(res, toProtoExample(v))
+	}
+	return res
+}
+
+// toProtoTime will convert time into timestamp of protobuf
+func toProtoTime(t time.Time) *timestamp.Timestamp {
+	return &timestamp.Timestamp{
+		Nanos:   int32(t.Nanosecond()),
+		Seconds: t.Unix(),
+	}
+}
+
+// toProtoTimePtr will convert time into timestamp of protobuf, nil time is left as nil
+func toProtoTimePtr(t *time.Time) *timestamp.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return toProtoTime(*t)
+}
+
+// fromProtoTime will convert timestamp of protobuf into time, nil timestamp become zero time
+func fromProtoTime(ts *timestamp.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return time.Unix(ts.GetSeconds(), int64(ts.GetNanos())).UTC()
+}
+
+// fromProtoTimePtr will convert timestamp of protobuf into time, nil timestamp is left as nil
+func fromProtoTimePtr(ts *timestamp.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := fromProtoTime(ts)
+	return &t
+}
 `
 )
 
@@ -746,6 +1618,7 @@ func TestGenerateEchoTransport(t *testing.T) {
 		gomodName   = "github.com/example/exampletranposport"
 		dirName     = fmt.Sprintf("%s/%s/%s", serviceName, dirLayer1, dirLayer2)
 		parser      = &domain.Parser{
+			Entity: domain.MockParser.Entity,
 			Usecase: domain.Usecase{
 				Name: "ExampleUsecase",
 				Method: []domain.Method{
@@ -829,7 +1702,7 @@ func TestGenerateEchoTransport(t *testing.T) {
 		}
 
 		// generate example_handler.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenEchoTransport(dirName, domainFile, gomodName, parser)
 		resGopg, err := newFs.FindFile(dirName + "/example_handler.go")
 		assert.NoError(t, err)
@@ -848,6 +1721,53 @@ func TestGenerateEchoTransport(t *testing.T) {
 		}
 		assert.Equal(t, expected_echo_example_transport, string(data))
 
+		data, err = ioutil.ReadFile(dirName + "/example_handler_test.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected_echo_example_transport_test, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, should answer error as problem details if it is configured", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer1)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer1 + "/" + dirLayer2)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		// generate example_handler.go file
+		gen := generator.NewGeneratorService(newFs)
+		gen.Configure(domain.Generator{ProblemJSON: true})
+		err = gen.GenEchoTransport(dirName, domainFile, gomodName, parser)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_handler.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "domain.WriteProblem(c.Response(), c.Request(), err)")
+		assert.NotContains(t, string(data), "domain.NewResponseError(err)")
+
+		data, err = ioutil.ReadFile(dirName + "/example_handler_test.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `domain.ProblemContentType, rec.Header().Get("Content-Type")`)
+
 		// remove directory of service
 		err = newFs.RemoveDir(serviceName)
 		if err != nil {
@@ -858,7 +1778,7 @@ func TestGenerateEchoTransport(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_handler file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenEchoTransport(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
@@ -957,7 +1877,7 @@ func TestGenerateGinTransport(t *testing.T) {
 		}
 
 		// generate example_handler.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGinTransport(dirName, domainFile, gomodName, parser)
 		resGopg, err := newFs.FindFile(dirName + "/example_handler.go")
 		assert.NoError(t, err)
@@ -976,6 +1896,12 @@ func TestGenerateGinTransport(t *testing.T) {
 		}
 		assert.Equal(t, expected_gin_example_transport, string(data))
 
+		// handler is tested through router of gin on top of mocked usecase
+		data, err = ioutil.ReadFile(dirName + "/example_handler_test.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "r := gin.New()")
+		assert.Contains(t, string(data), "mockExampleUsecase := new(mocks.ExampleUsecase)")
+
 		// remove directory of service
 		err = newFs.RemoveDir(serviceName)
 		if err != nil {
@@ -986,7 +1912,7 @@ func TestGenerateGinTransport(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_handler file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGinTransport(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
@@ -1085,7 +2011,7 @@ func TestGenerateGorillaMuxTransport(t *testing.T) {
 		}
 
 		// generate example_handler.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGorillaMuxTransport(dirName, domainFile, gomodName, parser)
 		resGopg, err := newFs.FindFile(dirName + "/example_handler.go")
 		assert.NoError(t, err)
@@ -1104,6 +2030,81 @@ func TestGenerateGorillaMuxTransport(t *testing.T) {
 		}
 		assert.Equal(t, expected_gorilla_mux_example_transport, string(data))
 
+		// handler is tested through router of gorilla mux on top of mocked usecase
+		data, err = ioutil.ReadFile(dirName + "/example_handler_test.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "r := mux.NewRouter()")
+		assert.Contains(t, string(data), "mockExampleUsecase := new(mocks.ExampleUsecase)")
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, should serve route annotated on method and post the unknown method", func(t *testing.T) {
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer1)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer1 + "/" + dirLayer2)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		routeParser := &domain.Parser{
+			Usecase: domain.Usecase{
+				Name: "ExampleUsecase",
+				Method: append(append([]domain.Method{}, parser.Usecase.Method...),
+					domain.Method{
+						Name: "Done",
+						Doc:  "Done will mark the example as done\n@route PUT /examples/:id/done",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "ctx", Type: "context.Context"},
+							domain.MethodValue{Name: "id", Type: "uint64"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "error"},
+						},
+					},
+					domain.Method{
+						Name: "Count",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "ctx", Type: "context.Context"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "int64"},
+							domain.MethodValue{Type: "error"},
+						},
+					},
+				),
+			},
+		}
+
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenGorillaMuxTransport(dirName, domainFile, gomodName, routeParser)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_handler.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `r.HandleFunc("/examples/{id}/done", handler.DoneHandler).Methods("PUT")`)
+		assert.Contains(t, string(data), `r.HandleFunc("/examples/count", handler.CountHandler).Methods("POST")`)
+
+		data, err = ioutil.ReadFile(dirName + "/example_handler_test.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `target:     "/examples/1/done",`)
+
 		// remove directory of service
 		err = newFs.RemoveDir(serviceName)
 		if err != nil {
@@ -1114,7 +2115,7 @@ func TestGenerateGorillaMuxTransport(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_handler file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGorillaMuxTransport(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
@@ -1213,7 +2214,7 @@ func TestGenerateNetHTTPTransport(t *testing.T) {
 		}
 
 		// generate example_handler.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenNetHTTPTransport(dirName, domainFile, gomodName, parser)
 		resGopg, err := newFs.FindFile(dirName + "/example_handler.go")
 		assert.NoError(t, err)
@@ -1232,6 +2233,119 @@ func TestGenerateNetHTTPTransport(t *testing.T) {
 		}
 		assert.Equal(t, expected_net_http_mux_example_transport, string(data))
 
+		// handler is tested through ServeMux on top of mocked usecase
+		data, err = ioutil.ReadFile(dirName + "/example_handler_test.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "r := http.NewServeMux()")
+		assert.Contains(t, string(data), "mockExampleUsecase := new(mocks.ExampleUsecase)")
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, should bind parameters from path, query and body", func(t *testing.T) {
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer1)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer1 + "/" + dirLayer2)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		bindParser := &domain.Parser{
+			Entity: domain.MockParser.Entity,
+			Usecase: domain.Usecase{
+				Name: "ExampleUsecase",
+				Method: []domain.Method{
+					domain.Method{
+						Name: "Search",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "ctx", Type: "context.Context"},
+							domain.MethodValue{Name: "name", Type: "string"},
+							domain.MethodValue{Name: "limit", Type: "int32"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "[]*domain.Example"},
+							domain.MethodValue{Type: "error"},
+						},
+					},
+					domain.Method{
+						Name: "Rename",
+						Doc:  "@route PUT /examples/:id/rename",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "ctx", Type: "context.Context"},
+							domain.MethodValue{Name: "exp", Type: "domain.Example"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "error"},
+						},
+					},
+					domain.Method{
+						Name: "Assign",
+						Doc:  "@route PUT /examples/:id/assign",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "ctx", Type: "context.Context"},
+							domain.MethodValue{Name: "id", Type: "uint64"},
+							domain.MethodValue{Name: "exp", Type: "*domain.Example"},
+							domain.MethodValue{Name: "tags", Type: "[]string"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "error"},
+						},
+					},
+				},
+			},
+		}
+
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenNetHTTPTransport(dirName, domainFile, gomodName, bindParser)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_handler.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `func (eh *exampleHandler) bindSearch(r *http.Request) (name string, limit int32, details map[string]string) {`)
+		assert.Contains(t, string(data), `name = r.URL.Query().Get("name")`)
+		assert.Contains(t, string(data), `if limitValue := r.URL.Query().Get("limit"); limitValue != "" {`)
+		assert.Contains(t, string(data), `limit = int32(limitParsed)`)
+		assert.Contains(t, string(data), `idParsed, err := strconv.ParseUint(s[1], 10, 64)`)
+		assert.Contains(t, string(data), `exp, tags = request.Exp, request.Tags`)
+		assert.Contains(t, string(data), `func (eh *exampleHandler) bindRename(r *http.Request) (exp domain.Example, details map[string]string) {
+	s := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if err := json.NewDecoder(r.Body).Decode(&exp); err != nil {
+		return exp, map[string]string{"body": "must be a valid json"}
+	}
+	idParsed, err := strconv.ParseUint(s[1], 10, 64)
+	if err != nil {
+		return exp, map[string]string{"id": "must be uint64"}
+	}
+	exp.ID = idParsed
+`)
+		assert.Contains(t, string(data), `if valid, err := middleware.IsRequestValid(exp); !valid {`)
+
+		// request which is not valid is checked against the first parameter that is able to be invalid
+		data, err = ioutil.ReadFile(dirName + "/example_handler_test.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `target:     "/examples/search?limit=invalid",`)
+		assert.Contains(t, string(data), `body, err := json.Marshal(map[string]interface{}{`)
+		assert.Contains(t, string(data), `mockExampleUsecase.On("Rename", mock.Anything, mock.MatchedBy(func(exp domain.Example) bool {
+		return exp.ID == 2
+	})).Return(nil).Once()`)
+		assert.Contains(t, string(data), `req := httptest.NewRequest(http.MethodPut, "/examples/2/rename", strings.NewReader(string(body)))`)
+
 		// remove directory of service
 		err = newFs.RemoveDir(serviceName)
 		if err != nil {
@@ -1242,7 +2356,7 @@ func TestGenerateNetHTTPTransport(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_handler file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenNetHTTPTransport(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
@@ -1258,6 +2372,7 @@ func TestGenerateGraphqlTransport(t *testing.T) {
 		gomodName   = "github.com/example/exampletranposport"
 		dirName     = fmt.Sprintf("%s/%s/%s", serviceName, dirLayer1, dirLayer2)
 		parser      = &domain.Parser{
+			Entity: domain.MockParser.Entity,
 			Usecase: domain.Usecase{
 				Name: "ExampleUsecase",
 				Method: []domain.Method{
@@ -1341,7 +2456,7 @@ func TestGenerateGraphqlTransport(t *testing.T) {
 		}
 
 		// generate graphql transport
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGraphqlTransport(dirName, domainFile, gomodName, parser)
 
 		// types directory
@@ -1462,9 +2577,99 @@ func TestGenerateGraphqlTransport(t *testing.T) {
 		}
 	})
 
+	t.Run("success, should serve every domain which has graphql types by the same schema", func(t *testing.T) {
+		// create directory of service
+		for _, dir := range []string{serviceName, serviceName + "/domain", serviceName + "/" + dirLayer1, dirName} {
+			err := newFs.CreateDir(dir)
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+		}
+
+		// example is parsed from its domain file once order is generated
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenDomain(serviceName+"/domain", "example")
+		assert.NoError(t, err)
+		err = gen.GenGraphqlTransport(dirName, domainFile, gomodName, parser)
+		assert.NoError(t, err)
+
+		order := &domain.Parser{
+			Usecase: domain.Usecase{
+				Name: "OrderUsecase",
+				Method: []domain.Method{
+					domain.Method{
+						Name:          "Fetch",
+						ParameterList: []domain.MethodValue{domain.MethodValue{Name: "ctx", Type: "context.Context"}},
+						ResultList:    []domain.MethodValue{domain.MethodValue{Type: "error"}},
+					},
+				},
+			},
+		}
+		err = gen.GenGraphqlTransport(dirName, "order.go", gomodName, order)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/queries/queries.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "func NewGraphQLQuery(exampleUsecase domain.ExampleUsecase, orderUsecase domain.OrderUsecase) *GraphQLQuery")
+		assert.Contains(t, string(data), `"exampleFetch":   gq.FetchExampleQuery(),`)
+		assert.Contains(t, string(data), `"orderFetch":     gq.FetchOrderQuery(),`)
+
+		data, err = ioutil.ReadFile(dirName + "/index.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "func NewGraphQLHandler(r *http.ServeMux, exampleUsecase domain.ExampleUsecase, orderUsecase domain.OrderUsecase)")
+		assert.Contains(t, string(data), "queries.NewGraphQLQuery(gh.ExampleUsecase, gh.OrderUsecase)")
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, should not import types package into itself for method which returns several values", func(t *testing.T) {
+		for _, dir := range []string{serviceName, serviceName + "/" + dirLayer1, dirName} {
+			err := newFs.CreateDir(dir)
+			assert.NoError(t, err)
+		}
+		defer newFs.RemoveDir(serviceName)
+
+		search := *parser
+		search.Usecase.Method = []domain.Method{
+			domain.Method{
+				Name: "Search",
+				ParameterList: []domain.MethodValue{
+					domain.MethodValue{Name: "ctx", Type: "context.Context"},
+					domain.MethodValue{Name: "q", Type: "string"},
+					domain.MethodValue{Name: "page", Type: "int"},
+				},
+				ResultList: []domain.MethodValue{
+					domain.MethodValue{Type: "[]*domain.Example"},
+					domain.MethodValue{Type: "int"},
+					domain.MethodValue{Type: "error"},
+				},
+			},
+		}
+
+		gen := generator.NewGeneratorService(newFs)
+		err := gen.GenGraphqlTransport(dirName, domainFile, gomodName, &search)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/types/example.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "SearchExampleResultType")
+		assert.NotContains(t, string(data), `"`+gomodName+`/transport/graphql/types"`)
+		assert.NotContains(t, string(data), "types.")
+
+		data, err = ioutil.ReadFile(dirName + "/queries/example.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "types.SearchExampleResultType")
+	})
+
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_handler file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGraphqlTransport(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
@@ -1480,6 +2685,7 @@ func TestGenerateGrpcTransport(t *testing.T) {
 		gomodName   = "github.com/example/exampletranposport"
 		dirName     = fmt.Sprintf("%s/%s/%s", serviceName, dirLayer1, dirLayer2)
 		parser      = &domain.Parser{
+			Entity: domain.MockParser.Entity,
 			Usecase: domain.Usecase{
 				Name: "ExampleUsecase",
 				Method: []domain.Method{
@@ -1563,7 +2769,7 @@ func TestGenerateGrpcTransport(t *testing.T) {
 		}
 
 		// generate example_handler.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenGrpcTransport(dirName, domainFile, gomodName, parser)
 		resGopg, err := newFs.FindFile(dirName + "/example_handler.go")
 		assert.NoError(t, err)
@@ -1592,7 +2798,7 @@ func TestGenerateGrpcTransport(t *testing.T) {
 
 	t.Run("failed, because directory not found", func(t *testing.T) {
 		// generate example_handler file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenGrpcTransport(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
@@ -0,0 +1,334 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/wicaker/cacli/domain"
+)
+
+const (
+	httptestPath = "net/http/httptest"
+	mockPath     = "github.com/stretchr/testify/mock"
+)
+
+// genRestTransportTest will generate <domain>_handler_test.go which serve the handler through httptest,
+// usecase is replaced by its mock so every error of usecase is able to be checked against the status code
+func (gen *caGen) genRestTransportTest(dirName string, domainName string, gomodName string, restServer string, parser *domain.Parser) error {
+	var (
+		imports     = map[string]string{"domain": gomodName + "/domain"}
+		useCase     = parser.Usecase.Name
+		mockUsecase = "mock" + useCase
+		handlerName = strings.ToUpper(string(domainName[0])) + domainName[1:] + "Handler"
+		newServer   = "new" + handlerName + "Server"
+		f           = jen.NewFile("rest_test")
+	)
+
+	f.ImportName(gomodName+"/domain", "domain")
+	f.ImportName(gomodName+"/domain/mocks", "mocks")
+	f.ImportName(gomodName+"/transport/rest", "rest")
+	f.ImportName(assertPath, "assert")
+	f.ImportName(mockPath, "mock")
+	f.ImportName("github.com/labstack/echo", "echo")
+	f.ImportName("github.com/gin-gonic/gin", "gin")
+	f.ImportName("github.com/gorilla/mux", "mux")
+
+	server, err := genRestTestServer(restServer)
+	if err != nil {
+		return err
+	}
+	server = append(server, jen.Qual(gomodName+"/transport/rest", "New"+handlerName).Call(jen.Id("r"), jen.Id("u")), jen.Return(jen.Id("r")))
+
+	f.Comment(fmt.Sprintf("%s will register handler of %s into %s router on top of the given usecase", newServer, domainName, restServer))
+	f.Func().Id(newServer).Params(jen.Id("u").Qual(gomodName+"/domain", useCase)).Qual("net/http", "Handler").Block(server...)
+
+	for _, i := range parser.Usecase.Method {
+		var (
+			route      = newRestRoute(domainName, i)
+			params     = newRestParams(route, i)
+			hasErr     = len(i.ResultList) > 0 && i.ResultList[len(i.ResultList)-1].Type == "error"
+			target     = field("target", jen.Lit(route.target("1")))
+			request    = []jen.Code{target}
+			invalid    []jen.Code
+			bodies     []restParam
+			anything   []jen.Code
+			mockReturn []jen.Code
+			body       []jen.Code
+		)
+
+		for range i.ParameterList {
+			anything = append(anything, jen.Qual(mockPath, "Anything"))
+		}
+		for idx, r := range i.ResultList {
+			if hasErr && idx == len(i.ResultList)-1 {
+				mockReturn = append(mockReturn, jen.Id("tt").Dot("err"))
+				continue
+			}
+			val, err := genTestValue(r.Type, imports)
+			if err != nil {
+				return err
+			}
+			mockReturn = append(mockReturn, val)
+		}
+
+		// request which is not valid never reach the usecase
+		for _, p := range params {
+			switch {
+			case p.source == fromBody:
+				bodies = append(bodies, p)
+			case invalid != nil || p.typ == "string":
+			case p.source == fromPath:
+				invalid = []jen.Code{field("target", jen.Lit(route.target("invalid")))}
+			case p.source == fromQuery:
+				invalid = []jen.Code{field("target", jen.Lit(route.target("1")+"?"+p.name+"=invalid"))}
+			}
+		}
+		if len(bodies) > 0 {
+			value, err := genRestTestBody(bodies, parser.Entity, imports)
+			if err != nil {
+				return err
+			}
+			body = append(body,
+				jen.List(jen.Id("body"), jen.Err()).Op(":=").Qual("encoding/json", "Marshal").Call(value),
+				jen.Qual(assertPath, "NoError").Call(jen.Id("t"), jen.Err()),
+				jen.Line(),
+			)
+			request = append(request, field("body", jen.String().Call(jen.Id("body"))))
+			invalid = []jen.Code{target, field("body", jen.Lit("invalid"))}
+		}
+
+		// without error result, only the success case is able to be tested
+		cases := []jen.Code{
+			restTestCase(append(append([]jen.Code{field("name", jen.Lit("success"))}, request...), field("wantStatus", jen.Qual("net/http", "StatusOK")))...),
+		}
+		if hasErr {
+			cases = append(cases,
+				restTestCase(append(append([]jen.Code{field("name", jen.Lit("error, should return status not found when usecase return domain.ErrNotFound"))}, request...),
+					field("err", jen.Qual(gomodName+"/domain", "ErrNotFound")),
+					field("wantStatus", jen.Qual("net/http", "StatusNotFound")),
+				)...),
+				restTestCase(append(append([]jen.Code{field("name", jen.Lit("error, should return status bad request when usecase return domain.ErrBadParamInput"))}, request...),
+					field("err", jen.Qual(gomodName+"/domain", "ErrBadParamInput")),
+					field("wantStatus", jen.Qual("net/http", "StatusBadRequest")),
+				)...),
+				restTestCase(append(append([]jen.Code{field("name", jen.Lit("error, should return status internal server error when usecase return unexpected error"))}, request...),
+					field("err", jen.Id("errUsecase")),
+					field("wantStatus", jen.Qual("net/http", "StatusInternalServerError")),
+				)...),
+			)
+		}
+		if invalid != nil {
+			cases = append(cases, restTestCase(append(append([]jen.Code{field("name", jen.Lit("error, should return status bad request when request is not valid"))}, invalid...),
+				field("invalid", jen.True()),
+				field("wantStatus", jen.Qual("net/http", "StatusBadRequest")),
+			)...))
+		}
+
+		var expect jen.Code = jen.Id(mockUsecase).Dot("On").Call(append([]jen.Code{jen.Lit(i.Name)}, anything...)...).Dot("Return").Call(mockReturn...).Dot("Once").Call()
+		if invalid != nil {
+			expect = jen.If(jen.Op("!").Id("tt").Dot("invalid")).Block(expect)
+		}
+
+		if hasErr {
+			body = append(body, jen.Id("errUsecase").Op(":=").Qual("errors", "New").Call(jen.Lit("unexpected error of usecase")), jen.Line())
+		}
+		assertions := []jen.Code{
+			jen.Id(mockUsecase).Dot("AssertExpectations").Call(jen.Id("t")),
+			jen.Qual(assertPath, "Equal").Call(jen.Id("t"), jen.Id("tt").Dot("wantStatus"), jen.Id("rec").Dot("Code")),
+		}
+		if gen.gen.ProblemJSON {
+			assertions = append(assertions, jen.If(jen.Id("tt").Dot("wantStatus").Op("!=").Qual("net/http", "StatusOK")).Block(
+				jen.Qual(assertPath, "Equal").Call(jen.Id("t"), jen.Qual(gomodName+"/domain", "ProblemContentType"), jen.Id("rec").Dot("Header").Call().Dot("Get").Call(jen.Lit("Content-Type"))),
+			))
+		}
+
+		body = append(body,
+			jen.Id("tests").Op(":=").Index().Struct(
+				jen.Id("name").String(),
+				jen.Id("target").String(),
+				jen.Id("body").String(),
+				jen.Id("invalid").Bool(),
+				jen.Id("err").Error(),
+				jen.Id("wantStatus").Int(),
+			).Values(append(cases, jen.Line())...),
+			jen.Line(),
+			jen.For(jen.List(jen.Id("_"), jen.Id("tt")).Op(":=").Range().Id("tests")).Block(
+				jen.Id("tt").Op(":=").Id("tt"),
+				jen.Id("t").Dot("Run").Call(jen.Id("tt").Dot("name"), jen.Func().Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(
+					append([]jen.Code{
+						jen.Id(mockUsecase).Op(":=").New(jen.Qual(gomodName+"/domain/mocks", useCase)),
+						expect,
+						jen.Line(),
+						jen.Id("req").Op(":=").Qual(httptestPath, "NewRequest").Call(jen.Qual("net/http", route.method()), jen.Id("tt").Dot("target"), jen.Qual("strings", "NewReader").Call(jen.Id("tt").Dot("body"))),
+						jen.Id("rec").Op(":=").Qual(httptestPath, "NewRecorder").Call(),
+						jen.Id(newServer).Call(jen.Id(mockUsecase)).Dot("ServeHTTP").Call(jen.Id("rec"), jen.Id("req")),
+						jen.Line(),
+					}, assertions...)...,
+				)),
+			),
+		)
+
+		f.Line()
+		f.Func().Id(fmt.Sprintf("Test%s_%s", handlerName, i.Name)).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(body...)
+
+		pathTest, err := genRestPathTest(gomodName, handlerName, newServer, useCase, route, i, parser.Entity, imports)
+		if err != nil {
+			return err
+		}
+		if pathTest != nil {
+			f.Line()
+			f.Add(pathTest)
+		}
+	}
+
+	return gen.save(f, fmt.Sprintf("%s/%s_handler_test.go", dirName, domainName))
+}
+
+// genRestPathTest will generate Test<Handler>_<Method>Path which check that field of entity bound from parameter of path,
+// e.g. ID of PUT /examples/:id, wins over the one written in body. It returns nil if no field of entity is bound from path
+func genRestPathTest(gomodName string, handlerName string, newServer string, useCase string, route restRoute, m domain.Method, entity domain.Entity, imports map[string]string) (jen.Code, error) {
+	var (
+		mockUsecase      = "mock" + useCase
+		params           = newRestParams(route, m)
+		entityBody, efs  = newRestEntityFields(route, params, entity)
+		args, mockReturn []jen.Code
+		match            []jen.Code
+	)
+	if len(efs) == 0 {
+		return nil, nil
+	}
+
+	// every parameter of path is filled by 2, while the entity in body is filled by 1
+	for _, ef := range efs {
+		var want jen.Code = jen.Lit(2)
+		if ef.field.Type == "string" {
+			want = jen.Lit("2")
+		}
+		match = append(match, jen.Id(entityBody.name).Dot(ef.field.Name).Op("==").Add(want))
+	}
+	for idx, p := range m.ParameterList {
+		name := p.Name
+		if name == "" {
+			name = fmt.Sprintf("param%d", idx)
+		}
+		if name != entityBody.name {
+			args = append(args, jen.Qual(mockPath, "Anything"))
+			continue
+		}
+		typ, err := genTypeCode(entityBody.typ, imports)
+		if err != nil {
+			return nil, err
+		}
+		var cond jen.Code = match[0]
+		for _, c := range match[1:] {
+			cond = jen.Add(cond).Op("&&").Add(c)
+		}
+		args = append(args, jen.Qual(mockPath, "MatchedBy").Call(jen.Func().Params(jen.Id(entityBody.name).Add(typ)).Bool().Block(jen.Return(cond))))
+	}
+	for _, r := range m.ResultList {
+		val, err := genTestValue(r.Type, imports)
+		if err != nil {
+			return nil, err
+		}
+		mockReturn = append(mockReturn, val)
+	}
+
+	var bodies []restParam
+	for _, p := range params {
+		if p.source == fromBody {
+			bodies = append(bodies, p)
+		}
+	}
+	value, err := genRestTestBody(bodies, entity, imports)
+	if err != nil {
+		return nil, err
+	}
+
+	return jen.Func().Id(fmt.Sprintf("Test%s_%sPath", handlerName, m.Name)).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(
+		jen.Id(mockUsecase).Op(":=").New(jen.Qual(gomodName+"/domain/mocks", useCase)),
+		jen.Id(mockUsecase).Dot("On").Call(append([]jen.Code{jen.Lit(m.Name)}, args...)...).Dot("Return").Call(mockReturn...).Dot("Once").Call(),
+		jen.Line(),
+		jen.List(jen.Id("body"), jen.Err()).Op(":=").Qual("encoding/json", "Marshal").Call(value),
+		jen.Qual(assertPath, "NoError").Call(jen.Id("t"), jen.Err()),
+		jen.Line(),
+		jen.Id("req").Op(":=").Qual(httptestPath, "NewRequest").Call(jen.Qual("net/http", route.method()), jen.Lit(route.target("2")), jen.Qual("strings", "NewReader").Call(jen.String().Call(jen.Id("body")))),
+		jen.Id("rec").Op(":=").Qual(httptestPath, "NewRecorder").Call(),
+		jen.Id(newServer).Call(jen.Id(mockUsecase)).Dot("ServeHTTP").Call(jen.Id("rec"), jen.Id("req")),
+		jen.Line(),
+		jen.Id(mockUsecase).Dot("AssertExpectations").Call(jen.Id("t")),
+		jen.Qual(assertPath, "Equal").Call(jen.Id("t"), jen.Qual("net/http", "StatusOK"), jen.Id("rec").Dot("Code")),
+	), nil
+}
+
+// genRestTestServer will create the router of rest server as variable r
+func genRestTestServer(restServer string) ([]jen.Code, error) {
+	switch restServer {
+	case domain.Echo:
+		return []jen.Code{jen.Id("r").Op(":=").Qual("github.com/labstack/echo", "New").Call()}, nil
+	case domain.Gin:
+		return []jen.Code{
+			jen.Qual("github.com/gin-gonic/gin", "SetMode").Call(jen.Qual("github.com/gin-gonic/gin", "TestMode")),
+			jen.Id("r").Op(":=").Qual("github.com/gin-gonic/gin", "New").Call(),
+		}, nil
+	case domain.GorillaMux:
+		return []jen.Code{jen.Id("r").Op(":=").Qual("github.com/gorilla/mux", "NewRouter").Call()}, nil
+	case domain.NetHTTP:
+		return []jen.Code{jen.Id("r").Op(":=").Qual("net/http", "NewServeMux").Call()}, nil
+	}
+	return nil, fmt.Errorf("rest server %s is not supported", restServer)
+}
+
+// restTestCase will generate a test case which write every field on its own line
+func restTestCase(fields ...jen.Code) jen.Code {
+	return jen.Line().Add(multilineValues(fields...))
+}
+
+// multilineValues will generate a composite literal which write every field on its own line
+func multilineValues(fields ...jen.Code) *jen.Statement {
+	var kv []jen.Code
+	for _, f := range fields {
+		kv = append(kv, jen.Line().Add(f))
+	}
+	return jen.Values(append(kv, jen.Line())...)
+}
+
+// genRestTestBody will generate the value which is encoded as json body of request,
+// entity is filled on every field so it is able to pass the common validation such as required
+func genRestTestBody(bodies []restParam, entity domain.Entity, imports map[string]string) (jen.Code, error) {
+	value := func(typ string) (jen.Code, error) {
+		if strings.TrimPrefix(typ, "*") != "domain."+entity.Name || len(entity.Fields) == 0 {
+			return genTestValue(typ, imports)
+		}
+		var fields []jen.Code
+		for _, f := range entity.Fields {
+			if f.Name == "" || strings.ToUpper(f.Name[:1]) != f.Name[:1] {
+				continue
+			}
+			val, err := genTestValue(f.Type, imports)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field(f.Name, val))
+		}
+		val := multilineValues(fields...)
+		if strings.HasPrefix(typ, "*") {
+			return jen.Op("&").Qual(imports["domain"], entity.Name).Add(val), nil
+		}
+		return jen.Qual(imports["domain"], entity.Name).Add(val), nil
+	}
+
+	if len(bodies) == 1 {
+		return value(bodies[0].typ)
+	}
+	// every parameter is written as a field of json body which is named as the parameter
+	var fields []jen.Code
+	for _, p := range bodies {
+		val, err := value(p.typ)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, jen.Lit(p.name).Op(":").Add(val))
+	}
+	return jen.Map(jen.String()).Interface().Add(multilineValues(fields...)), nil
+}
@@ -54,23 +54,292 @@ func (gen *caGen) GenUsecase(dirName string, domainFile string, gomodName string
 
 	for _, i := range parser.Usecase.Method {
 		var (
-			param            = genParamList(i)
-			returnT, returnV = genReturnList(i)
+			param            = genParamList(i, parserImports(gomodName, parser))
+			returnT, returnV = genReturnList(i, parserImports(gomodName, parser))
+			ctx              string
+			params           = append([]domain.MethodValue{}, i.ParameterList...)
+			body             []jen.Code
 		)
+		_, passThrough := repositoryMethod(parser, i)
+
+		// parameters are passed to repository, so every one of them has to be named
+		if passThrough {
+			param = nil
+			for idx := range params {
+				if params[idx].Name == "" {
+					params[idx].Name = fmt.Sprintf("param%d", idx)
+				}
+				param = append(param, jen.Id(params[idx].Name).Op(params[idx].Type))
+			}
+		}
+		for _, p := range params {
+			if p.Type == "context.Context" && p.Name != "" {
+				ctx = p.Name
+			}
+		}
+
+		// the method runs under the timeout of usecase if it receives context
+		if ctx != "" {
+			body = append(body,
+				jen.List(jen.Id(ctx), jen.Id("cancel")).Op(":=").Qual("context", "WithTimeout").Call(jen.Id(ctx), jen.Id(string(domainName[0])+"u").Dot("contextTimeout")),
+				jen.Id("defer").Id("cancel").Call(),
+				jen.Line(),
+			)
+		}
+		if passThrough {
+			var args []jen.Code
+			for _, p := range params {
+				arg := jen.Id(p.Name)
+				if strings.HasPrefix(p.Type, "...") {
+					arg = arg.Op("...")
+				}
+				args = append(args, arg)
+			}
+			call := jen.Id(string(domainName[0]) + "u").Dot(domainName + "Repo").Dot(i.Name).Call(args...)
+			if len(i.ResultList) > 0 {
+				body = append(body, jen.Return(call))
+			} else {
+				body = append(body, call)
+			}
+		} else {
+			reason := fmt.Sprintf("TODO: %s has no matching method in %s, implement it by hand", i.Name, repository)
+			if repository == "" {
+				reason = fmt.Sprintf("TODO: %s has no repository, implement it by hand", useCase)
+			}
+			body = append(body, jen.Comment(reason), jen.Return(notImplemented(i, returnV)...))
+		}
+
 		f.Line()
 		f.Func().
-			Params(jen.Id(string(domainName[0])+"u").Op("*").Id(domainName+"Usecase")).
-			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(
-			jen.List(jen.Id("ctx"), jen.Id("cancel")).Op(":=").Qual("context", "WithTimeout").Call(jen.Id("ctx"), jen.Id(string(domainName[0])+"u").Dot("contextTimeout")),
-			jen.Id("defer").Id("cancel").Call(),
-			jen.Return(returnV[:]...),
-		)
+			Params(jen.Id(string(domainName[0]) + "u").Op("*").Id(domainName + "Usecase")).
+			Id(i.Name).Params(param[:]...).Call(returnT[:]...).Block(body...)
 	}
 
 	fileDir := fmt.Sprintf("%s/%s_usecase.go", dirName, domainName)
-	err := f.Save(fileDir)
+	err := gen.save(f, fileDir)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	return gen.genUsecaseTest(dirName, domainName, gomodName, parser)
+}
+
+// genUsecaseTest will generate table driven test of every usecase method which passes through into repository,
+// the repository is replaced by its mock in domain/mocks
+func (gen *caGen) genUsecaseTest(dirName string, domainName string, gomodName string, parser *domain.Parser) error {
+	var (
+		imports  = map[string]string{"domain": gomodName + "/domain"}
+		useCase  = parser.Usecase.Name
+		mockRepo = "mock" + strings.TrimSuffix(parser.Repository.Name, "Repository") + "Repo"
+		newS     = fmt.Sprintf("New%s", useCase)
+	)
+
+	f := jen.NewFile("usecase_test")
+	f.ImportName(gomodName+"/domain", "domain")
+	f.ImportName(gomodName+"/domain/mocks", "mocks")
+	f.ImportAlias(gomodName+"/usecase", "ucase")
+	f.ImportName("github.com/stretchr/testify/assert", "assert")
+	f.ImportName("github.com/stretchr/testify/mock", "mock")
+
+	for _, i := range parser.Usecase.Method {
+		testName := fmt.Sprintf("Test%s_%s", useCase, i.Name)
+
+		f.Line()
+		repo, ok := repositoryMethod(parser, i)
+		if !ok {
+			reason := fmt.Sprintf("TODO: %s has no matching method in %s, write the test by hand", i.Name, parser.Repository.Name)
+			if parser.Repository.Name == "" {
+				reason = fmt.Sprintf("TODO: %s has no repository, write the test by hand", useCase)
+			}
+			f.Func().Id(testName).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(
+				jen.Id("t").Dot("Skip").Call(jen.Lit(reason)),
+			)
+			continue
+		}
+
+		var (
+			errIdx     = -1
+			hasCtx     = len(repo.ParameterList) > 0 && repo.ParameterList[0].Type == "context.Context"
+			anything   []jen.Code
+			callArgs   []jen.Code
+			rfParams   []jen.Code
+			wants      []jen.Code
+			mockReturn []jen.Code
+			gots       []jen.Code
+			asserts    []jen.Code
+		)
+		for idx, r := range repo.ResultList {
+			if r.Type == "error" {
+				errIdx = idx
+			}
+		}
+
+		for idx, p := range repo.ParameterList {
+			typ, err := genTypeCode(p.Type, imports)
+			if err != nil {
+				return err
+			}
+			val, err := genTestValue(p.Type, imports)
+			if err != nil {
+				return err
+			}
+			anything = append(anything, jen.Qual("github.com/stretchr/testify/mock", "Anything"))
+			callArgs = append(callArgs, val)
+			name := "_"
+			if idx == 0 && hasCtx {
+				name = "ctx"
+			}
+			rfParams = append(rfParams, jen.Id(name).Add(typ))
+		}
+
+		nonErr := len(repo.ResultList)
+		if errIdx >= 0 {
+			nonErr--
+		}
+		for idx, r := range repo.ResultList {
+			if idx == errIdx {
+				mockReturn = append(mockReturn, jen.Func().Params(rfParams...).Error().Block(
+					jen.If(jen.Id("tt").Dot("block")).Block(
+						jen.Op("<-").Id("ctx").Dot("Done").Call(),
+						jen.Return(jen.Id("ctx").Dot("Err").Call()),
+					),
+					jen.Return(jen.Id("tt").Dot("err")),
+				))
+				gots = append(gots, jen.Err())
+				continue
+			}
+
+			want, got := "want", "got"
+			if nonErr > 1 {
+				want, got = fmt.Sprintf("want%d", idx), fmt.Sprintf("got%d", idx)
+			}
+			val, err := genTestValue(r.Type, imports)
+			if err != nil {
+				return err
+			}
+			wants = append(wants, jen.Id(want).Op(":=").Add(val))
+			mockReturn = append(mockReturn, jen.Id(want))
+			gots = append(gots, jen.Id(got))
+			asserts = append(asserts, jen.Qual("github.com/stretchr/testify/assert", "Equal").Call(jen.Id("t"), jen.Id(want), jen.Id(got)))
+		}
+
+		// without error result, only the success case is able to be tested
+		cases := []jen.Code{
+			jen.Line().Values(field("name", jen.Lit("success")), field("timeout", jen.Qual("time", "Second"))),
+		}
+		check := asserts
+		if errIdx >= 0 {
+			cases = append(cases, jen.Line().Values(
+				field("name", jen.Lit("error, should propagate error of repository")),
+				field("timeout", jen.Qual("time", "Second")),
+				field("err", jen.Id("errRepository")),
+				field("wantErr", jen.Id("errRepository")),
+			))
+			if hasCtx {
+				cases = append(cases, jen.Line().Values(
+					field("name", jen.Lit("error, should stop when context timeout")),
+					field("timeout", jen.Qual("time", "Millisecond")),
+					field("block", jen.True()),
+					field("wantErr", jen.Qual("context", "DeadlineExceeded")),
+				))
+			}
+			check = []jen.Code{
+				jen.If(jen.Id("tt").Dot("wantErr").Op("!=").Nil()).Block(
+					jen.Qual("github.com/stretchr/testify/assert", "Equal").Call(jen.Id("t"), jen.Id("tt").Dot("wantErr"), jen.Err()),
+					jen.Return(),
+				),
+				jen.Qual("github.com/stretchr/testify/assert", "NoError").Call(jen.Id("t"), jen.Err()),
+			}
+			check = append(check, asserts...)
+		}
+
+		var call jen.Code = jen.Id("usecase").Dot(i.Name).Call(callArgs...)
+		if len(gots) > 0 {
+			call = jen.List(gots...).Op(":=").Id("usecase").Dot(i.Name).Call(callArgs...)
+		}
+
+		run := []jen.Code{
+			jen.Id(mockRepo).Op(":=").New(jen.Qual(gomodName+"/domain/mocks", parser.Repository.Name)),
+		}
+		run = append(run, wants...)
+		run = append(run,
+			jen.Id(mockRepo).Dot("On").Call(append([]jen.Code{jen.Lit(repo.Name)}, anything...)...).Dot("Return").Call(mockReturn...).Dot("Once").Call(),
+			jen.Line(),
+			jen.Id("usecase").Op(":=").Qual(gomodName+"/usecase", newS).Call(jen.Id(mockRepo), jen.Id("tt").Dot("timeout")),
+			call,
+			jen.Line(),
+			jen.Id(mockRepo).Dot("AssertExpectations").Call(jen.Id("t")),
+		)
+		run = append(run, check...)
+
+		var body []jen.Code
+		if errIdx >= 0 {
+			body = append(body, jen.Id("errRepository").Op(":=").Qual("errors", "New").Call(jen.Lit("unexpected error of repository")), jen.Line())
+		}
+		body = append(body,
+			jen.Id("tests").Op(":=").Index().Struct(
+				jen.Id("name").String(),
+				jen.Id("timeout").Qual("time", "Duration"),
+				jen.Id("block").Bool(),
+				jen.Id("err").Error(),
+				jen.Id("wantErr").Error(),
+			).Values(append(cases, jen.Line())...),
+			jen.Line(),
+			jen.For(jen.List(jen.Id("_"), jen.Id("tt")).Op(":=").Range().Id("tests")).Block(
+				jen.Id("tt").Op(":=").Id("tt"),
+				jen.Id("t").Dot("Run").Call(jen.Id("tt").Dot("name"), jen.Func().Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(run...)),
+			),
+		)
+
+		f.Func().Id(testName).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(body...)
+	}
+
+	return gen.save(f, fmt.Sprintf("%s/%s_usecase_test.go", dirName, domainName))
+}
+
+// field will generate a key value pair of composite literal, the order is kept as it is written
+func field(key string, value jen.Code) jen.Code {
+	return jen.Id(key).Op(":").Add(value)
+}
+
+// genTestValue will generate a value of the given type which is used as argument or result in generated tests
+func genTestValue(typ string, imports map[string]string) (*jen.Statement, error) {
+	switch {
+	case typ == "context.Context":
+		return jen.Qual("context", "TODO").Call(), nil
+	case typ == "error" || typ == "interface{}":
+		return jen.Nil(), nil
+	case typ == "string":
+		return jen.Lit("test"), nil
+	case typ == "bool":
+		return jen.True(), nil
+	case typ == "int":
+		return jen.Lit(1), nil
+	case isBasicType(typ):
+		return jen.Id(typ).Call(jen.Lit(1)), nil
+	case strings.HasPrefix(typ, "..."):
+		return genTestValue(typ[3:], imports)
+	case strings.HasPrefix(typ, "*domain."):
+		return jen.Op("&").Qual(imports["domain"], typ[len("*domain."):]).Values(), nil
+	case strings.HasPrefix(typ, "domain."):
+		return jen.Qual(imports["domain"], typ[len("domain."):]).Values(), nil
+	case strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["):
+		t, err := genTypeCode(typ, imports)
+		if err != nil {
+			return nil, err
+		}
+		return t.Values(), nil
+	case strings.HasPrefix(typ, "*"):
+		t, err := genTypeCode(typ[1:], imports)
+		if err != nil {
+			return nil, err
+		}
+		return jen.New(t), nil
+	default:
+		t, err := genTypeCode(typ, imports)
+		if err != nil {
+			return nil, err
+		}
+		return jen.Op("*").New(t), nil
+	}
 }
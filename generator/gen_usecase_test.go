@@ -18,6 +18,7 @@ const (
 
 import (
 	"context"
+	"errors"
 	"github.com/example/exampleusecase/domain"
 	"time"
 )
@@ -34,31 +35,264 @@ func NewExampleUsecase(timeout time.Duration) domain.ExampleUsecase {
 func (eu *exampleUsecase) Fetch(ctx context.Context) ([]*domain.Example, error) {
 	ctx, cancel := context.WithTimeout(ctx, eu.contextTimeout)
 	defer cancel()
-	return nil, nil
+
+	// TODO: ExampleUsecase has no repository, implement it by hand
+	return nil, errors.New("Fetch is not implemented")
 }
 
 func (eu *exampleUsecase) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
 	ctx, cancel := context.WithTimeout(ctx, eu.contextTimeout)
 	defer cancel()
-	return nil, nil
+
+	// TODO: ExampleUsecase has no repository, implement it by hand
+	return nil, errors.New("GetByID is not implemented")
 }
 
 func (eu *exampleUsecase) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	ctx, cancel := context.WithTimeout(ctx, eu.contextTimeout)
 	defer cancel()
-	return nil, nil
+
+	// TODO: ExampleUsecase has no repository, implement it by hand
+	return nil, errors.New("Store is not implemented")
 }
 
 func (eu *exampleUsecase) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	ctx, cancel := context.WithTimeout(ctx, eu.contextTimeout)
 	defer cancel()
-	return nil, nil
+
+	// TODO: ExampleUsecase has no repository, implement it by hand
+	return nil, errors.New("Update is not implemented")
 }
 
 func (eu *exampleUsecase) Delete(ctx context.Context, id uint64) error {
 	ctx, cancel := context.WithTimeout(ctx, eu.contextTimeout)
 	defer cancel()
-	return nil
+
+	// TODO: ExampleUsecase has no repository, implement it by hand
+	return errors.New("Delete is not implemented")
+}
+`
+
+	expected_example_usecase_test = `package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/example/exampleusecase/domain"
+	"github.com/example/exampleusecase/domain/mocks"
+	ucase "github.com/example/exampleusecase/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestExampleUsecase_Fetch(t *testing.T) {
+	errRepository := errors.New("unexpected error of repository")
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		block   bool
+		err     error
+		wantErr error
+	}{
+		{name: "success", timeout: time.Second},
+		{name: "error, should propagate error of repository", timeout: time.Second, err: errRepository, wantErr: errRepository},
+		{name: "error, should stop when context timeout", timeout: time.Millisecond, block: true, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockExampleRepo := new(mocks.ExampleRepository)
+			want := []*domain.Example{}
+			mockExampleRepo.On("Fetch", mock.Anything).Return(want, func(ctx context.Context) error {
+				if tt.block {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return tt.err
+			}).Once()
+
+			usecase := ucase.NewExampleUsecase(mockExampleRepo, tt.timeout)
+			got, err := usecase.Fetch(context.TODO())
+
+			mockExampleRepo.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestExampleUsecase_GetByID(t *testing.T) {
+	errRepository := errors.New("unexpected error of repository")
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		block   bool
+		err     error
+		wantErr error
+	}{
+		{name: "success", timeout: time.Second},
+		{name: "error, should propagate error of repository", timeout: time.Second, err: errRepository, wantErr: errRepository},
+		{name: "error, should stop when context timeout", timeout: time.Millisecond, block: true, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockExampleRepo := new(mocks.ExampleRepository)
+			want := &domain.Example{}
+			mockExampleRepo.On("GetByID", mock.Anything, mock.Anything).Return(want, func(ctx context.Context, _ uint64) error {
+				if tt.block {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return tt.err
+			}).Once()
+
+			usecase := ucase.NewExampleUsecase(mockExampleRepo, tt.timeout)
+			got, err := usecase.GetByID(context.TODO(), uint64(1))
+
+			mockExampleRepo.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestExampleUsecase_Store(t *testing.T) {
+	errRepository := errors.New("unexpected error of repository")
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		block   bool
+		err     error
+		wantErr error
+	}{
+		{name: "success", timeout: time.Second},
+		{name: "error, should propagate error of repository", timeout: time.Second, err: errRepository, wantErr: errRepository},
+		{name: "error, should stop when context timeout", timeout: time.Millisecond, block: true, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockExampleRepo := new(mocks.ExampleRepository)
+			want := &domain.Example{}
+			mockExampleRepo.On("Store", mock.Anything, mock.Anything).Return(want, func(ctx context.Context, _ *domain.Example) error {
+				if tt.block {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return tt.err
+			}).Once()
+
+			usecase := ucase.NewExampleUsecase(mockExampleRepo, tt.timeout)
+			got, err := usecase.Store(context.TODO(), &domain.Example{})
+
+			mockExampleRepo.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestExampleUsecase_Update(t *testing.T) {
+	errRepository := errors.New("unexpected error of repository")
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		block   bool
+		err     error
+		wantErr error
+	}{
+		{name: "success", timeout: time.Second},
+		{name: "error, should propagate error of repository", timeout: time.Second, err: errRepository, wantErr: errRepository},
+		{name: "error, should stop when context timeout", timeout: time.Millisecond, block: true, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockExampleRepo := new(mocks.ExampleRepository)
+			want := &domain.Example{}
+			mockExampleRepo.On("Update", mock.Anything, mock.Anything).Return(want, func(ctx context.Context, _ *domain.Example) error {
+				if tt.block {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return tt.err
+			}).Once()
+
+			usecase := ucase.NewExampleUsecase(mockExampleRepo, tt.timeout)
+			got, err := usecase.Update(context.TODO(), &domain.Example{})
+
+			mockExampleRepo.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestExampleUsecase_Delete(t *testing.T) {
+	errRepository := errors.New("unexpected error of repository")
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		block   bool
+		err     error
+		wantErr error
+	}{
+		{name: "success", timeout: time.Second},
+		{name: "error, should propagate error of repository", timeout: time.Second, err: errRepository, wantErr: errRepository},
+		{name: "error, should stop when context timeout", timeout: time.Millisecond, block: true, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockExampleRepo := new(mocks.ExampleRepository)
+			mockExampleRepo.On("Delete", mock.Anything, mock.Anything).Return(func(ctx context.Context, _ uint64) error {
+				if tt.block {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return tt.err
+			}).Once()
+
+			usecase := ucase.NewExampleUsecase(mockExampleRepo, tt.timeout)
+			err := usecase.Delete(context.TODO(), uint64(1))
+
+			mockExampleRepo.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
 }
 `
 
@@ -86,31 +320,36 @@ func NewExampleUsecase(er domain.ExampleRepository, timeout time.Duration) domai
 func (eu *exampleUsecase) Fetch(ctx context.Context) ([]*domain.Example, error) {
 	ctx, cancel := context.WithTimeout(ctx, eu.contextTimeout)
 	defer cancel()
-	return nil, nil
+
+	return eu.exampleRepo.Fetch(ctx)
 }
 
 func (eu *exampleUsecase) GetByID(ctx context.Context, id uint64) (*domain.Example, error) {
 	ctx, cancel := context.WithTimeout(ctx, eu.contextTimeout)
 	defer cancel()
-	return nil, nil
+
+	return eu.exampleRepo.GetByID(ctx, id)
 }
 
 func (eu *exampleUsecase) Store(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	ctx, cancel := context.WithTimeout(ctx, eu.contextTimeout)
 	defer cancel()
-	return nil, nil
+
+	return eu.exampleRepo.Store(ctx, exp)
 }
 
 func (eu *exampleUsecase) Update(ctx context.Context, exp *domain.Example) (*domain.Example, error) {
 	ctx, cancel := context.WithTimeout(ctx, eu.contextTimeout)
 	defer cancel()
-	return nil, nil
+
+	return eu.exampleRepo.Update(ctx, exp)
 }
 
 func (eu *exampleUsecase) Delete(ctx context.Context, id uint64) error {
 	ctx, cancel := context.WithTimeout(ctx, eu.contextTimeout)
 	defer cancel()
-	return nil
+
+	return eu.exampleRepo.Delete(ctx, id)
 }
 `
 )
@@ -202,7 +441,7 @@ func TestGenerateUsecaseLayer(t *testing.T) {
 		}
 
 		// generate example_usecase.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenUsecase(dirName, domainFile, gomodName, parser)
 		resGopg, err := newFs.FindFile(dirName + "/example_usecase.go")
 		assert.NoError(t, err)
@@ -221,6 +460,10 @@ func TestGenerateUsecaseLayer(t *testing.T) {
 		}
 		assert.Equal(t, expected_example_usecase_no_repo, string(data))
 
+		data, err = ioutil.ReadFile(dirName + "/example_usecase_test.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `t.Skip("TODO: ExampleUsecase has no repository, write the test by hand")`)
+
 		// remove directory of service
 		err = newFs.RemoveDir(serviceName)
 		if err != nil {
@@ -363,7 +606,7 @@ func TestGenerateUsecaseLayer(t *testing.T) {
 		}
 
 		// generate example_usecase.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err = gen.GenUsecase(dirName, domainFile, gomodName, parser)
 		resGopg, err := newFs.FindFile(dirName + "/example_usecase.go")
 		assert.NoError(t, err)
@@ -382,6 +625,109 @@ func TestGenerateUsecaseLayer(t *testing.T) {
 		}
 		assert.Equal(t, expected_example_usecase_with_repo, string(data))
 
+		data, err = ioutil.ReadFile(dirName + "/example_usecase_test.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected_example_usecase_test, string(data))
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, should pass through into repository only the method which has the same signature", func(t *testing.T) {
+		parser := &domain.Parser{
+			Usecase: domain.Usecase{
+				Name: "ExampleUsecase",
+				Method: []domain.Method{
+					domain.Method{
+						Name: "Fetch",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "c", Type: "context.Context"},
+							domain.MethodValue{Name: "names", Type: "...string"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "[]*domain.Example"},
+							domain.MethodValue{Type: "error"},
+						},
+					},
+					domain.Method{
+						Name: "Store",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "ctx", Type: "context.Context"},
+							domain.MethodValue{Name: "exp", Type: "domain.Example"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "error"},
+						},
+					},
+					domain.Method{
+						Name: "Count",
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "int"},
+						},
+					},
+				},
+			},
+			Repository: domain.Repository{
+				Name: "ExampleRepository",
+				Method: []domain.Method{
+					domain.Method{
+						Name: "Fetch",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "ctx", Type: "context.Context"},
+							domain.MethodValue{Name: "names", Type: "...string"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "[]*domain.Example"},
+							domain.MethodValue{Type: "error"},
+						},
+					},
+					domain.Method{
+						Name: "Store",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "ctx", Type: "context.Context"},
+							domain.MethodValue{Name: "exp", Type: "*domain.Example"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "error"},
+						},
+					},
+				},
+			},
+		}
+
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newFs.CreateDir(serviceName + "/" + dirLayer)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		gen := generator.NewGeneratorService(newFs)
+		err = gen.GenUsecase(dirName, domainFile, gomodName, parser)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(dirName + "/example_usecase.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `c, cancel := context.WithTimeout(c, eu.contextTimeout)
+	defer cancel()
+
+	return eu.exampleRepo.Fetch(c, names...)`)
+		assert.Contains(t, string(data), `	// TODO: Store has no matching method in ExampleRepository, implement it by hand
+	return errors.New("Store is not implemented")`)
+		assert.Contains(t, string(data), `func (eu *exampleUsecase) Count() int {
+	// TODO: Count has no matching method in ExampleRepository, implement it by hand
+	return 0
+}`)
+
 		// remove directory of service
 		err = newFs.RemoveDir(serviceName)
 		if err != nil {
@@ -392,7 +738,7 @@ func TestGenerateUsecaseLayer(t *testing.T) {
 
 	t.Run("failed, because parser not contain appropriate value", func(t *testing.T) {
 		// generate status_code.go file
-		gen := generator.NewGeneratorService()
+		gen := generator.NewGeneratorService(newFs)
 		err := gen.GenUsecase(dirName, domainFile, gomodName, parser)
 
 		assert.Error(t, err)
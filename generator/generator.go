@@ -4,30 +4,79 @@ Package generator used to generate codes and create a file based on the layer
 package generator
 
 import (
+	"bytes"
+	"strings"
+
 	"github.com/dave/jennifer/jen"
 	"github.com/wicaker/cacli/domain"
 )
 
 type caGen struct {
 	gen domain.Generator
+	fs  domain.FsService
+}
+
+// NewGeneratorService will create new a caGen object representation of domain.Generator interface,
+// every file is written through the given filesystem
+func NewGeneratorService(fs domain.FsService) domain.GeneratorService {
+	return &caGen{fs: fs}
+}
+
+// Configure will set the options which change the generated codes, e.g. errors of rest as problem+json
+func (gen *caGen) Configure(option domain.Generator) {
+	gen.gen = option
+}
+
+// save will render the jen file and write it through the filesystem of generator
+func (gen *caGen) save(f *jen.File, fileName string) error {
+	buf := &bytes.Buffer{}
+	if err := f.Render(buf); err != nil {
+		return err
+	}
+	return gen.fs.WriteFile(fileName, buf.Bytes())
 }
 
-// NewGeneratorService will create new a caGen object representation of domain.Generator interface
-func NewGeneratorService() domain.GeneratorService {
-	return &caGen{}
+// ensureDir will create the directory if it does not exist yet, so a layer is able to be generated again
+func (gen *caGen) ensureDir(dirName string) error {
+	res, err := gen.fs.FindDir(dirName)
+	if err != nil || res != nil {
+		return err
+	}
+	return gen.fs.CreateDir(dirName)
+}
+
+// parserImports will return import path by package name for the types of the parsed domain,
+// the package of domain itself is always known
+func parserImports(gomodName string, parser *domain.Parser) map[string]string {
+	imports := map[string]string{}
+	for name, importPath := range parser.Imports {
+		imports[name] = importPath
+	}
+	imports["domain"] = gomodName + "/domain"
+	return imports
+}
+
+// genValueType will convert type of parameter or result into jen code, so the package of it is imported,
+// type which is not a valid expression is written as it is
+func genValueType(typ string, imports map[string]string) jen.Code {
+	code, err := genTypeCode(typ, imports)
+	if err != nil {
+		return jen.Op(typ)
+	}
+	return code
 }
 
-func genParamList(i domain.Method) []jen.Code {
+func genParamList(i domain.Method, imports map[string]string) []jen.Code {
 	var param []jen.Code
 	for _, j := range i.ParameterList {
-		param = append(param, jen.Id(j.Name).Op(j.Type))
+		param = append(param, jen.Id(j.Name).Add(genValueType(j.Type, imports)))
 	}
 	return param
 }
 
-func genReturnList(i domain.Method) (returnType []jen.Code, returnValue []jen.Code) {
+func genReturnList(i domain.Method, imports map[string]string) (returnType []jen.Code, returnValue []jen.Code) {
 	for _, k := range i.ResultList {
-		returnType = append(returnType, jen.Id(k.Name).Op(k.Type))
+		returnType = append(returnType, jen.Id(k.Name).Add(genValueType(k.Type, imports)))
 		switch k.Type {
 		case "string":
 			returnValue = append(returnValue, jen.Op(`""`))
@@ -38,12 +87,8 @@ func genReturnList(i domain.Method) (returnType []jen.Code, returnValue []jen.Co
 		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintpr", "byte", "rune":
 			returnValue = append(returnValue, jen.Op("0"))
 		default:
-			if len(k.Type) > 7 {
-				if k.Type[:7] == "domain." {
-					returnValue = append(returnValue, jen.Op(k.Type+"{}"))
-				} else {
-					returnValue = append(returnValue, jen.Nil())
-				}
+			if strings.HasPrefix(k.Type, "domain.") {
+				returnValue = append(returnValue, jen.Add(genValueType(k.Type, imports)).Values())
 			} else {
 				returnValue = append(returnValue, jen.Nil())
 			}
@@ -51,3 +96,46 @@ func genReturnList(i domain.Method) (returnType []jen.Code, returnValue []jen.Co
 	}
 	return
 }
+
+// notImplemented will replace the nil error of the zero values by an error which tells the method is not implemented,
+// so a stub which is left as it is fails instead of returning nothing, method without error result keeps the zero values
+func notImplemented(i domain.Method, returnValue []jen.Code) []jen.Code {
+	if len(i.ResultList) == 0 || i.ResultList[len(i.ResultList)-1].Type != "error" {
+		return returnValue
+	}
+	values := append([]jen.Code{}, returnValue[:len(returnValue)-1]...)
+	return append(values, jen.Qual("errors", "New").Call(jen.Lit(i.Name+" is not implemented")))
+}
+
+// repositoryMethod will find method of repository which has the same name and signature with the usecase method,
+// the parameter and result names are ignored
+func repositoryMethod(parser *domain.Parser, m domain.Method) (domain.Method, bool) {
+	if parser.Repository.Name == "" {
+		return domain.Method{}, false
+	}
+	for _, r := range parser.Repository.Method {
+		if r.Name != m.Name || len(r.ParameterList) != len(m.ParameterList) || len(r.ResultList) != len(m.ResultList) {
+			continue
+		}
+		same := true
+		for i := range r.ParameterList {
+			same = same && r.ParameterList[i].Type == m.ParameterList[i].Type
+		}
+		for i := range r.ResultList {
+			same = same && r.ResultList[i].Type == m.ResultList[i].Type
+		}
+		if same {
+			return r, true
+		}
+	}
+	return domain.Method{}, false
+}
+
+// valueResults will return the results of method except the error
+func valueResults(m domain.Method) []domain.MethodValue {
+	results := m.ResultList
+	if len(results) > 0 && results[len(results)-1].Type == "error" {
+		results = results[:len(results)-1]
+	}
+	return results
+}
@@ -0,0 +1,65 @@
+/*
+Package manifest used to read and write .cacli.yaml, the manifest in root of generated project
+*/
+package manifest
+
+import (
+	"bytes"
+	"path/filepath"
+
+	"github.com/wicaker/cacli/domain"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+type caManifest struct {
+	fs domain.FsService
+}
+
+// NewManifestService will create new a caManifest object representation of domain.ManifestService interface
+func NewManifestService(fs domain.FsService) domain.ManifestService {
+	return &caManifest{fs: fs}
+}
+
+// Read is a method for read manifest in the given directory, return nil if the manifest not found
+func (m *caManifest) Read(dirName string) (*domain.Manifest, error) {
+	fileName := filepath.Join(dirName, domain.ManifestFile)
+	res, err := m.fs.FindFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	err = v.ReadConfig(bytes.NewReader(res.([]byte)))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &domain.Manifest{}
+	err = v.Unmarshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// Write is a method for write manifest into the given directory
+// viper lowercase every key when writing, so yaml is used directly to keep the key as it is
+func (m *caManifest) Write(dirName string, manifest *domain.Manifest) error {
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	err = m.fs.WriteFile(filepath.Join(dirName, domain.ManifestFile), data)
+	if err != nil {
+		return err
+	}
+	return nil
+}
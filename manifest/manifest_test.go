@@ -0,0 +1,75 @@
+package manifest_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/fs"
+	"github.com/wicaker/cacli/manifest"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifest(t *testing.T) {
+	var (
+		serviceName = "test_manifest"
+		newFs       = fs.NewFsService()
+		newManifest = manifest.NewManifestService(newFs)
+		expected    = &domain.Manifest{
+			Version:    "v0.1.0",
+			GoModName:  "github.com/example/examplemanifest",
+			DbHelper:   domain.Sqlx,
+			RestServer: domain.GorillaMux,
+			GraphqlOpt: true,
+			GrpcOpt:    false,
+			Domains:    []string{"example"},
+			Files:      []string{"domain/example.go", "usecase/example_usecase.go"},
+			Tables: []domain.Table{
+				domain.Table{Domain: "example", Name: "examples", Columns: []domain.Column{
+					domain.Column{Name: "id", Type: "uint64", Primary: true},
+					domain.Column{Name: "deleted_at", Type: "*time.Time"},
+				}},
+			},
+		}
+	)
+
+	t.Run("success, should write and read the manifest", func(t *testing.T) {
+		// create directory of service
+		err := newFs.CreateDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		err = newManifest.Write(serviceName, expected)
+		assert.NoError(t, err)
+
+		res, err := newFs.FindFile(serviceName + "/" + domain.ManifestFile)
+		assert.NoError(t, err)
+		assert.Contains(t, string(res.([]byte)), "goModName: github.com/example/examplemanifest")
+
+		result, err := newManifest.Read(serviceName)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+
+		// remove directory of service
+		err = newFs.RemoveDir(serviceName)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	})
+
+	t.Run("success, return nil if the manifest not found", func(t *testing.T) {
+		result, err := newManifest.Read(serviceName)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("failed, because directory not found", func(t *testing.T) {
+		err := newManifest.Write(serviceName, expected)
+		assert.Error(t, err)
+	})
+}
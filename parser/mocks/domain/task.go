@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Base represent the columns which every table has
+type Base struct {
+	Version int `json:"version"`
+}
+
+// Task represent a task which should be done
+type Task struct {
+	Base
+	// ID is primary key of task
+	ID          int64      `json:"id" db:"id"`
+	Title, Note string     `validate:"required,max=100"`
+	Labels      []string   `json:"labels,omitempty" db:"-"`
+	Attempts    [3]int     `json:"attempts"`
+	DoneAt      *time.Time `json:"done_at" db:"done_at" pg:",soft_delete"` // nil if not done yet
+}
+
+// TaskUsecase represent the Task's usecases contract
+type TaskUsecase interface {
+	Fetch(ctx context.Context) ([]*Task, error)
+	// Done will mark the task as done
+	// @route PUT /tasks/:id/done
+	Done(ctx context.Context, id int64) error
+}
+
+// TaskRepository represent the Task's repository contract
+type TaskRepository interface {
+	Fetch(ctx context.Context) ([]*Task, error)
+}
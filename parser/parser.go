@@ -6,7 +6,12 @@ package parser
 import (
 	"fmt"
 	"go/ast"
+	"path"
+	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/wicaker/cacli/domain"
 )
 
 func getMethodsInInterface(n ast.Node) []*ast.Field {
@@ -27,24 +32,238 @@ func getFuncType(n ast.Node) *ast.FuncType {
 	}
 }
 
-func getTypeExpr(n ast.Node, structName string) string {
+// majorVersion match the last element of import path which is only the major version of module, e.g. v9 of github.com/go-pg/pg/v9
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// getImports will return import path by its package name, the name is taken from the alias,
+// else guessed from the path as go tooling does, e.g. pg of github.com/go-pg/pg/v9 and yaml of gopkg.in/yaml.v2
+func getImports(specs []*ast.ImportSpec) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range specs {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		var name string
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else {
+			name = path.Base(importPath)
+			if majorVersion.MatchString(name) && path.Dir(importPath) != "." {
+				name = path.Base(path.Dir(importPath))
+			}
+			name = strings.TrimPrefix(strings.SplitN(name, ".", 2)[0], "go-")
+		}
+
+		// blank and dot import have no name to qualify the types
+		if name == "_" || name == "." {
+			continue
+		}
+		imports[name] = importPath
+	}
+	return imports
+}
+
+// commentText will return text of comment group without the trailing newline
+func commentText(c *ast.CommentGroup) string {
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSpace(c.Text())
+}
+
+// parseTags will split struct tag into its key and value, following the convention of reflect.StructTag
+func parseTags(tag string) map[string]string {
+	tags := map[string]string{}
+	for tag != "" {
+		tag = strings.TrimLeft(tag, " ")
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		// scan quoted value, which may contain escaped quote
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			break
+		}
+		tags[key] = value
+		tag = tag[i+1:]
+	}
+	return tags
+}
+
+// getTypeExpr will print type expression as it is seen from outside of domain package,
+// exported identifier is qualified with domain, except the type parameters
+func getTypeExpr(n ast.Node, typeParams map[string]bool) string {
 	switch d := n.(type) {
 	case *ast.StarExpr:
-		return "*" + getTypeExpr(d.X, structName)
+		return "*" + getTypeExpr(d.X, typeParams)
 	case *ast.SelectorExpr:
 		result := fmt.Sprintf("%s.%s", d.X, d.Sel)
 		return result
 	case *ast.Ident:
 		name := d.Name
-		if string(d.Name[0]) == strings.ToUpper(string(name[0])) {
-			return "domain." + d.Name
+		if typeParams[name] || !ast.IsExported(name) {
+			return name
 		}
-		return d.Name
+		return "domain." + name
+	case *ast.BasicLit:
+		return d.Value
+	case *ast.ParenExpr:
+		return "(" + getTypeExpr(d.X, typeParams) + ")"
+	case *ast.UnaryExpr:
+		return d.Op.String() + getTypeExpr(d.X, typeParams)
+	case *ast.BinaryExpr:
+		return getTypeExpr(d.X, typeParams) + " " + d.Op.String() + " " + getTypeExpr(d.Y, typeParams)
 	case *ast.ArrayType:
-		return "[]" + getTypeExpr(d.Elt, structName)
+		if d.Len == nil {
+			return "[]" + getTypeExpr(d.Elt, typeParams)
+		}
+		return "[" + getTypeExpr(d.Len, typeParams) + "]" + getTypeExpr(d.Elt, typeParams)
+	case *ast.Ellipsis:
+		if d.Elt == nil {
+			return "..."
+		}
+		return "..." + getTypeExpr(d.Elt, typeParams)
+	case *ast.MapType:
+		return "map[" + getTypeExpr(d.Key, typeParams) + "]" + getTypeExpr(d.Value, typeParams)
+	case *ast.ChanType:
+		value := getTypeExpr(d.Value, typeParams)
+		switch d.Dir {
+		case ast.SEND:
+			return "chan<- " + value
+		case ast.RECV:
+			return "<-chan " + value
+		}
+		// chan <-chan T is parsed as chan<- chan T
+		if c, ok := d.Value.(*ast.ChanType); ok && c.Dir == ast.RECV {
+			value = "(" + value + ")"
+		}
+		return "chan " + value
+	case *ast.FuncType:
+		return "func" + getSignature(d, typeParams)
 	case *ast.InterfaceType:
-		return "interface{}"
+		if d.Methods == nil || len(d.Methods.List) == 0 {
+			return "interface{}"
+		}
+		var methods []string
+		for _, m := range d.Methods.List {
+			if f, ok := m.Type.(*ast.FuncType); ok && len(m.Names) > 0 {
+				methods = append(methods, m.Names[0].Name+getSignature(f, typeParams))
+				continue
+			}
+			methods = append(methods, getTypeExpr(m.Type, typeParams))
+		}
+		return "interface{ " + strings.Join(methods, "; ") + " }"
+	case *ast.StructType:
+		if d.Fields == nil || len(d.Fields.List) == 0 {
+			return "struct{}"
+		}
+		var fields []string
+		for _, f := range d.Fields.List {
+			field := getFieldList(&ast.FieldList{List: []*ast.Field{f}}, typeParams)
+			if f.Tag != nil {
+				field += " " + f.Tag.Value
+			}
+			fields = append(fields, field)
+		}
+		return "struct{ " + strings.Join(fields, "; ") + " }"
+	case *ast.IndexExpr:
+		return getTypeExpr(d.X, typeParams) + "[" + getTypeExpr(d.Index, typeParams) + "]"
+	case *ast.IndexListExpr:
+		var indices []string
+		for _, i := range d.Indices {
+			indices = append(indices, getTypeExpr(i, typeParams))
+		}
+		return getTypeExpr(d.X, typeParams) + "[" + strings.Join(indices, ", ") + "]"
 	default:
 		return ""
 	}
 }
+
+// getSignature will print parameters and results of function type
+func getSignature(f *ast.FuncType, typeParams map[string]bool) string {
+	result := "(" + getFieldList(f.Params, typeParams) + ")"
+	if f.Results == nil || len(f.Results.List) == 0 {
+		return result
+	}
+
+	results := getFieldList(f.Results, typeParams)
+	if len(f.Results.List) == 1 && len(f.Results.List[0].Names) == 0 {
+		return result + " " + results
+	}
+	return result + " (" + results + ")"
+}
+
+// getFieldList will print list of field, e.g. parameters of function as `a, b int, c string`
+func getFieldList(fields *ast.FieldList, typeParams map[string]bool) string {
+	if fields == nil {
+		return ""
+	}
+
+	var list []string
+	for _, f := range fields.List {
+		typ := getTypeExpr(f.Type, typeParams)
+		if len(f.Names) == 0 {
+			list = append(list, typ)
+			continue
+		}
+
+		var names []string
+		for _, n := range f.Names {
+			names = append(names, n.Name)
+		}
+		list = append(list, strings.Join(names, ", ")+" "+typ)
+	}
+	return strings.Join(list, ", ")
+}
+
+// getMethodValues will append every parameter or result of the field list into values,
+// field which declare several names, e.g. `from, to uint64`, is split into one value per name
+func getMethodValues(values []domain.MethodValue, fields *ast.FieldList, typeParams map[string]bool) []domain.MethodValue {
+	if fields == nil {
+		return values
+	}
+	for _, k := range fields.List {
+		r := getTypeExpr(k.Type, typeParams)
+		if len(k.Names) == 0 {
+			values = append(values, domain.MethodValue{Type: r})
+			continue
+		}
+		for _, n := range k.Names {
+			values = append(values, domain.MethodValue{Name: n.String(), Type: r})
+		}
+	}
+	return values
+}
+
+// getTypeParams will collect name of the declared type parameters, e.g. T and K of [T any, K comparable]
+func getTypeParams(fields *ast.FieldList) map[string]bool {
+	typeParams := map[string]bool{}
+	if fields == nil {
+		return typeParams
+	}
+	for _, f := range fields.List {
+		for _, n := range f.Names {
+			typeParams[n.Name] = true
+		}
+	}
+	return typeParams
+}
@@ -6,8 +6,8 @@ import (
 	"go/parser"
 	"go/scanner"
 	"go/token"
-	"io/ioutil"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/wicaker/cacli/domain"
@@ -16,13 +16,15 @@ import (
 type doParser struct {
 	domainFileName string
 	par            domain.Parser
+	fs             domain.FsService
 }
 
 // NewParserDomain will create new a doParser object representation of domain.ParserDomain interface
-// used particularly to parsing domain layer
-func NewParserDomain(dfn string) domain.ParserDomain {
+// used particularly to parsing domain layer, the file is read through the given filesystem
+func NewParserDomain(fs domain.FsService, dfn string) domain.ParserDomain {
 	return &doParser{
 		domainFileName: dfn,
+		fs:             fs,
 	}
 }
 
@@ -34,7 +36,7 @@ func (v *doParser) DomainParser(filePath string) (*domain.Parser, error) {
 		fs = token.NewFileSet()
 	)
 
-	b, err := ioutil.ReadFile(filePath)
+	b, err := v.fs.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +44,7 @@ func (v *doParser) DomainParser(filePath string) (*domain.Parser, error) {
 	f := fs.AddFile(filePath, fs.Base(), len(b))
 	s.Init(f, b, nil, scanner.ScanComments)
 
-	p, err := parser.ParseFile(fs, filePath, nil, parser.AllErrors)
+	p, err := parser.ParseFile(fs, filePath, b, parser.AllErrors|parser.ParseComments)
 	if err != nil {
 		log.Printf("could not parse %s: %v", filePath, err)
 		return nil, err
@@ -64,6 +66,7 @@ func (v *doParser) Visit(n ast.Node) ast.Visitor {
 	}
 	switch d := n.(type) {
 	case *ast.File:
+		v.par.Imports = getImports(d.Imports)
 		for _, j := range d.Decls {
 			v.getType(j)
 		}
@@ -77,15 +80,30 @@ func (v *doParser) getType(n ast.Node) {
 	case *ast.GenDecl:
 		if d.Tok.String() == "type" {
 			for _, j := range d.Specs {
-				v.getTypeSpec(j)
+				v.getTypeSpec(j, d.Doc)
 			}
 		}
 	}
 }
 
-func (v *doParser) getTypeSpec(n ast.Node) {
+func (v *doParser) getTypeSpec(n ast.Node, doc *ast.CommentGroup) {
 	switch d := n.(type) {
 	case *ast.TypeSpec:
+		// get entity of domain, the struct which has same name with name of file in domain dir
+		if st, ok := d.Type.(*ast.StructType); ok {
+			if strings.ToUpper(d.Name.Name) != strings.ToUpper(v.domainFileName) {
+				return
+			}
+			if d.Doc != nil {
+				doc = d.Doc
+			}
+
+			v.par.Entity.Name = d.Name.Name
+			v.par.Entity.Doc = commentText(doc)
+			v.initiateEntity(st.Fields.List, getTypeParams(d.TypeParams))
+			return
+		}
+
 		if _, ok := d.Type.(*ast.InterfaceType); ok {
 			// get Usecase layer of interface usecase
 			if len(d.Name.Name) > 7 {
@@ -100,7 +118,7 @@ func (v *doParser) getTypeSpec(n ast.Node) {
 
 					v.par.Usecase.Name = d.Name.Name
 					field := getMethodsInInterface(d.Type)
-					v.initiateUsecase(field, d.Name.Name)
+					v.initiateUsecase(field, getTypeParams(d.TypeParams))
 					return
 				}
 			}
@@ -118,7 +136,7 @@ func (v *doParser) getTypeSpec(n ast.Node) {
 
 					v.par.Repository.Name = d.Name.Name
 					field := getMethodsInInterface(d.Type)
-					v.initiateRepository(field, d.Name.Name)
+					v.initiateRepository(field, getTypeParams(d.TypeParams))
 					return
 				}
 			}
@@ -126,60 +144,78 @@ func (v *doParser) getTypeSpec(n ast.Node) {
 	}
 }
 
+// initiateEntity will initiate every field of entity, field which declare several names is split into one field per name
+func (v *doParser) initiateEntity(fields []*ast.Field, typeParams map[string]bool) {
+	for _, j := range fields {
+		field := domain.Field{
+			Type:    getTypeExpr(j.Type, typeParams),
+			Tags:    map[string]string{},
+			Doc:     commentText(j.Doc),
+			Comment: commentText(j.Comment),
+		}
+		switch t := j.Type.(type) {
+		case *ast.StarExpr:
+			field.Pointer = true
+		case *ast.ArrayType:
+			field.Slice = t.Len == nil
+		}
+		if j.Tag != nil {
+			tag, err := strconv.Unquote(j.Tag.Value)
+			if err == nil {
+				field.Tags = parseTags(tag)
+			}
+		}
+
+		if len(j.Names) == 0 {
+			field.Name = strings.TrimPrefix(field.Type[strings.LastIndex(field.Type, ".")+1:], "*")
+			field.Embedded = true
+			v.par.Entity.Fields = append(v.par.Entity.Fields, field)
+			continue
+		}
+		for _, name := range j.Names {
+			f := field
+			f.Name = name.Name
+			v.par.Entity.Fields = append(v.par.Entity.Fields, f)
+		}
+	}
+}
+
 // initiateRepository will initiate detail information which needed in repository layer
-func (v *doParser) initiateRepository(field []*ast.Field, nameType string) {
+func (v *doParser) initiateRepository(field []*ast.Field, typeParams map[string]bool) {
 	for _, j := range field {
+		// embedded interface has no name
+		if len(j.Names) == 0 {
+			continue
+		}
 		method := domain.Method{
 			Name:          j.Names[0].String(),
 			ParameterList: []domain.MethodValue{},
 			ResultList:    []domain.MethodValue{},
+			Doc:           commentText(j.Doc),
 		}
 		fType := getFuncType(j.Type)
-		for _, k := range fType.Params.List {
-			r := getTypeExpr(k.Type, nameType[:len(nameType)-10])
-			methodValue := domain.MethodValue{Type: r}
-			if len(k.Names) > 0 {
-				methodValue.Name = k.Names[0].String()
-			}
-			method.ParameterList = append(method.ParameterList, methodValue)
-		}
-		for _, k := range fType.Results.List {
-			r := getTypeExpr(k.Type, nameType[:len(nameType)-10])
-			methodValue := domain.MethodValue{Type: r}
-			if len(k.Names) > 0 {
-				methodValue.Name = k.Names[0].String()
-			}
-			method.ResultList = append(method.ResultList, methodValue)
-		}
+		method.ParameterList = getMethodValues(method.ParameterList, fType.Params, typeParams)
+		method.ResultList = getMethodValues(method.ResultList, fType.Results, typeParams)
 		v.par.Repository.Method = append(v.par.Repository.Method, method)
 	}
 }
 
 // initiateUsecase will initiate detail information which needed in usecase layer
-func (v *doParser) initiateUsecase(field []*ast.Field, nameType string) {
+func (v *doParser) initiateUsecase(field []*ast.Field, typeParams map[string]bool) {
 	for _, j := range field {
+		// embedded interface has no name
+		if len(j.Names) == 0 {
+			continue
+		}
 		method := domain.Method{
 			Name:          j.Names[0].String(),
 			ParameterList: []domain.MethodValue{},
 			ResultList:    []domain.MethodValue{},
+			Doc:           commentText(j.Doc),
 		}
 		fType := getFuncType(j.Type)
-		for _, k := range fType.Params.List {
-			r := getTypeExpr(k.Type, nameType[:len(nameType)-7])
-			methodValue := domain.MethodValue{Type: r}
-			if len(k.Names) > 0 {
-				methodValue.Name = k.Names[0].String()
-			}
-			method.ParameterList = append(method.ParameterList, methodValue)
-		}
-		for _, k := range fType.Results.List {
-			r := getTypeExpr(k.Type, nameType[:len(nameType)-7])
-			methodValue := domain.MethodValue{Type: r}
-			if len(k.Names) > 0 {
-				methodValue.Name = k.Names[0].String()
-			}
-			method.ResultList = append(method.ResultList, methodValue)
-		}
+		method.ParameterList = getMethodValues(method.ParameterList, fType.Params, typeParams)
+		method.ResultList = getMethodValues(method.ResultList, fType.Results, typeParams)
 		v.par.Usecase.Method = append(v.par.Usecase.Method, method)
 	}
 }
@@ -1,12 +1,15 @@
 package parser_test
 
 import (
+	"fmt"
+	goparser "go/parser"
 	"path"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/fs"
 	"github.com/wicaker/cacli/parser"
 
 	"github.com/stretchr/testify/assert"
@@ -17,8 +20,19 @@ func TestParserDomainLayerSuccess(t *testing.T) {
 		pathFile string              = "./mocks/domain/example.go"
 		file                         = path.Base(pathFile)                          //example.go
 		fileName                     = strings.TrimSuffix(file, filepath.Ext(file)) //example
-		pars     domain.ParserDomain = parser.NewParserDomain(fileName)
+		pars     domain.ParserDomain = parser.NewParserDomain(fs.NewFsService(), fileName)
 		expected *domain.Parser      = &domain.Parser{
+			Entity: domain.Entity{
+				Name: "Example",
+				Doc:  "Example struct, models of example table",
+				Fields: []domain.Field{
+					domain.Field{Name: "ID", Type: "uint64", Tags: map[string]string{"json": "id"}},
+					domain.Field{Name: "Name", Type: "string", Tags: map[string]string{"json": "name"}},
+					domain.Field{Name: "CreatedAt", Type: "time.Time", Tags: map[string]string{"db": "created_at", "json": "created_at"}},
+					domain.Field{Name: "UpdatedAt", Type: "time.Time", Tags: map[string]string{"db": "updated_at", "json": "updated_at"}},
+					domain.Field{Name: "DeletedAt", Type: "*time.Time", Tags: map[string]string{"db": "deleted_at", "json": "deleted_at", "pg": ",soft_delete"}, Pointer: true},
+				},
+			},
 			Usecase: domain.Usecase{
 				Name: "ExampleUsecase",
 				Method: []domain.Method{
@@ -135,6 +149,7 @@ func TestParserDomainLayerSuccess(t *testing.T) {
 					},
 				},
 			},
+			Imports: map[string]string{"context": "context", "time": "time"},
 		}
 	)
 
@@ -145,12 +160,45 @@ func TestParserDomainLayerSuccess(t *testing.T) {
 	})
 }
 
+func TestParserDomainEntity(t *testing.T) {
+	var (
+		pars     domain.ParserDomain = parser.NewParserDomain(fs.NewFsService(), "task")
+		expected                     = domain.Entity{
+			Name: "Task",
+			Doc:  "Task represent a task which should be done",
+			Fields: []domain.Field{
+				domain.Field{Name: "Base", Type: "domain.Base", Tags: map[string]string{}, Embedded: true},
+				domain.Field{Name: "ID", Type: "int64", Tags: map[string]string{"json": "id", "db": "id"}, Doc: "ID is primary key of task"},
+				domain.Field{Name: "Title", Type: "string", Tags: map[string]string{"validate": "required,max=100"}},
+				domain.Field{Name: "Note", Type: "string", Tags: map[string]string{"validate": "required,max=100"}},
+				domain.Field{Name: "Labels", Type: "[]string", Tags: map[string]string{"json": "labels,omitempty", "db": "-"}, Slice: true},
+				domain.Field{Name: "Attempts", Type: "[3]int", Tags: map[string]string{"json": "attempts"}},
+				domain.Field{Name: "DoneAt", Type: "*time.Time", Tags: map[string]string{"json": "done_at", "db": "done_at", "pg": ",soft_delete"}, Pointer: true, Comment: "nil if not done yet"},
+			},
+		}
+	)
+
+	t.Run("success, get entity with its fields", func(t *testing.T) {
+		res, err := pars.DomainParser("./mocks/domain/task.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, res.Entity)
+		assert.Equal(t, "done_at", res.Entity.Fields[6].Tag("db"))
+	})
+
+	t.Run("success, get doc of usecase method", func(t *testing.T) {
+		res, err := pars.DomainParser("./mocks/domain/task.go")
+		assert.NoError(t, err)
+		assert.Equal(t, "", res.Usecase.Method[0].Doc)
+		assert.Equal(t, "Done will mark the task as done\n@route PUT /tasks/:id/done", res.Usecase.Method[1].Doc)
+	})
+}
+
 func TestParserDomainLayerFailed(t *testing.T) {
 	var (
 		path2 string              = "./mocks/domain/example2.go"
 		path3 string              = "./mocks/domain/example3.go"
 		path4 string              = "./mocks/domain/example4.go"
-		pars  domain.ParserDomain = parser.NewParserDomain("example")
+		pars  domain.ParserDomain = parser.NewParserDomain(fs.NewFsService(), "example")
 	)
 
 	t.Run("failed, no file or directory ", func(t *testing.T) {
@@ -174,8 +222,164 @@ func TestParserDomainLayerFailed(t *testing.T) {
 	})
 
 	t.Run("failed, filename and interface name not same", func(t *testing.T) {
-		pars = parser.NewParserDomain("example4")
+		pars = parser.NewParserDomain(fs.NewFsService(), "example4")
 		_, err := pars.DomainParser(path4)
 		assert.Error(t, err)
 	})
 }
+
+func TestParserDomainTypeExpr(t *testing.T) {
+	var (
+		types = []struct {
+			src      string
+			expected string
+		}{
+			{src: "uint64", expected: "uint64"},
+			{src: "*Shape", expected: "*domain.Shape"},
+			{src: "[]*time.Time", expected: "[]*time.Time"},
+			{src: "[3]int", expected: "[3]int"},
+			{src: "[Size]byte", expected: "[domain.Size]byte"},
+			{src: "map[string]*Shape", expected: "map[string]*domain.Shape"},
+			{src: "map[K][]T", expected: "map[K][]T"},
+			{src: "chan int", expected: "chan int"},
+			{src: "<-chan Shape", expected: "<-chan domain.Shape"},
+			{src: "chan<- []byte", expected: "chan<- []byte"},
+			{src: "chan (<-chan int)", expected: "chan (<-chan int)"},
+			{src: "func()", expected: "func()"},
+			{src: "func(int) error", expected: "func(int) error"},
+			{src: "func(ctx context.Context, a, b Shape) (*Shape, error)", expected: "func(ctx context.Context, a, b domain.Shape) (*domain.Shape, error)"},
+			{src: "func(args ...string) (n int, err error)", expected: "func(args ...string) (n int, err error)"},
+			{src: "interface{}", expected: "interface{}"},
+			{src: "interface{ Get(id K) (T, error); fmt.Stringer }", expected: "interface{ Get(id K) (T, error); fmt.Stringer }"},
+			{src: "struct{}", expected: "struct{}"},
+			{src: "struct{ ID K `json:\"id\"`; Shape; Items []T }", expected: "struct{ ID K `json:\"id\"`; domain.Shape; Items []T }"},
+			{src: "Page[T]", expected: "domain.Page[T]"},
+			{src: "Pair[K, *Shape]", expected: "domain.Pair[K, *domain.Shape]"},
+			{src: "...Shape", expected: "...domain.Shape"},
+		}
+		genFile = func(typ []string) []byte {
+			src := "package domain\n\n"
+			for _, layer := range []string{"Usecase", "Repository"} {
+				src += "type Shape" + layer + "[K comparable, T any] interface {\n"
+				for i, t := range typ {
+					src += fmt.Sprintf("\tM%d(p %s)\n", i, t)
+				}
+				src += "}\n\n"
+			}
+			return []byte(src)
+		}
+		parse = func(src []byte) (*domain.Parser, error) {
+			newFs := fs.NewDryRunFsService()
+			err := newFs.WriteFile("shape.go", src)
+			if err != nil {
+				return nil, err
+			}
+			return parser.NewParserDomain(newFs, "shape").DomainParser("shape.go")
+		}
+	)
+
+	t.Run("success, print every type expression and parse it back", func(t *testing.T) {
+		var src []string
+		for _, typ := range types {
+			src = append(src, typ.src)
+		}
+
+		res, err := parse(genFile(src))
+		assert.NoError(t, err)
+
+		var printed []string
+		for i, typ := range types {
+			assert.Equal(t, typ.expected, res.Repository.Method[i].ParameterList[0].Type)
+			assert.Equal(t, typ.expected, res.Usecase.Method[i].ParameterList[0].Type)
+
+			_, err := goparser.ParseExpr(strings.TrimPrefix(typ.expected, "..."))
+			assert.NoError(t, err, typ.expected)
+			printed = append(printed, strings.ReplaceAll(typ.expected, "domain.", ""))
+		}
+
+		// the printed types are valid source, so printing them again give the same result
+		res2, err := parse(genFile(printed))
+		assert.NoError(t, err)
+		assert.Equal(t, res.Repository, res2.Repository)
+	})
+}
+
+func TestParserDomainGroupedValues(t *testing.T) {
+	var (
+		src = []byte(`package domain
+
+import "context"
+
+type MoveUsecase interface {
+	Move(ctx context.Context, from, to uint64) (moved, skipped int, err error)
+}
+
+type MoveRepository interface {
+	Move(ctx context.Context, from, to uint64) (moved, skipped int, err error)
+}
+`)
+		expected = domain.Method{
+			Name: "Move",
+			ParameterList: []domain.MethodValue{
+				domain.MethodValue{Name: "ctx", Type: "context.Context"},
+				domain.MethodValue{Name: "from", Type: "uint64"},
+				domain.MethodValue{Name: "to", Type: "uint64"},
+			},
+			ResultList: []domain.MethodValue{
+				domain.MethodValue{Name: "moved", Type: "int"},
+				domain.MethodValue{Name: "skipped", Type: "int"},
+				domain.MethodValue{Name: "err", Type: "error"},
+			},
+		}
+	)
+
+	t.Run("success, split grouped parameters and results into one value per name", func(t *testing.T) {
+		newFs := fs.NewDryRunFsService()
+		err := newFs.WriteFile("move.go", src)
+		assert.NoError(t, err)
+
+		res, err := parser.NewParserDomain(newFs, "move").DomainParser("move.go")
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Method{expected}, res.Usecase.Method)
+		assert.Equal(t, []domain.Method{expected}, res.Repository.Method)
+	})
+}
+
+func TestParserDomainImports(t *testing.T) {
+	src := []byte(`package domain
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/lib/pq"
+	pg "github.com/go-pg/pg/v9"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v2"
+)
+
+type EventUsecase interface {
+	Fetch(ctx context.Context, from, to time.Time) ([]uuid.UUID, error)
+}
+
+type EventRepository interface {
+	Fetch(ctx context.Context, from, to time.Time) ([]uuid.UUID, error)
+}
+`)
+
+	t.Run("success, get import path of the domain file by package name", func(t *testing.T) {
+		newFs := fs.NewDryRunFsService()
+		err := newFs.WriteFile("event.go", src)
+		assert.NoError(t, err)
+
+		res, err := parser.NewParserDomain(newFs, "event").DomainParser("event.go")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"context": "context",
+			"time":    "time",
+			"pg":      "github.com/go-pg/pg/v9",
+			"uuid":    "github.com/google/uuid",
+			"yaml":    "gopkg.in/yaml.v2",
+		}, res.Imports)
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"go/parser"
 	"go/scanner"
 	"go/token"
-	"io/ioutil"
 	"log"
 
 	"github.com/wicaker/cacli/domain"
@@ -13,12 +12,13 @@ import (
 
 type generalParser struct {
 	par domain.Parser
+	fs  domain.FsService
 }
 
 // NewParserGeneral will create new a generalParser object representation of domain.ParserGeneral interface
 // Unlike parser_domain, parser_general can parsing usecase layer, repository layer, transport layer
-func NewParserGeneral() domain.ParserGeneral {
-	return &generalParser{}
+func NewParserGeneral(fs domain.FsService) domain.ParserGeneral {
+	return &generalParser{fs: fs}
 }
 
 // GeneralParser function
@@ -29,7 +29,7 @@ func (v *generalParser) GeneralParser(filePath string) (*domain.Parser, error) {
 		fs = token.NewFileSet()
 	)
 
-	b, err := ioutil.ReadFile(filePath)
+	b, err := v.fs.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +37,7 @@ func (v *generalParser) GeneralParser(filePath string) (*domain.Parser, error) {
 	f := fs.AddFile(filePath, fs.Base(), len(b))
 	s.Init(f, b, nil, scanner.ScanComments)
 
-	p, err := parser.ParseFile(fs, filePath, nil, parser.AllErrors)
+	p, err := parser.ParseFile(fs, filePath, b, parser.AllErrors)
 	if err != nil {
 		log.Printf("could not parse %s: %v", filePath, err)
 		return nil, err
@@ -66,6 +66,11 @@ func (v *generalParser) getFuncDecl(n ast.Node) {
 	switch d := n.(type) {
 	case *ast.FuncDecl:
 		// spew.Dump(d)
+		if d.Recv != nil {
+			v.initiateReceiver(d)
+			return
+		}
+
 		if len(d.Name.String()) > 7 {
 			if d.Name.Name[:3] == "New" && d.Name.Name[len(d.Name.Name)-7:len(d.Name.Name)] == "Usecase" {
 				v.initiateUsecase(d)
@@ -95,24 +100,8 @@ func (v *generalParser) initiateRepository(d *ast.FuncDecl) {
 		ResultList:    []domain.MethodValue{},
 	}
 	fType := getFuncType(d.Type)
-	for _, k := range fType.Params.List {
-		r := getTypeExpr(k.Type, "")
-
-		methodValue := domain.MethodValue{Type: r}
-		if len(k.Names) > 0 {
-			methodValue.Name = k.Names[0].String()
-		}
-		method.ParameterList = append(method.ParameterList, methodValue)
-	}
-	for _, k := range fType.Results.List {
-		r := getTypeExpr(k.Type, "")
-
-		methodValue := domain.MethodValue{Type: r}
-		if len(k.Names) > 0 {
-			methodValue.Name = k.Names[0].String()
-		}
-		method.ResultList = append(method.ResultList, methodValue)
-	}
+	method.ParameterList = getMethodValues(method.ParameterList, fType.Params, nil)
+	method.ResultList = getMethodValues(method.ResultList, fType.Results, nil)
 	v.par.Repository.Method = append(v.par.Repository.Method, method)
 }
 
@@ -124,24 +113,8 @@ func (v *generalParser) initiateUsecase(d *ast.FuncDecl) {
 		ResultList:    []domain.MethodValue{},
 	}
 	fType := getFuncType(d.Type)
-	for _, k := range fType.Params.List {
-		r := getTypeExpr(k.Type, "")
-
-		methodValue := domain.MethodValue{Type: r}
-		if len(k.Names) > 0 {
-			methodValue.Name = k.Names[0].String()
-		}
-		method.ParameterList = append(method.ParameterList, methodValue)
-	}
-	for _, k := range fType.Results.List {
-		r := getTypeExpr(k.Type, "")
-
-		methodValue := domain.MethodValue{Type: r}
-		if len(k.Names) > 0 {
-			methodValue.Name = k.Names[0].String()
-		}
-		method.ResultList = append(method.ResultList, methodValue)
-	}
+	method.ParameterList = getMethodValues(method.ParameterList, fType.Params, nil)
+	method.ResultList = getMethodValues(method.ResultList, fType.Results, nil)
 	v.par.Usecase.Method = append(v.par.Usecase.Method, method)
 }
 
@@ -153,25 +126,56 @@ func (v *generalParser) initiateHandler(d *ast.FuncDecl) {
 		ResultList:    []domain.MethodValue{},
 	}
 	fType := getFuncType(d.Type)
-	for _, k := range fType.Params.List {
-		r := getTypeExpr(k.Type, "")
+	method.ParameterList = getMethodValues(method.ParameterList, fType.Params, nil)
+	method.ResultList = getMethodValues(method.ResultList, fType.Results, nil)
+	v.par.Handler.Method = append(v.par.Handler.Method, method)
+}
 
-		methodValue := domain.MethodValue{Type: r}
-		if len(k.Names) > 0 {
-			methodValue.Name = k.Names[0].String()
-		}
-		method.ParameterList = append(method.ParameterList, methodValue)
+// initiateReceiver will initiate detail information of method which has a receiver
+func (v *generalParser) initiateReceiver(d *ast.FuncDecl) {
+	if len(d.Recv.List) == 0 {
+		return
+	}
+	recvType := d.Recv.List[0].Type
+	if star, ok := recvType.(*ast.StarExpr); ok {
+		recvType = star.X
 	}
-	if fType.Results != nil {
-		for _, k := range fType.Results.List {
-			r := getTypeExpr(k.Type, "")
 
-			methodValue := domain.MethodValue{Type: r}
-			if len(k.Names) > 0 {
-				methodValue.Name = k.Names[0].String()
+	// type parameters of generic receiver, e.g. T of *exampleRepository[T]
+	typeParams := map[string]bool{}
+	switch r := recvType.(type) {
+	case *ast.IndexExpr:
+		recvType = r.X
+		if i, ok := r.Index.(*ast.Ident); ok {
+			typeParams[i.Name] = true
+		}
+	case *ast.IndexListExpr:
+		recvType = r.X
+		for _, index := range r.Indices {
+			if i, ok := index.(*ast.Ident); ok {
+				typeParams[i.Name] = true
 			}
-			method.ResultList = append(method.ResultList, methodValue)
 		}
 	}
-	v.par.Handler.Method = append(v.par.Handler.Method, method)
+	ident, ok := recvType.(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	method := domain.Method{
+		Name:          d.Name.String(),
+		ParameterList: []domain.MethodValue{},
+		ResultList:    []domain.MethodValue{},
+	}
+	fType := getFuncType(d.Type)
+	method.ParameterList = getMethodValues(method.ParameterList, fType.Params, typeParams)
+	method.ResultList = getMethodValues(method.ResultList, fType.Results, typeParams)
+
+	for i := range v.par.Receiver {
+		if v.par.Receiver[i].Name == ident.Name {
+			v.par.Receiver[i].Method = append(v.par.Receiver[i].Method, method)
+			return
+		}
+	}
+	v.par.Receiver = append(v.par.Receiver, domain.Receiver{Name: ident.Name, Method: []domain.Method{method}})
 }
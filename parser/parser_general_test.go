@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/fs"
 	"github.com/wicaker/cacli/parser"
 
 	"github.com/stretchr/testify/assert"
@@ -11,7 +12,7 @@ import (
 
 func TestParserGeneralLayerSuccess(t *testing.T) {
 	var (
-		pars      domain.ParserGeneral = parser.NewParserGeneral()
+		pars      domain.ParserGeneral = parser.NewParserGeneral(fs.NewFsService())
 		expecRepo *domain.Parser       = &domain.Parser{
 			Repository: domain.Repository{
 				Name: "",
@@ -83,7 +84,7 @@ func TestParserGeneralLayerSuccess(t *testing.T) {
 
 func TestParserGeneralLayerFailed(t *testing.T) {
 	var (
-		pars domain.ParserGeneral = parser.NewParserGeneral()
+		pars domain.ParserGeneral = parser.NewParserGeneral(fs.NewFsService())
 	)
 
 	t.Run("failed, no file or directory ", func(t *testing.T) {
@@ -96,3 +97,74 @@ func TestParserGeneralLayerFailed(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestParserGeneralReceiverSuccess(t *testing.T) {
+	var (
+		expecRecv = []domain.Receiver{
+			domain.Receiver{
+				Name: "exampleHandler",
+				Method: []domain.Method{
+					domain.Method{
+						Name: "FetchHandler",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "c", Type: "echo.Context"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "error"},
+						},
+					},
+					domain.Method{
+						Name: "GetByIDHandler",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "c", Type: "echo.Context"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "error"},
+						},
+					},
+					domain.Method{
+						Name: "StoreHandler",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "c", Type: "echo.Context"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "error"},
+						},
+					},
+					domain.Method{
+						Name: "UpdateHandler",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "c", Type: "echo.Context"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "error"},
+						},
+					},
+					domain.Method{
+						Name: "DeleteHandler",
+						ParameterList: []domain.MethodValue{
+							domain.MethodValue{Name: "c", Type: "echo.Context"},
+						},
+						ResultList: []domain.MethodValue{
+							domain.MethodValue{Type: "error"},
+						},
+					},
+				},
+			},
+		}
+	)
+
+	t.Run("success, get receiver methods of transport layer", func(t *testing.T) {
+		res, err := parser.NewParserGeneral(fs.NewFsService()).GeneralParser("./mocks/transport/rest/example_handler.go")
+		assert.NoError(t, err)
+		assert.Equal(t, expecRecv, res.Receiver)
+	})
+
+	t.Run("success, get receiver methods of usecase layer", func(t *testing.T) {
+		res, err := parser.NewParserGeneral(fs.NewFsService()).GeneralParser("./mocks/usecase/example_usecase.go")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(res.Receiver))
+		assert.Equal(t, "exampleUsecase", res.Receiver[0].Name)
+		assert.Equal(t, domain.MockParser.Usecase.Method, res.Receiver[0].Method)
+	})
+}
@@ -0,0 +1,177 @@
+/*
+Package spec used to read and validate the declarative specification of service
+*/
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"regexp"
+	"strings"
+
+	"github.com/wicaker/cacli/domain"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	entityNameRegexp = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	fieldNameRegexp  = regexp.MustCompile(`^[A-Z][a-zA-Z0-9_]*$`)
+	routeRegexp      = regexp.MustCompile(`^(GET|POST|PUT|PATCH|DELETE) /[a-zA-Z0-9_\-/:.]*$`)
+)
+
+type caSpec struct {
+}
+
+// NewSpecService will create new a caSpec object representation of domain.SpecService interface
+func NewSpecService() domain.SpecService {
+	return &caSpec{}
+}
+
+// Read is a method for read and validate specification file, format of file is detected from its extension
+func (s *caSpec) Read(fileName string) (*domain.Spec, error) {
+	v := viper.New()
+	v.SetConfigFile(fileName)
+	err := v.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &domain.Spec{}
+	err = v.UnmarshalExact(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not valid: %s", fileName, err)
+	}
+
+	return spec, nil
+}
+
+// validate will collect every invalid value in spec, so all of them can be fixed at once
+func validate(spec *domain.Spec) error {
+	var errs []string
+
+	if spec.Service == "" {
+		errs = append(errs, "service is required")
+	}
+	if spec.Module == "" {
+		errs = append(errs, "module is required")
+	}
+
+	switch spec.Database {
+	case "":
+		errs = append(errs, "database is required, choose one of: gopg, gorm, sqlx, sql, mongod")
+	case domain.GoPg, domain.Gorm, domain.Sqlx, domain.SQL, domain.Mongod:
+	default:
+		errs = append(errs, fmt.Sprintf("database %q is not supported, choose one of: gopg, gorm, sqlx, sql, mongod", spec.Database))
+	}
+
+	switch spec.Dialect {
+	case "", domain.MySQL, domain.Postgres:
+	default:
+		errs = append(errs, fmt.Sprintf("dialect %q is not supported, choose one of: mysql, postgres", spec.Dialect))
+	}
+
+	switch spec.Transports.Rest {
+	case "":
+		errs = append(errs, "transports.rest is required, choose one of: echo, gin, gorilla mux, net/http, no")
+	case domain.Echo, domain.Gin, domain.GorillaMux, domain.NetHTTP, "no":
+	default:
+		errs = append(errs, fmt.Sprintf("transports.rest %q is not supported, choose one of: echo, gin, gorilla mux, net/http, no", spec.Transports.Rest))
+	}
+
+	entityNames := map[string]bool{}
+	for i, e := range spec.Entities {
+		path := fmt.Sprintf("entities[%d]", i)
+		if !entityNameRegexp.MatchString(e.Name) {
+			errs = append(errs, path+".name is required, must start with lowercase letter and only contain letters or digits")
+		}
+		if entityNames[e.Name] {
+			errs = append(errs, fmt.Sprintf("%s.name %q is declared more than once", path, e.Name))
+		}
+		entityNames[e.Name] = true
+
+		for j, f := range e.Fields {
+			fieldPath := fmt.Sprintf("%s.fields[%d]", path, j)
+			if !fieldNameRegexp.MatchString(f.Name) {
+				errs = append(errs, fieldPath+".name is required and must be exported")
+			}
+			if err := validateType(f.Type); err != nil {
+				errs = append(errs, fieldPath+".type "+err.Error())
+			}
+		}
+
+		for j, m := range e.Methods {
+			methodPath := fmt.Sprintf("%s.methods[%d]", path, j)
+			if !fieldNameRegexp.MatchString(m.Name) {
+				errs = append(errs, methodPath+".name is required and must be exported")
+			}
+			for k, p := range m.Params {
+				param := strings.Fields(p)
+				if len(param) < 2 {
+					errs = append(errs, fmt.Sprintf("%s.params[%d] must be written as `name type`", methodPath, k))
+					continue
+				}
+				if err := validateType(strings.Join(param[1:], " ")); err != nil {
+					errs = append(errs, fmt.Sprintf("%s.params[%d] %s", methodPath, k, err))
+				}
+			}
+			for k, r := range m.Results {
+				if err := validateType(r); err != nil {
+					errs = append(errs, fmt.Sprintf("%s.results[%d] %s", methodPath, k, err))
+				}
+			}
+			if m.Route != "" && !routeRegexp.MatchString(m.Route) {
+				errs = append(errs, fmt.Sprintf("%s.route %q must be written as `VERB /path`, e.g. `PUT /tasks/:id/done`", methodPath, m.Route))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func validateType(typ string) error {
+	if typ == "" {
+		return errors.New("is required")
+	}
+	expr, err := parser.ParseExpr(typ)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid go type", typ)
+	}
+	if !isSupportedType(expr) {
+		return fmt.Errorf("%q is not supported, only named, pointer, slice, array, map and interface{} types are able to be generated", typ)
+	}
+	return nil
+}
+
+// isSupportedType will check the type against the expressions which the generator is able to write
+func isSupportedType(expr ast.Expr) bool {
+	switch d := expr.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		_, ok := d.X.(*ast.Ident)
+		return ok
+	case *ast.StarExpr:
+		return isSupportedType(d.X)
+	case *ast.ArrayType:
+		if _, ok := d.Len.(*ast.BasicLit); d.Len != nil && !ok {
+			return false
+		}
+		return isSupportedType(d.Elt)
+	case *ast.MapType:
+		return isSupportedType(d.Key) && isSupportedType(d.Value)
+	case *ast.InterfaceType:
+		return d.Methods == nil || len(d.Methods.List) == 0
+	}
+	return false
+}
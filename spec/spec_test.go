@@ -0,0 +1,77 @@
+package spec_test
+
+import (
+	"testing"
+
+	"github.com/wicaker/cacli/domain"
+	"github.com/wicaker/cacli/spec"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadSpec(t *testing.T) {
+	var (
+		specService = spec.NewSpecService()
+		expected    = &domain.Spec{
+			Service:  "task",
+			Module:   "github.com/example/task",
+			Database: domain.Sqlx,
+			Dialect:  domain.Postgres,
+			Transports: domain.SpecTransport{
+				Rest: domain.Gin,
+				Grpc: true,
+			},
+			Entities: []domain.SpecEntity{
+				domain.SpecEntity{
+					Name: "task",
+					Fields: []domain.SpecField{
+						domain.SpecField{Name: "ID", Type: "uint64"},
+						domain.SpecField{Name: "Title", Type: "string", Tags: map[string]string{"json": "title"}},
+					},
+					Methods: []domain.SpecMethod{
+						domain.SpecMethod{Name: "Fetch", Params: []string{"ctx context.Context"}, Results: []string{"[]*Task", "error"}},
+						domain.SpecMethod{Name: "Done", Params: []string{"ctx context.Context", "id uint64"}, Results: []string{"error"}, Route: "PUT /tasks/:id/done"},
+					},
+				},
+			},
+		}
+	)
+
+	t.Run("success, should read the specification", func(t *testing.T) {
+		res, err := specService.Read("./mocks/service.yaml")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("failed, because required value is missing or invalid", func(t *testing.T) {
+		_, err := specService.Read("./mocks/invalid.yaml")
+		assert.Error(t, err)
+		for _, msg := range []string{
+			"module is required",
+			`database "mysql" is not supported`,
+			`dialect "oracle" is not supported`,
+			"transports.rest is required",
+			"entities[0].name is required",
+			"entities[0].fields[0].name is required and must be exported",
+			`entities[0].fields[0].type "map[string" is not a valid go type`,
+			`entities[0].fields[1].type "chan int" is not supported`,
+			`entities[0].fields[2].type "struct{}" is not supported`,
+			`entities[0].methods[0].params[1] "func()" is not supported`,
+			"entities[0].methods[0].params[0] must be written as `name type`",
+			`entities[0].methods[0].route "/tasks/done" must be written as` + " `VERB /path`",
+		} {
+			assert.Contains(t, err.Error(), msg)
+		}
+	})
+
+	t.Run("failed, because unknown key", func(t *testing.T) {
+		_, err := specService.Read("./mocks/unknown_key.yaml")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "modul")
+	})
+
+	t.Run("failed, because file not found", func(t *testing.T) {
+		_, err := specService.Read("./mocks/not_found.yaml")
+		assert.Error(t, err)
+	})
+}